@@ -2,12 +2,15 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
 	"time"
 
 	"api-jet-manager/internal/api/routes"
 	"api-jet-manager/internal/config"
 	"api-jet-manager/internal/infrastructure/database"
+	"api-jet-manager/internal/infrastructure/realtime"
 )
 
 func main() {
@@ -21,9 +24,14 @@ func main() {
 	}
 	log.Printf("Configurações carregadas com sucesso. Host do BD: %s, Modo Gin: %s", cfg.BLUEPRINT_DB_HOST, cfg.GinMode)
 
+	// Subcomando "migrate" apenas gerencia o esquema e encerra
+	migrateOnly := len(os.Args) > 1 && os.Args[1] == "migrate"
+
 	// Aguardar alguns segundos para garantir que o banco de dados esteja pronto
-	log.Println("Aguardando o banco de dados ficar disponível...")
-	time.Sleep(5 * time.Second)
+	if !migrateOnly {
+		log.Println("Aguardando o banco de dados ficar disponível...")
+		time.Sleep(5 * time.Second)
+	}
 
 	// Conecta ao banco de dados
 	log.Println("Conectando ao banco de dados...")
@@ -39,6 +47,13 @@ func main() {
 	}()
 	log.Println("Conexão com o banco de dados estabelecida com sucesso")
 
+	if migrateOnly {
+		if err := runMigrate(db, os.Args[2:]); err != nil {
+			log.Fatalf("Falha ao executar migrate: %v", err)
+		}
+		return
+	}
+
 	// Executa migrações
 	log.Println("Executando migrações do banco de dados...")
 	if err := database.RunMigrations(db); err != nil {
@@ -46,9 +61,17 @@ func main() {
 	}
 	log.Println("Migrações executadas com sucesso")
 
+	// Eventos em tempo real: entregues localmente e repassados às outras réplicas via LISTEN/NOTIFY
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hub := realtime.NewHub()
+	publisher := realtime.NewPostgresPublisher(db, hub)
+	go publisher.Listen(ctx, cfg.DatabaseDSN())
+
 	// Configuração do router e inicialização do servidor
 	log.Println("Configurando rotas da API...")
-	router := routes.SetupRouter(cfg, db)
+	router := routes.SetupRouter(ctx, cfg, db, hub, publisher)
 
 	log.Printf("Iniciando servidor na porta %s...", cfg.ServerAddress)
 	if err := router.Run(cfg.ServerAddress); err != nil {
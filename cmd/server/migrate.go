@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"api-jet-manager/internal/infrastructure/database"
+	"api-jet-manager/migrations"
+)
+
+const migrateUsage = "uso: main migrate up | down [n] | status"
+
+// runMigrate executa o subcomando migrate: up aplica as pendentes,
+// down reverte as últimas n (1 por padrão) e status lista as versões
+func runMigrate(db *database.PostgresDB, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	migrator, err := database.NewMigrator(db, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migração(ões) aplicada(s)\n", applied)
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("quantidade inválida: %s", args[1])
+			}
+		}
+
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migração(ões) revertida(s)\n", reverted)
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			appliedAt := "pendente"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%02d  %-40s %s\n", status.Version, status.Name, appliedAt)
+		}
+
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	return nil
+}
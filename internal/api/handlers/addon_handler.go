@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OptionRequest struct {
+	Name        string       `json:"name" binding:"required"`
+	Price       models.Money `json:"price" binding:"min=0"`
+	Active      *bool        `json:"active"`
+	MaxQuantity int          `json:"max_quantity" binding:"min=0"`
+}
+
+type AddonRequest struct {
+	Title         string               `json:"title" binding:"required"`
+	SelectionType models.SelectionType `json:"selection_type" binding:"required"`
+	MinSelections int                  `json:"min_selections" binding:"min=0"`
+	MaxSelections int                  `json:"max_selections" binding:"min=0"`
+	Required      bool                 `json:"required"`
+	PriceMethod   models.PriceMethod   `json:"price_method"`
+	Options       []OptionRequest      `json:"options" binding:"dive"`
+}
+
+type AddonHandler struct {
+	addonService *services.AddonService
+}
+
+func NewAddonHandler(addonService *services.AddonService) *AddonHandler {
+	return &AddonHandler{
+		addonService: addonService,
+	}
+}
+
+// toOption converte a requisição em uma opção, aplicando os valores padrão
+func (r OptionRequest) toOption(option *models.Option) {
+	option.Name = r.Name
+	option.Price = r.Price
+	option.Active = true
+	if r.Active != nil {
+		option.Active = *r.Active
+	}
+	option.MaxQuantity = r.MaxQuantity
+	if option.MaxQuantity == 0 {
+		option.MaxQuantity = 1
+	}
+}
+
+func (r AddonRequest) toAddon(addon *models.Addon) {
+	addon.Title = r.Title
+	addon.SelectionType = r.SelectionType
+	addon.MinSelections = r.MinSelections
+	addon.MaxSelections = r.MaxSelections
+	addon.Required = r.Required
+	addon.PriceMethod = r.PriceMethod
+	if addon.PriceMethod == "" {
+		addon.PriceMethod = models.Sum
+	}
+}
+
+// addonError traduz os erros do serviço de complementos para respostas HTTP
+func addonError(c *gin.Context, err error, notFound string) {
+	if errors.Is(err, services.ErrInvalidAddon) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, repositories.ErrProductNotFound) || errors.Is(err, repositories.ErrAddonNotFound) || errors.Is(err, repositories.ErrOptionNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": notFound})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// Create cria um grupo de complementos para o produto, opcionalmente com suas opções
+func (h *AddonHandler) Create(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	productID, ok := uuidParam(c, "product_id", "product")
+	if !ok {
+		return
+	}
+
+	var req AddonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	addon := &models.Addon{ProductID: productID}
+	req.toAddon(addon)
+
+	for _, optionReq := range req.Options {
+		var option models.Option
+		optionReq.toOption(&option)
+		addon.Options = append(addon.Options, option)
+	}
+
+	if err := h.addonService.Create(restaurantID, addon); err != nil {
+		addonError(c, err, "product not found")
+		return
+	}
+
+	c.JSON(http.StatusCreated, addon)
+}
+
+// List retorna os complementos do produto com suas opções
+func (h *AddonHandler) List(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	productID, ok := uuidParam(c, "product_id", "product")
+	if !ok {
+		return
+	}
+
+	addons, err := h.addonService.ListByProduct(restaurantID, productID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, addons)
+}
+
+func (h *AddonHandler) GetByID(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	addonID, ok := uuidParam(c, "addon_id", "addon")
+	if !ok {
+		return
+	}
+
+	addon, err := h.addonService.GetByID(restaurantID, addonID)
+	if err != nil || addon.ProductID.String() != c.Param("product_id") {
+		c.JSON(http.StatusNotFound, gin.H{"error": "addon not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, addon)
+}
+
+// Update altera as regras do complemento; as opções são mantidas pelas rotas próprias
+func (h *AddonHandler) Update(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	addonID, ok := uuidParam(c, "addon_id", "addon")
+	if !ok {
+		return
+	}
+
+	var req AddonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	addon, err := h.addonService.GetByID(restaurantID, addonID)
+	if err != nil || addon.ProductID.String() != c.Param("product_id") {
+		c.JSON(http.StatusNotFound, gin.H{"error": "addon not found"})
+		return
+	}
+
+	req.toAddon(addon)
+
+	if err := h.addonService.Update(restaurantID, addon); err != nil {
+		addonError(c, err, "addon not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, addon)
+}
+
+func (h *AddonHandler) Delete(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	addonID, ok := uuidParam(c, "addon_id", "addon")
+	if !ok {
+		return
+	}
+
+	addon, err := h.addonService.GetByID(restaurantID, addonID)
+	if err != nil || addon.ProductID.String() != c.Param("product_id") {
+		c.JSON(http.StatusNotFound, gin.H{"error": "addon not found"})
+		return
+	}
+
+	if err := h.addonService.Delete(restaurantID, addonID); err != nil {
+		addonError(c, err, "addon not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "addon deleted successfully"})
+}
+
+// CreateOption adiciona uma opção ao complemento
+func (h *AddonHandler) CreateOption(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	addonID, ok := uuidParam(c, "addon_id", "addon")
+	if !ok {
+		return
+	}
+
+	var req OptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	option := &models.Option{AddonID: addonID}
+	req.toOption(option)
+
+	if err := h.addonService.CreateOption(restaurantID, option); err != nil {
+		addonError(c, err, "addon not found")
+		return
+	}
+
+	c.JSON(http.StatusCreated, option)
+}
+
+func (h *AddonHandler) ListOptions(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	addonID, ok := uuidParam(c, "addon_id", "addon")
+	if !ok {
+		return
+	}
+
+	options, err := h.addonService.ListOptions(restaurantID, addonID)
+	if err != nil {
+		addonError(c, err, "addon not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, options)
+}
+
+func (h *AddonHandler) UpdateOption(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	addonID, ok := uuidParam(c, "addon_id", "addon")
+	if !ok {
+		return
+	}
+
+	optionID, ok := uuidParam(c, "option_id", "option")
+	if !ok {
+		return
+	}
+
+	var req OptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	option, err := h.addonService.GetOption(restaurantID, addonID, optionID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "option not found"})
+		return
+	}
+
+	req.toOption(option)
+
+	if err := h.addonService.UpdateOption(restaurantID, option); err != nil {
+		addonError(c, err, "option not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, option)
+}
+
+func (h *AddonHandler) DeleteOption(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	addonID, ok := uuidParam(c, "addon_id", "addon")
+	if !ok {
+		return
+	}
+
+	optionID, ok := uuidParam(c, "option_id", "option")
+	if !ok {
+		return
+	}
+
+	if err := h.addonService.DeleteOption(restaurantID, addonID, optionID); err != nil {
+		addonError(c, err, "option not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "option deleted successfully"})
+}
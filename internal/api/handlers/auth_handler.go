@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type SwitchRestaurantRequest struct {
+	RestaurantID uuid.UUID `json:"restaurant_id" binding:"required"`
+}
+
+type AuthHandler struct {
+	authService       *services.AuthService
+	restaurantService *services.RestaurantService
+}
+
+func NewAuthHandler(authService *services.AuthService, restaurantService *services.RestaurantService) *AuthHandler {
+	return &AuthHandler{
+		authService:       authService,
+		restaurantService: restaurantService,
+	}
+}
+
+// clientInfo identifica o dispositivo da requisição para registro na sessão
+func clientInfo(c *gin.Context) services.ClientInfo {
+	return services.ClientInfo{UserAgent: c.Request.UserAgent(), IP: c.ClientIP()}
+}
+
+// restaurantActive verifica se o restaurante do usuário está ativo (exceto para superadmin).
+// Em caso de falha a resposta de erro já é escrita e retorna false.
+func restaurantActive(c *gin.Context, restaurantService *services.RestaurantService, user *models.User) bool {
+	if user.Type == models.UserTypeSuperAdmin || user.RestaurantID == nil {
+		return true
+	}
+
+	isActive, err := restaurantService.IsRestaurantActive(*user.RestaurantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error verifying restaurant status"})
+		return false
+	}
+	if !isActive {
+		c.JSON(http.StatusForbidden, gin.H{"error": "your restaurant subscription is inactive or expired"})
+		return false
+	}
+	return true
+}
+
+// Refresh troca o refresh token por um novo par de tokens; o token usado deixa de valer
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, user, err := h.authService.Refresh(req.RefreshToken, clientInfo(c))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRefreshToken) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !restaurantActive(c, h.restaurantService, user) {
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// SwitchRestaurant troca o restaurante ativo. A sessão atual é encerrada e os novos tokens
+// valem apenas para o restaurante escolhido.
+func (h *AuthHandler) SwitchRestaurant(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	// O token do login por PIN vale só no terminal e não pode abrir uma sessão com refresh token
+	if c.GetString("device_id") != "" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "PIN sessions cannot switch restaurants"})
+		return
+	}
+
+	var req SwitchRestaurantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	restaurant, err := h.restaurantService.GetByID(req.RestaurantID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "restaurant not found"})
+		return
+	}
+
+	// A assinatura é verificada antes de encerrar a sessão atual
+	if !restaurantActive(c, h.restaurantService, &models.User{Type: actor.Type, RestaurantID: &restaurant.ID}) {
+		return
+	}
+
+	expiresAt := time.Now()
+	if value, ok := c.Get("token_expires_at"); ok {
+		expiresAt = value.(time.Time)
+	}
+
+	tokens, user, err := h.authService.Switch(actor.ID, c.GetString("token_id"), c.GetString("session_id"), expiresAt, restaurant.ID, clientInfo(c))
+	if err != nil {
+		if errors.Is(err, services.ErrNotMember) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token":              tokens.AccessToken,
+		"expires_at":         tokens.ExpiresAt,
+		"refresh_token":      tokens.RefreshToken,
+		"refresh_expires_at": tokens.RefreshExpiresAt,
+		"user": gin.H{
+			"id":            user.ID,
+			"name":          user.Name,
+			"email":         user.Email,
+			"type":          user.Type,
+			"restaurant_id": user.RestaurantID,
+		},
+		"restaurant": restaurant,
+	})
+}
+
+// Logout encerra a sessão do token usado na requisição
+func (h *AuthHandler) Logout(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	expiresAt := time.Now()
+	if value, ok := c.Get("token_expires_at"); ok {
+		expiresAt = value.(time.Time)
+	}
+
+	if err := h.authService.Logout(userID, c.GetString("token_id"), c.GetString("session_id"), expiresAt); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "logged out successfully"})
+}
+
+// LogoutAll encerra todas as sessões do usuário autenticado, em qualquer dispositivo
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.authService.RevokeUser(userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "all sessions revoked"})
+}
+
+// RevokeUserSessions encerra as sessões de um usuário abertas no restaurante, por exemplo após um desligamento
+func (h *AuthHandler) RevokeUserSessions(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := uuidParam(c, "user_id", "user")
+	if !ok {
+		return
+	}
+
+	if err := h.authService.RevokeRestaurantUser(restaurantID, userID); err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "user sessions revoked"})
+}
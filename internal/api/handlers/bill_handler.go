@@ -0,0 +1,398 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// BillRequest usa o vencimento no formato YYYY-MM-DD, como os lançamentos manuais
+type BillRequest struct {
+	Kind         models.BillKind            `json:"kind" binding:"required"`
+	Category     models.TransactionCategory `json:"category" binding:"required"`
+	Description  string                     `json:"description" binding:"required"`
+	Counterparty string                     `json:"counterparty"`
+	SupplierID   *uuid.UUID                 `json:"supplier_id"`
+	Document     string                     `json:"document"`
+	Amount       models.Money               `json:"amount" binding:"required,gt=0"`
+	DueDate      string                     `json:"due_date" binding:"required"`
+	Notes        string                     `json:"notes"`
+}
+
+// SettleBillRequest registra uma baixa; sem amount baixa todo o saldo
+type SettleBillRequest struct {
+	Amount        models.Money `json:"amount"`
+	PaymentMethod string       `json:"payment_method"`
+	PaidAt        *time.Time   `json:"paid_at"`
+	Notes         string       `json:"notes"`
+}
+
+type BillAttachmentRequest struct {
+	FileName    string `json:"file_name" binding:"required"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	URL         string `json:"url" binding:"required"`
+}
+
+// input converte a requisição; em caso de falha a resposta de erro já é escrita e ok retorna false
+func (req *BillRequest) input(c *gin.Context) (services.BillInput, bool) {
+	dueDate, err := time.Parse("2006-01-02", req.DueDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid due date format (required: YYYY-MM-DD)"})
+		return services.BillInput{}, false
+	}
+
+	return services.BillInput{
+		Kind:         req.Kind,
+		Category:     req.Category,
+		Description:  req.Description,
+		Counterparty: req.Counterparty,
+		SupplierID:   req.SupplierID,
+		Document:     req.Document,
+		Amount:       req.Amount,
+		DueDate:      dueDate,
+		Notes:        req.Notes,
+	}, true
+}
+
+type BillHandler struct {
+	billService *services.BillService
+}
+
+func NewBillHandler(billService *services.BillService) *BillHandler {
+	return &BillHandler{
+		billService: billService,
+	}
+}
+
+// billError traduz os erros do serviço de contas para respostas HTTP
+func billError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidBill):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrBillStatus):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrBillNotFound), errors.Is(err, repositories.ErrBillAttachmentNotFound),
+		errors.Is(err, repositories.ErrSupplierNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// billKind lê ?kind=; em caso de falha a resposta de erro já é escrita e ok retorna false
+func billKind(c *gin.Context) (models.BillKind, bool) {
+	kind := models.BillKind(c.Query("kind"))
+	if kind != "" && !kind.Valid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid kind"})
+		return "", false
+	}
+	return kind, true
+}
+
+func (h *BillHandler) Create(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req BillRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input, ok := req.input(c)
+	if !ok {
+		return
+	}
+
+	bill, err := h.billService.Create(restaurantID, userID, input)
+	if err != nil {
+		billError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, bill)
+}
+
+// List filtra por ?kind=, ?status= (inclusive overdue), ?supplier_id=, ?due_from= e ?due_to= (YYYY-MM-DD)
+func (h *BillHandler) List(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	kind, ok := billKind(c)
+	if !ok {
+		return
+	}
+	filter := repositories.BillFilter{Kind: kind}
+
+	if value := c.Query("supplier_id"); value != "" {
+		supplierID, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid supplier ID"})
+			return
+		}
+		filter.SupplierID = &supplierID
+	}
+
+	for param, target := range map[string]**time.Time{"due_from": &filter.DueFrom, "due_to": &filter.DueTo} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param + " date format (required: YYYY-MM-DD)"})
+			return
+		}
+		*target = &parsed
+	}
+
+	switch status := models.BillStatus(c.Query("status")); status {
+	case "", models.BillStatusOpen, models.BillStatusPartiallyPaid, models.BillStatusPaid,
+		models.BillStatusOverdue, models.BillStatusCancelled:
+		filter.Status = status
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+		return
+	}
+
+	bills, err := h.billService.List(restaurantID, filter)
+	if err != nil {
+		billError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bills)
+}
+
+// DueThisWeek lista as contas em aberto que vencem até domingo; aceita ?kind=
+func (h *BillHandler) DueThisWeek(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	kind, ok := billKind(c)
+	if !ok {
+		return
+	}
+
+	bills, err := h.billService.DueThisWeek(restaurantID, kind)
+	if err != nil {
+		billError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bills)
+}
+
+// Overdue lista as contas vencidas; aceita ?kind=
+func (h *BillHandler) Overdue(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	kind, ok := billKind(c)
+	if !ok {
+		return
+	}
+
+	bills, err := h.billService.Overdue(restaurantID, kind)
+	if err != nil {
+		billError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bills)
+}
+
+func (h *BillHandler) GetByID(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, ok := uuidParam(c, "bill_id", "bill")
+	if !ok {
+		return
+	}
+
+	bill, err := h.billService.Get(restaurantID, id)
+	if err != nil {
+		billError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bill)
+}
+
+func (h *BillHandler) Update(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, ok := uuidParam(c, "bill_id", "bill")
+	if !ok {
+		return
+	}
+
+	var req BillRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input, ok := req.input(c)
+	if !ok {
+		return
+	}
+
+	bill, err := h.billService.Update(restaurantID, id, input)
+	if err != nil {
+		billError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bill)
+}
+
+// Settle registra uma baixa total ou parcial da conta
+func (h *BillHandler) Settle(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, ok := uuidParam(c, "bill_id", "bill")
+	if !ok {
+		return
+	}
+
+	// O corpo é opcional: sem ele a conta é quitada pelo saldo
+	var req SettleBillRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bill, err := h.billService.Settle(restaurantID, id, userID, services.SettlementInput{
+		Amount:        req.Amount,
+		PaymentMethod: req.PaymentMethod,
+		PaidAt:        req.PaidAt,
+		Notes:         req.Notes,
+	})
+	if err != nil {
+		billError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bill)
+}
+
+func (h *BillHandler) Cancel(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, ok := uuidParam(c, "bill_id", "bill")
+	if !ok {
+		return
+	}
+
+	bill, err := h.billService.Cancel(restaurantID, id)
+	if err != nil {
+		billError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bill)
+}
+
+// AddAttachment registra os dados de um arquivo já armazenado, como a nota ou o boleto
+func (h *BillHandler) AddAttachment(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, ok := uuidParam(c, "bill_id", "bill")
+	if !ok {
+		return
+	}
+
+	var req BillAttachmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	attachment, err := h.billService.AddAttachment(restaurantID, id, userID, services.AttachmentInput{
+		FileName:    req.FileName,
+		ContentType: req.ContentType,
+		Size:        req.Size,
+		URL:         req.URL,
+	})
+	if err != nil {
+		billError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+func (h *BillHandler) DeleteAttachment(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, ok := uuidParam(c, "bill_id", "bill")
+	if !ok {
+		return
+	}
+
+	attachmentID, ok := uuidParam(c, "attachment_id", "attachment")
+	if !ok {
+		return
+	}
+
+	if err := h.billService.DeleteAttachment(restaurantID, id, attachmentID); err != nil {
+		billError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "attachment deleted successfully"})
+}
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type OpenCashSessionRequest struct {
+	Register     string       `json:"register"` // Vazio usa o caixa padrão
+	OpeningFloat models.Money `json:"opening_float"`
+	Notes        string       `json:"notes"`
+}
+
+type CashMovementRequest struct {
+	Type   models.CashMovementType `json:"type" binding:"required"`
+	Amount models.Money            `json:"amount"`
+	Reason string                  `json:"reason"`
+}
+
+// CloseCashSessionRequest traz os valores contados por forma de pagamento
+type CloseCashSessionRequest struct {
+	Counted map[models.PaymentMethod]models.Money `json:"counted" binding:"required"`
+	Notes   string                                `json:"notes"`
+}
+
+type CashHandler struct {
+	cashService *services.CashService
+}
+
+func NewCashHandler(cashService *services.CashService) *CashHandler {
+	return &CashHandler{
+		cashService: cashService,
+	}
+}
+
+// cashError traduz os erros do serviço de caixa para respostas HTTP
+func cashError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidCashSession):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCashSessionClosed), errors.Is(err, services.ErrCashRegisterInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrCashSessionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// Open abre um turno de caixa com o fundo de troco informado
+func (h *CashHandler) Open(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req OpenCashSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := h.cashService.Open(restaurantID, userID, req.Register, req.OpeningFloat, req.Notes)
+	if err != nil {
+		cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, session)
+}
+
+// List aceita os filtros ?register=, ?status= e ?from= e ?to= (RFC3339) sobre a abertura
+func (h *CashHandler) List(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	filter := repositories.CashSessionFilter{Register: c.Query("register")}
+	for param, target := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param + " date"})
+			return
+		}
+		*target = &parsed
+	}
+
+	switch status := models.CashSessionStatus(c.Query("status")); status {
+	case "", models.CashSessionStatusOpen, models.CashSessionStatusClosed:
+		filter.Status = status
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+		return
+	}
+
+	sessions, err := h.cashService.List(restaurantID, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// Summary retorna o turno com os valores esperados em cada forma de pagamento
+func (h *CashHandler) Summary(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	sessionID, ok := uuidParam(c, "session_id", "cash session")
+	if !ok {
+		return
+	}
+
+	summary, err := h.cashService.Summary(restaurantID, sessionID)
+	if err != nil {
+		cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// AddMovement registra um suprimento ou uma sangria no turno
+func (h *CashHandler) AddMovement(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	sessionID, ok := uuidParam(c, "session_id", "cash session")
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req CashMovementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	movement, err := h.cashService.AddMovement(restaurantID, sessionID, userID, req.Type, req.Amount, req.Reason)
+	if err != nil {
+		cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, movement)
+}
+
+// Close fecha o turno conferindo os valores contados contra os esperados
+func (h *CashHandler) Close(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	sessionID, ok := uuidParam(c, "session_id", "cash session")
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req CloseCashSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	summary, err := h.cashService.Close(restaurantID, sessionID, userID, services.CashCloseInput{
+		Counted: req.Counted,
+		Notes:   req.Notes,
+	})
+	if err != nil {
+		cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// restaurantIDFromContext obtém o restaurante definido pelo AuthMiddleware.
+// Em caso de falha a resposta de erro já é escrita e ok retorna false.
+func restaurantIDFromContext(c *gin.Context) (uuid.UUID, bool) {
+	restaurantIDRaw, _ := c.Get("restaurant_id")
+	restaurantIDPtr, ok := restaurantIDRaw.(*uuid.UUID)
+	if !ok || restaurantIDPtr == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "restaurant ID is nil"})
+		return uuid.Nil, false
+	}
+	return *restaurantIDPtr, true
+}
+
+// uuidParam lê e converte um parâmetro de rota para UUID.
+// Em caso de falha a resposta de erro já é escrita e ok retorna false.
+func uuidParam(c *gin.Context, name, label string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + label + " ID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// actorFromContext obtém o usuário autenticado definido pelo AuthMiddleware; ok é false sem autenticação
+func actorFromContext(c *gin.Context) (services.Actor, bool) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		return services.Actor{}, false
+	}
+	userType, ok := c.Get("user_type")
+	if !ok {
+		return services.Actor{}, false
+	}
+	actorType, ok := userType.(models.UserType)
+	if !ok {
+		return services.Actor{}, false
+	}
+	return services.Actor{ID: userID, Type: actorType}, true
+}
+
+// requirePermission verifica uma permissão que depende do conteúdo da requisição, como o status pedido.
+// Em caso de falha a resposta de erro já é escrita e retorna false.
+func requirePermission(c *gin.Context, roleService *services.RoleService, restaurantID uuid.UUID, permission models.Permission) bool {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return false
+	}
+
+	permissions, err := roleService.Permissions(restaurantID, actor)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error verifying permissions"})
+		return false
+	}
+	if !slices.Contains(permissions, permission) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions", "permission": permission})
+		return false
+	}
+	return true
+}
+
+// deviceFromContext obtém o terminal definido pelo DeviceAuthMiddleware.
+// Em caso de falha a resposta de erro já é escrita e ok retorna false.
+func deviceFromContext(c *gin.Context) (*models.Device, bool) {
+	value, _ := c.Get("device")
+	device, ok := value.(*models.Device)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return nil, false
+	}
+	return device, true
+}
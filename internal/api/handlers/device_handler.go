@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeviceRequest descreve um terminal; sem kind vale pos e sem auto_lock_minutes o terminal bloqueia após 5 minutos
+type DeviceRequest struct {
+	Name            string            `json:"name" binding:"required"`
+	Kind            models.DeviceKind `json:"kind"`
+	AutoLockMinutes int               `json:"auto_lock_minutes"`
+}
+
+type PairDeviceRequest struct {
+	PairingCode string `json:"pairing_code" binding:"required"`
+}
+
+func (req *DeviceRequest) input() services.DeviceInput {
+	return services.DeviceInput{
+		Name:            req.Name,
+		Kind:            req.Kind,
+		AutoLockMinutes: req.AutoLockMinutes,
+	}
+}
+
+type DeviceHandler struct {
+	deviceService *services.DeviceService
+}
+
+func NewDeviceHandler(deviceService *services.DeviceService) *DeviceHandler {
+	return &DeviceHandler{
+		deviceService: deviceService,
+	}
+}
+
+// deviceError traduz os erros do serviço de terminais para respostas HTTP
+func deviceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidDevice):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidPairingCode):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrDeviceScope):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrDeviceNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+func (h *DeviceHandler) List(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	devices, err := h.deviceService.List(restaurantID)
+	if err != nil {
+		deviceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, devices)
+}
+
+func (h *DeviceHandler) GetByID(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, ok := uuidParam(c, "device_id", "device")
+	if !ok {
+		return
+	}
+
+	device, err := h.deviceService.Get(restaurantID, id)
+	if err != nil {
+		deviceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, device)
+}
+
+// Create cadastra um terminal. O pairing_code da resposta é digitado no terminal para pareá-lo.
+func (h *DeviceHandler) Create(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req DeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	device, pairing, err := h.deviceService.Register(restaurantID, actor, req.input())
+	if err != nil {
+		deviceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"device":             device,
+		"pairing_code":       pairing.Code,
+		"pairing_expires_at": pairing.ExpiresAt,
+	})
+}
+
+func (h *DeviceHandler) Update(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, ok := uuidParam(c, "device_id", "device")
+	if !ok {
+		return
+	}
+
+	var req DeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	device, err := h.deviceService.Update(restaurantID, id, req.input())
+	if err != nil {
+		deviceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, device)
+}
+
+// Delete exclui o terminal; o token dele e o do funcionário conectado deixam de valer
+func (h *DeviceHandler) Delete(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, ok := uuidParam(c, "device_id", "device")
+	if !ok {
+		return
+	}
+
+	if err := h.deviceService.Delete(restaurantID, id); err != nil {
+		deviceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "device deleted successfully"})
+}
+
+// RenewPairing gera um novo código de pareamento, para parear o terminal de novo ou trocar o aparelho
+func (h *DeviceHandler) RenewPairing(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, ok := uuidParam(c, "device_id", "device")
+	if !ok {
+		return
+	}
+
+	device, pairing, err := h.deviceService.RenewPairing(restaurantID, id)
+	if err != nil {
+		deviceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"device":             device,
+		"pairing_code":       pairing.Code,
+		"pairing_expires_at": pairing.ExpiresAt,
+	})
+}
+
+// Revoke revoga a credencial do terminal remotamente; ele só volta a funcionar se for pareado de novo
+func (h *DeviceHandler) Revoke(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, ok := uuidParam(c, "device_id", "device")
+	if !ok {
+		return
+	}
+
+	device, err := h.deviceService.Revoke(restaurantID, id)
+	if err != nil {
+		deviceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, device)
+}
+
+// Pair troca o código de pareamento pela credencial do terminal. O device_token da resposta é enviado
+// no cabeçalho X-Device-Token nas requisições seguintes e não é exibido de novo.
+func (h *DeviceHandler) Pair(c *gin.Context) {
+	var req PairDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	device, token, err := h.deviceService.Pair(req.PairingCode, c.ClientIP())
+	if err != nil {
+		deviceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"device":       device,
+		"device_token": token,
+	})
+}
+
+// Me retorna o terminal autenticado e as ações que a sua credencial permite
+func (h *DeviceHandler) Me(c *gin.Context) {
+	device, ok := deviceFromContext(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, device)
+}
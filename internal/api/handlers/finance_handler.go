@@ -1,11 +1,13 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
 
 	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
 	"api-jet-manager/internal/services"
 
 	"github.com/gin-gonic/gin"
@@ -13,12 +15,12 @@ import (
 )
 
 type FinanceTransactionRequest struct {
-	Type        string     `json:"type" binding:"required"`
-	Category    string     `json:"category" binding:"required"`
-	Amount      float64    `json:"amount" binding:"required,gt=0"`
-	Description string     `json:"description" binding:"required"`
-	Date        string     `json:"date" binding:"required"`
-	OrderID     *uuid.UUID `json:"order_id"`
+	Type        string       `json:"type" binding:"required"`
+	Category    string       `json:"category" binding:"required"`
+	Amount      models.Money `json:"amount" binding:"required,gt=0"`
+	Description string       `json:"description" binding:"required"`
+	Date        string       `json:"date" binding:"required"`
+	OrderID     *uuid.UUID   `json:"order_id"`
 }
 
 type FinanceHandler struct {
@@ -31,6 +33,18 @@ func NewFinanceHandler(financeService *services.FinanceService) *FinanceHandler
 	}
 }
 
+// financeError traduz os erros do serviço financeiro para respostas HTTP
+func financeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrLinkedTransaction):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrTransactionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "transaction not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
 func (h *FinanceHandler) Create(c *gin.Context) {
 	var req FinanceTransactionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -249,7 +263,7 @@ func (h *FinanceHandler) GetSummary(c *gin.Context) {
 			"date":    dateStr,
 			"income":  income,
 			"expense": expense,
-			"balance": income - expense,
+			"balance": income.Sub(expense),
 		})
 
 	case "monthly":
@@ -286,7 +300,7 @@ func (h *FinanceHandler) GetSummary(c *gin.Context) {
 			"month":   month,
 			"income":  income,
 			"expense": expense,
-			"balance": income - expense,
+			"balance": income.Sub(expense),
 		})
 
 	default:
@@ -382,7 +396,7 @@ func (h *FinanceHandler) Update(c *gin.Context) {
 	transaction.OrderID = req.OrderID
 
 	if err := h.financeService.Update(transaction); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		financeError(c, err)
 		return
 	}
 
@@ -415,7 +429,7 @@ func (h *FinanceHandler) Delete(c *gin.Context) {
 	}
 
 	if err := h.financeService.Delete(restaurant_uuid, transactionID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		financeError(c, err)
 		return
 	}
 
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// fakeFinanceRepository guarda uma única transação e a origem informada pelo teste
+type fakeFinanceRepository struct {
+	repositories.FinanceRepository
+	transaction *models.FinancialTransaction
+	source      models.TransactionSource
+}
+
+func (r *fakeFinanceRepository) FindByID(restaurantID, id uuid.UUID) (*models.FinancialTransaction, error) {
+	if r.transaction.RestaurantID != restaurantID || r.transaction.ID != id {
+		return nil, repositories.ErrTransactionNotFound
+	}
+	copied := *r.transaction
+	return &copied, nil
+}
+
+func (r *fakeFinanceRepository) FindSource(restaurantID, id uuid.UUID) (models.TransactionSource, error) {
+	if _, err := r.FindByID(restaurantID, id); err != nil {
+		return models.TransactionSourceManual, err
+	}
+	return r.source, nil
+}
+
+func (r *fakeFinanceRepository) Update(transaction *models.FinancialTransaction) error {
+	return nil
+}
+
+func (r *fakeFinanceRepository) Delete(restaurantID, id uuid.UUID) error {
+	return nil
+}
+
+func TestFinanceHandlerLinkedTransactions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	body := `{"type":"income","category":"sales","amount":10,"description":"Venda","date":"2026-10-17"}`
+
+	tests := []struct {
+		name   string
+		source models.TransactionSource
+		method string
+		want   int
+	}{
+		{name: "update manual", source: models.TransactionSourceManual, method: http.MethodPut, want: http.StatusOK},
+		{name: "update payment", source: models.TransactionSourcePayment, method: http.MethodPut, want: http.StatusConflict},
+		{name: "update purchase order", source: models.TransactionSourcePurchaseOrder, method: http.MethodPut, want: http.StatusConflict},
+		{name: "update recurring", source: models.TransactionSourceRecurring, method: http.MethodPut, want: http.StatusConflict},
+		{name: "update bill settlement", source: models.TransactionSourceBillSettlement, method: http.MethodPut, want: http.StatusConflict},
+		{name: "delete manual", source: models.TransactionSourceManual, method: http.MethodDelete, want: http.StatusOK},
+		{name: "delete payment", source: models.TransactionSourcePayment, method: http.MethodDelete, want: http.StatusConflict},
+		{name: "delete purchase order", source: models.TransactionSourcePurchaseOrder, method: http.MethodDelete, want: http.StatusConflict},
+		{name: "delete recurring", source: models.TransactionSourceRecurring, method: http.MethodDelete, want: http.StatusConflict},
+		{name: "delete bill settlement", source: models.TransactionSourceBillSettlement, method: http.MethodDelete, want: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		transaction := &models.FinancialTransaction{ID: uuid.New(), RestaurantID: uuid.New(), Amount: 1000}
+		handler := NewFinanceHandler(services.NewFinanceService(&fakeFinanceRepository{transaction: transaction, source: tt.source}))
+
+		router := gin.New()
+		router.PUT("/restaurants/:restaurant_id/finance/transactions/:transaction_id", handler.Update)
+		router.DELETE("/restaurants/:restaurant_id/finance/transactions/:transaction_id", handler.Delete)
+
+		url := "/restaurants/" + transaction.RestaurantID.String() + "/finance/transactions/" + transaction.ID.String()
+		req := httptest.NewRequest(tt.method, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d (%s)", tt.name, rec.Code, tt.want, rec.Body.String())
+		}
+		if tt.want == http.StatusConflict && !strings.Contains(rec.Body.String(), "linked") {
+			t.Errorf("%s: body = %s, want the conflict reason", tt.name, rec.Body.String())
+		}
+	}
+}
+
+func TestFinanceHandlerDeleteMissingTransaction(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	transaction := &models.FinancialTransaction{ID: uuid.New(), RestaurantID: uuid.New()}
+	handler := NewFinanceHandler(services.NewFinanceService(&fakeFinanceRepository{transaction: transaction}))
+
+	router := gin.New()
+	router.DELETE("/restaurants/:restaurant_id/finance/transactions/:transaction_id", handler.Delete)
+
+	req := httptest.NewRequest(http.MethodDelete, "/restaurants/"+transaction.RestaurantID.String()+"/finance/transactions/"+uuid.NewString(), nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,370 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type IngredientRequest struct {
+	Name         string                `json:"name" binding:"required"`
+	Unit         models.IngredientUnit `json:"unit" binding:"required"`
+	MinimumStock models.Quantity       `json:"minimum_stock"`
+	OnHand       models.Quantity       `json:"on_hand"` // Estoque inicial; ignorado na atualização
+}
+
+type StockAdjustmentRequest struct {
+	Type     models.StockMovementType `json:"type" binding:"required"`
+	Quantity models.Quantity          `json:"quantity"`
+	Notes    string                   `json:"notes"`
+}
+
+type RecipeItemRequest struct {
+	IngredientID uuid.UUID       `json:"ingredient_id" binding:"required"`
+	Quantity     models.Quantity `json:"quantity"`
+}
+
+// RecipeRequest substitui a ficha técnica inteira; uma lista vazia remove a ficha
+type RecipeRequest struct {
+	Items []RecipeItemRequest `json:"items"`
+}
+
+type InventoryHandler struct {
+	inventoryService *services.InventoryService
+}
+
+func NewInventoryHandler(inventoryService *services.InventoryService) *InventoryHandler {
+	return &InventoryHandler{
+		inventoryService: inventoryService,
+	}
+}
+
+// inventoryError traduz os erros do serviço de estoque para respostas HTTP
+func inventoryError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidIngredient), errors.Is(err, services.ErrInvalidRecipe),
+		errors.Is(err, services.ErrInvalidStockMovement):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrIngredientInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrIngredientNotFound), errors.Is(err, repositories.ErrProductNotFound),
+		errors.Is(err, repositories.ErrOptionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+func (h *InventoryHandler) CreateIngredient(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req IngredientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ingredient := &models.Ingredient{
+		RestaurantID: restaurantID,
+		Name:         req.Name,
+		Unit:         req.Unit,
+		OnHand:       req.OnHand,
+		MinimumStock: req.MinimumStock,
+	}
+
+	if err := h.inventoryService.CreateIngredient(ingredient, userID); err != nil {
+		inventoryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ingredient)
+}
+
+// ListIngredients aceita ?low_stock=true para listar apenas os insumos que precisam de reposição
+func (h *InventoryHandler) ListIngredients(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	ingredients, err := h.inventoryService.ListIngredients(restaurantID, c.Query("low_stock") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, ingredients)
+}
+
+func (h *InventoryHandler) GetIngredient(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	ingredientID, ok := uuidParam(c, "ingredient_id", "ingredient")
+	if !ok {
+		return
+	}
+
+	ingredient, err := h.inventoryService.GetIngredient(restaurantID, ingredientID)
+	if err != nil {
+		inventoryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ingredient)
+}
+
+func (h *InventoryHandler) UpdateIngredient(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	ingredientID, ok := uuidParam(c, "ingredient_id", "ingredient")
+	if !ok {
+		return
+	}
+
+	var req IngredientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ingredient, err := h.inventoryService.GetIngredient(restaurantID, ingredientID)
+	if err != nil {
+		inventoryError(c, err)
+		return
+	}
+
+	ingredient.Name = req.Name
+	ingredient.Unit = req.Unit
+	ingredient.MinimumStock = req.MinimumStock
+
+	if err := h.inventoryService.UpdateIngredient(ingredient); err != nil {
+		inventoryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ingredient)
+}
+
+func (h *InventoryHandler) DeleteIngredient(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	ingredientID, ok := uuidParam(c, "ingredient_id", "ingredient")
+	if !ok {
+		return
+	}
+
+	if err := h.inventoryService.DeleteIngredient(restaurantID, ingredientID); err != nil {
+		inventoryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "ingredient deleted successfully"})
+}
+
+// AdjustStock registra uma compra, perda ou ajuste de contagem do insumo
+func (h *InventoryHandler) AdjustStock(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	ingredientID, ok := uuidParam(c, "ingredient_id", "ingredient")
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req StockAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ingredient, err := h.inventoryService.AdjustStock(restaurantID, ingredientID, userID, services.StockAdjustment{
+		Type:     req.Type,
+		Quantity: req.Quantity,
+		Notes:    req.Notes,
+	})
+	if err != nil {
+		inventoryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ingredient)
+}
+
+// ListMovements aceita os filtros ?ingredient_id=, ?order_id=, ?from= e ?to= (RFC3339)
+func (h *InventoryHandler) ListMovements(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var filter repositories.StockMovementFilter
+	for param, target := range map[string]**uuid.UUID{"ingredient_id": &filter.IngredientID, "order_id": &filter.OrderID} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param})
+			return
+		}
+		*target = &parsed
+	}
+	for param, target := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param + " date"})
+			return
+		}
+		*target = &parsed
+	}
+
+	movements, err := h.inventoryService.ListMovements(restaurantID, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, movements)
+}
+
+func recipeItems(req RecipeRequest) []models.RecipeItem {
+	items := make([]models.RecipeItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, models.RecipeItem{
+			IngredientID: item.IngredientID,
+			Quantity:     item.Quantity,
+		})
+	}
+	return items
+}
+
+func (h *InventoryHandler) GetProductRecipe(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	productID, ok := uuidParam(c, "product_id", "product")
+	if !ok {
+		return
+	}
+
+	items, err := h.inventoryService.GetProductRecipe(restaurantID, productID)
+	if err != nil {
+		inventoryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// SetProductRecipe substitui a ficha técnica do produto; a disponibilidade passa a seguir o estoque
+func (h *InventoryHandler) SetProductRecipe(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	productID, ok := uuidParam(c, "product_id", "product")
+	if !ok {
+		return
+	}
+
+	var req RecipeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	items, err := h.inventoryService.SetProductRecipe(restaurantID, productID, recipeItems(req))
+	if err != nil {
+		inventoryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+func (h *InventoryHandler) GetOptionRecipe(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	optionID, ok := uuidParam(c, "option_id", "option")
+	if !ok {
+		return
+	}
+
+	items, err := h.inventoryService.GetOptionRecipe(restaurantID, optionID)
+	if err != nil {
+		inventoryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+func (h *InventoryHandler) SetOptionRecipe(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	optionID, ok := uuidParam(c, "option_id", "option")
+	if !ok {
+		return
+	}
+
+	var req RecipeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	items, err := h.inventoryService.SetOptionRecipe(restaurantID, optionID, recipeItems(req))
+	if err != nil {
+		inventoryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
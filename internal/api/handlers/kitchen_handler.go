@@ -0,0 +1,374 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// kitchenFeedHeartbeat mantém a conexão SSE viva através de proxies que encerram conexões ociosas
+const kitchenFeedHeartbeat = 20 * time.Second
+
+type StationRequest struct {
+	Name   string `json:"name" binding:"required"`
+	Active *bool  `json:"active"`
+}
+
+// StationRoutingRequest define a praça de um produto ou categoria; nulo remove o vínculo
+type StationRoutingRequest struct {
+	StationID *uuid.UUID `json:"station_id"`
+}
+
+type KitchenHandler struct {
+	kitchenService *services.KitchenService
+}
+
+func NewKitchenHandler(kitchenService *services.KitchenService) *KitchenHandler {
+	return &KitchenHandler{
+		kitchenService: kitchenService,
+	}
+}
+
+// kitchenError traduz os erros do serviço da cozinha para respostas HTTP
+func kitchenError(c *gin.Context, err error) {
+	var transitionErr *services.InvalidTransitionError
+	switch {
+	case errors.Is(err, services.ErrInvalidStation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidTicketAction), errors.As(err, &transitionErr):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrStationNotFound), errors.Is(err, repositories.ErrTicketNotFound),
+		errors.Is(err, repositories.ErrProductNotFound), errors.Is(err, repositories.ErrCategoryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// stationParam lê a praça da URL; "all" representa todas as praças do restaurante
+func stationParam(c *gin.Context) (*uuid.UUID, bool) {
+	if c.Param("station_id") == "all" {
+		return nil, true
+	}
+	stationID, ok := uuidParam(c, "station_id", "station")
+	if !ok {
+		return nil, false
+	}
+	return &stationID, true
+}
+
+func (h *KitchenHandler) CreateStation(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req StationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	station := &models.KitchenStation{
+		RestaurantID: restaurantID,
+		Name:         req.Name,
+		Active:       true,
+	}
+	if req.Active != nil {
+		station.Active = *req.Active
+	}
+
+	if err := h.kitchenService.CreateStation(station); err != nil {
+		kitchenError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, station)
+}
+
+func (h *KitchenHandler) ListStations(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	stations, err := h.kitchenService.ListStations(restaurantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, stations)
+}
+
+func (h *KitchenHandler) UpdateStation(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	stationID, ok := uuidParam(c, "station_id", "station")
+	if !ok {
+		return
+	}
+
+	var req StationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	station, err := h.kitchenService.GetStation(restaurantID, stationID)
+	if err != nil {
+		kitchenError(c, err)
+		return
+	}
+
+	station.Name = req.Name
+	if req.Active != nil {
+		station.Active = *req.Active
+	}
+
+	if err := h.kitchenService.UpdateStation(station); err != nil {
+		kitchenError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, station)
+}
+
+func (h *KitchenHandler) DeleteStation(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	stationID, ok := uuidParam(c, "station_id", "station")
+	if !ok {
+		return
+	}
+
+	if err := h.kitchenService.DeleteStation(restaurantID, stationID); err != nil {
+		kitchenError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "station deleted successfully"})
+}
+
+// SetProductStation envia o produto para uma praça específica, sobrepondo a praça da categoria
+func (h *KitchenHandler) SetProductStation(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	productID, ok := uuidParam(c, "product_id", "product")
+	if !ok {
+		return
+	}
+
+	var req StationRoutingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.kitchenService.SetProductStation(restaurantID, productID, req.StationID); err != nil {
+		kitchenError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"product_id": productID, "station_id": req.StationID})
+}
+
+// SetCategoryStation define a praça padrão dos produtos da categoria
+func (h *KitchenHandler) SetCategoryStation(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	categoryID, ok := uuidParam(c, "category_id", "category")
+	if !ok {
+		return
+	}
+
+	var req StationRoutingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.kitchenService.SetCategoryStation(restaurantID, categoryID, req.StationID); err != nil {
+		kitchenError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"category_id": categoryID, "station_id": req.StationID})
+}
+
+// ListTickets lista as comandas, filtrando opcionalmente por praça, pedido e status.
+// Sem filtro de status, retorna apenas as comandas abertas.
+func (h *KitchenHandler) ListTickets(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var filter repositories.KitchenTicketFilter
+
+	if value := c.Query("station_id"); value != "" {
+		stationID, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid station ID"})
+			return
+		}
+		filter.StationID = &stationID
+	}
+
+	if value := c.Query("order_id"); value != "" {
+		orderID, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order ID"})
+			return
+		}
+		filter.OrderID = &orderID
+	}
+
+	switch status := models.TicketStatus(c.Query("status")); status {
+	case "":
+		filter.Statuses = []models.TicketStatus{models.TicketStatusQueued, models.TicketStatusInProgress}
+	case "all":
+	case models.TicketStatusQueued, models.TicketStatusInProgress, models.TicketStatusDone:
+		filter.Statuses = []models.TicketStatus{status}
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ticket status"})
+		return
+	}
+
+	tickets, err := h.kitchenService.ListTickets(restaurantID, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tickets)
+}
+
+// ticketAction executa start, bump ou recall sobre a comanda da URL. A ação fica em nome do usuário
+// conectado e, quando feita em um terminal, também do terminal; a tela autenticada pela credencial
+// do terminal não tem usuário e a ação fica só em nome do terminal.
+func (h *KitchenHandler) ticketAction(c *gin.Context, action func(restaurantID, ticketID uuid.UUID, actor services.TicketActor) (*models.KitchenTicket, error)) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	ticketID, ok := uuidParam(c, "ticket_id", "ticket")
+	if !ok {
+		return
+	}
+
+	var actor services.TicketActor
+	if userID, err := uuid.Parse(c.GetString("user_id")); err == nil {
+		actor.UserID = userID
+	}
+	if deviceID, err := uuid.Parse(c.GetString("device_id")); err == nil {
+		actor.DeviceID = &deviceID
+	}
+	if actor.UserID == uuid.Nil && actor.DeviceID == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	ticket, err := action(restaurantID, ticketID, actor)
+	if err != nil {
+		kitchenError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ticket)
+}
+
+// StartTicket marca a comanda como em preparo
+func (h *KitchenHandler) StartTicket(c *gin.Context) {
+	h.ticketAction(c, func(restaurantID, ticketID uuid.UUID, _ services.TicketActor) (*models.KitchenTicket, error) {
+		return h.kitchenService.Start(restaurantID, ticketID)
+	})
+}
+
+// BumpTicket finaliza a comanda; o pedido fica pronto quando todas as comandas estiverem prontas
+func (h *KitchenHandler) BumpTicket(c *gin.Context) {
+	h.ticketAction(c, h.kitchenService.Bump)
+}
+
+// RecallTicket devolve uma comanda finalizada para a tela da praça
+func (h *KitchenHandler) RecallTicket(c *gin.Context) {
+	h.ticketAction(c, h.kitchenService.Recall)
+}
+
+// Feed transmite, via Server-Sent Events, as comandas abertas da praça e depois cada alteração.
+// Use "all" como praça para acompanhar todas as comandas, inclusive as sem praça definida.
+func (h *KitchenHandler) Feed(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	stationID, ok := stationParam(c)
+	if !ok {
+		return
+	}
+
+	if stationID != nil {
+		if _, err := h.kitchenService.GetStation(restaurantID, *stationID); err != nil {
+			kitchenError(c, err)
+			return
+		}
+	}
+
+	// Inscrever antes de carregar o snapshot para não perder eventos entre as duas etapas
+	events, unsubscribe := h.kitchenService.Subscribe(restaurantID, stationID)
+	defer unsubscribe()
+
+	tickets, err := h.kitchenService.OpenTickets(restaurantID, stationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.SSEvent("snapshot", tickets)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(kitchenFeedHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case event, open := <-events:
+			if !open {
+				// A tela ficou para trás e foi desconectada; o cliente reconecta e recebe um novo snapshot
+				return false
+			}
+			c.SSEvent(string(event.Type), event.Ticket)
+			return true
+		case <-heartbeat.C:
+			c.SSEvent("heartbeat", gin.H{"at": time.Now()})
+			return true
+		}
+	})
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AddMemberRequest vincula ao restaurante um usuário já cadastrado em outro restaurante
+type AddMemberRequest struct {
+	Email  string          `json:"email" binding:"required,email"`
+	Type   models.UserType `json:"type"`
+	RoleID *uuid.UUID      `json:"role_id"`
+}
+
+type UpdateMemberRequest struct {
+	Type   models.UserType `json:"type"`
+	RoleID *uuid.UUID      `json:"role_id"`
+}
+
+type MembershipHandler struct {
+	membershipService *services.MembershipService
+}
+
+func NewMembershipHandler(membershipService *services.MembershipService) *MembershipHandler {
+	return &MembershipHandler{
+		membershipService: membershipService,
+	}
+}
+
+// membershipError traduz os erros do serviço de vínculos para respostas HTTP
+func membershipError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrInvalidMembership) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	roleError(c, err)
+}
+
+// restaurantOptions resume os restaurantes do usuário para o login e a troca de restaurante
+func restaurantOptions(memberships []models.Membership) []gin.H {
+	options := make([]gin.H, 0, len(memberships))
+	for _, membership := range memberships {
+		option := gin.H{
+			"id":      membership.RestaurantID,
+			"type":    membership.Type,
+			"role_id": membership.RoleID,
+		}
+		if membership.Restaurant != nil {
+			option["name"] = membership.Restaurant.Name
+			option["logo"] = membership.Restaurant.Logo
+			option["status"] = membership.Restaurant.Status
+		}
+		options = append(options, option)
+	}
+	return options
+}
+
+// MyRestaurants lista os restaurantes do usuário autenticado, para escolher o restaurante ativo
+func (h *MembershipHandler) MyRestaurants(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	memberships, err := h.membershipService.ListForUser(userID)
+	if err != nil {
+		membershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, restaurantOptions(memberships))
+}
+
+func (h *MembershipHandler) List(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	memberships, err := h.membershipService.ListMembers(restaurantID)
+	if err != nil {
+		membershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, memberships)
+}
+
+func (h *MembershipHandler) Add(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req AddMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	membership, err := h.membershipService.AddMember(restaurantID, actor, req.Email, services.MembershipInput{
+		Type:   req.Type,
+		RoleID: req.RoleID,
+	})
+	if err != nil {
+		membershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, membership)
+}
+
+func (h *MembershipHandler) Update(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	userID, ok := uuidParam(c, "user_id", "user")
+	if !ok {
+		return
+	}
+
+	var req UpdateMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	membership, err := h.membershipService.UpdateMember(restaurantID, userID, actor, services.MembershipInput{
+		Type:   req.Type,
+		RoleID: req.RoleID,
+	})
+	if err != nil {
+		membershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, membership)
+}
+
+// Remove desvincula o usuário do restaurante; a conta continua valendo nos demais restaurantes
+func (h *MembershipHandler) Remove(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	userID, ok := uuidParam(c, "user_id", "user")
+	if !ok {
+		return
+	}
+
+	if err := h.membershipService.RemoveMember(restaurantID, userID, actor); err != nil {
+		membershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "member removed successfully"})
+}
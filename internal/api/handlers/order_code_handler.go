@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrderCodeSettingsRequest struct {
+	Prefix   string                `json:"prefix"`
+	Reset    models.OrderCodeReset `json:"reset" binding:"required"`
+	Padding  int                   `json:"padding" binding:"required"`
+	Timezone string                `json:"timezone"` // Mantém o fuso atual quando omitido
+}
+
+type OrderCodeHandler struct {
+	codeService *services.OrderCodeService
+}
+
+func NewOrderCodeHandler(codeService *services.OrderCodeService) *OrderCodeHandler {
+	return &OrderCodeHandler{
+		codeService: codeService,
+	}
+}
+
+// GetSettings retorna o formato usado nos códigos dos pedidos do restaurante
+func (h *OrderCodeHandler) GetSettings(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	settings, err := h.codeService.GetSettings(restaurantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateSettings altera o formato dos códigos dos próximos pedidos
+func (h *OrderCodeHandler) UpdateSettings(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req OrderCodeSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := h.codeService.GetSettings(restaurantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings.Prefix = req.Prefix
+	settings.Reset = req.Reset
+	settings.Padding = req.Padding
+	if req.Timezone != "" {
+		settings.Timezone = req.Timezone
+	}
+
+	if err := h.codeService.UpdateSettings(settings); err != nil {
+		if errors.Is(err, services.ErrInvalidOrderCodeSettings) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
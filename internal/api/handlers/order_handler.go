@@ -1,126 +1,41 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
-	"sync"
 	"time"
 
 	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
 	"api-jet-manager/internal/services"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/gorilla/websocket"
 )
 
-// Gerenciador de WebSocket
-type WebSocketManager struct {
-	clients    map[*websocket.Conn]bool
-	broadcast  chan *models.Order
-	register   chan *websocket.Conn
-	unregister chan *websocket.Conn
+type OrderItemOptionRequest struct {
+	OptionID uuid.UUID `json:"option_id" binding:"required"`
+	Quantity int       `json:"quantity" binding:"omitempty,min=1"`
 }
 
-// Nova instância do WebSocketManager
-func NewWebSocketManager() *WebSocketManager {
-	return &WebSocketManager{
-		clients:    make(map[*websocket.Conn]bool),
-		broadcast:  make(chan *models.Order),
-		register:   make(chan *websocket.Conn),
-		unregister: make(chan *websocket.Conn),
-	}
-}
-
-// Gerencia conexões WebSocket
-func (manager *WebSocketManager) Run() {
-	for {
-		select {
-		case conn := <-manager.register:
-			manager.clients[conn] = true
-		case conn := <-manager.unregister:
-			if _, ok := manager.clients[conn]; ok {
-				delete(manager.clients, conn)
-				conn.Close()
-			}
-		case message := <-manager.broadcast:
-			for client := range manager.clients {
-				err := client.WriteJSON(message)
-				if err != nil {
-					client.Close()
-					delete(manager.clients, client)
-				}
-			}
-		}
-	}
-}
-
-// WebSocket endpoint
-func (manager *WebSocketManager) ServeWebSocket(c *gin.Context) {
-	upgrader := websocket.Upgrader{
-		ReadBufferSize:  1024,
-		WriteBufferSize: 1024,
-		CheckOrigin: func(r *http.Request) bool {
-			return true
-		},
-	}
-
-	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
-	if err != nil {
-		fmt.Println("Erro ao conectar WebSocket:", err)
-		return
-	}
-
-	manager.register <- conn
-
-	// defer func() {
-	// 	manager.unregister <- conn
-	// }()
-}
-
-// ProductCodeGenerator gerencia a geração de códigos de produto
-type ProductCodeGenerator struct {
-	mutex       sync.Mutex
-	lastOrderID int
-	lastDay     int
-}
-
-// NewProductCodeGenerator cria uma nova instância do gerador
-func NewProductCodeGenerator() *ProductCodeGenerator {
-	now := time.Now()
-	return &ProductCodeGenerator{
-		lastOrderID: 0,
-		lastDay:     now.Day(),
-	}
+type OrderItemRequest struct {
+	ProductID uuid.UUID                `json:"product_id" binding:"required"`
+	Quantity  int                      `json:"quantity" binding:"required,min=1"`
+	Notes     string                   `json:"notes"`
+	Options   []OrderItemOptionRequest `json:"options" binding:"dive"`
 }
 
-// GenerateCode gera um novo código de produto no formato #DDNNN
-func (g *ProductCodeGenerator) GenerateCode() string {
-	g.mutex.Lock()
-	defer g.mutex.Unlock()
-
-	now := time.Now()
-	currentDay := now.Day()
-
-	// Se mudou o dia, reinicia o contador
-	if currentDay != g.lastDay {
-		g.lastOrderID = 0
-		g.lastDay = currentDay
+// selections converte as opções da requisição para o formato usado pelo serviço
+func (r OrderItemRequest) selections() []services.OptionSelection {
+	selections := make([]services.OptionSelection, 0, len(r.Options))
+	for _, option := range r.Options {
+		selections = append(selections, services.OptionSelection{
+			OptionID: option.OptionID,
+			Quantity: option.Quantity,
+		})
 	}
-
-	// Incrementa o contador de pedidos
-	g.lastOrderID++
-
-	// Formata o código: #DDNNN
-	code := fmt.Sprintf("#%02d%03d", currentDay, g.lastOrderID)
-
-	return code
-}
-
-type OrderItemRequest struct {
-	ProductID uuid.UUID `json:"product_id" binding:"required"`
-	Quantity  int       `json:"quantity" binding:"required,min=1"`
-	Notes     string    `json:"notes"`
+	return selections
 }
 
 type OrderRequest struct {
@@ -138,19 +53,35 @@ type OrderDeliveryRequest struct {
 	DeliveryAddress string             `json:"delivery_address"`
 }
 
+// TransferOrderRequest indica a mesa para onde o pedido vai
+type TransferOrderRequest struct {
+	TableID uuid.UUID `json:"table_id" binding:"required"`
+}
+
+// MoveItemsRequest indica os itens do pedido da URL que passam para outro pedido
+type MoveItemsRequest struct {
+	ToOrderID uuid.UUID   `json:"to_order_id" binding:"required"`
+	ItemIDs   []uuid.UUID `json:"item_ids" binding:"required,min=1"`
+}
+
+// MergeOrdersRequest indica o pedido que será juntado ao pedido da URL
+type MergeOrdersRequest struct {
+	SourceOrderID uuid.UUID `json:"source_order_id" binding:"required"`
+}
+
 type OrderHandler struct {
-	orderService     *services.OrderService
-	tableService     *services.TableService
-	codeGenerator    *ProductCodeGenerator
-	webSocketManager *WebSocketManager
+	orderService   *services.OrderService
+	tableService   *services.TableService
+	paymentService *services.PaymentService
+	roleService    *services.RoleService
 }
 
-func NewOrderHandler(orderService *services.OrderService, tableService *services.TableService, webSocketManager *WebSocketManager) *OrderHandler {
+func NewOrderHandler(orderService *services.OrderService, tableService *services.TableService, paymentService *services.PaymentService, roleService *services.RoleService) *OrderHandler {
 	return &OrderHandler{
-		orderService:     orderService,
-		tableService:     tableService,
-		codeGenerator:    NewProductCodeGenerator(),
-		webSocketManager: webSocketManager,
+		orderService:   orderService,
+		tableService:   tableService,
+		paymentService: paymentService,
+		roleService:    roleService,
 	}
 }
 
@@ -161,15 +92,12 @@ func (h *OrderHandler) Create(c *gin.Context) {
 		return
 	}
 
-	userIDRaw, _ := c.Get("user_id")
-	userIDPtr, ok := userIDRaw.(*uuid.UUID)
-	if !ok {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
 
-	userID := *userIDPtr
-
 	restaurantIDRaw, _ := c.Get("restaurant_id")
 	restaurantIDPtr, ok := restaurantIDRaw.(*uuid.UUID)
 	if !ok {
@@ -179,29 +107,21 @@ func (h *OrderHandler) Create(c *gin.Context) {
 
 	restaurantId := *restaurantIDPtr
 
-	// Verificar se a mesa existe e está livre
+	// Verificar se a mesa existe; a disponibilidade é validada na transação de criação
 	if req.TableID != nil {
-		table, err := h.tableService.GetByID(restaurantId, *req.TableID)
-		if err != nil {
+		if _, err := h.tableService.GetByID(restaurantId, *req.TableID); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "table not found"})
 			return
 		}
-
-		if table.Status != models.TableStatusFree && table.Status != models.TableStatusReserved {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "table is not available"})
-			return
-		}
 	}
 
-	// Gerar código do pedido
-	orderCode := h.codeGenerator.GenerateCode()
-
 	order := &models.Order{
-		TableID: req.TableID,
-		UserID:  userID,
-		Status:  models.OrderStatusPending,
-		Notes:   req.Notes,
-		Code:    orderCode,
+		RestaurantID: restaurantId,
+		TableID:      req.TableID,
+		UserID:       userID,
+		Type:         models.OrderTypeInHouse,
+		Status:       models.OrderStatusPending,
+		Notes:        req.Notes,
 	}
 
 	// Processar itens do pedido
@@ -218,37 +138,27 @@ func (h *OrderHandler) Create(c *gin.Context) {
 			return
 		}
 
-		orderItems = append(orderItems, models.OrderItem{
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
-			Price:     product.Price,
-			Notes:     item.Notes,
-		})
-	}
-
-	if err := h.orderService.CreateOrder(order, orderItems); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-
-	// Atualizar status da mesa se o pedido estiver associado a uma mesa
-	if req.TableID != nil {
-		if err := h.tableService.UpdateStatus(restaurantId, *req.TableID, models.TableStatusOccupied); err != nil {
-			// Log do erro, mas não falha a criação do pedido
-			c.JSON(http.StatusCreated, gin.H{
-				"order":   order,
-				"warning": "order created but failed to update table status",
-			})
+		orderItem, err := h.orderService.BuildItem(restaurantId, product, item.Quantity, item.Notes, item.selections())
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidAddonSelection) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
 
-		if err := h.tableService.SetCurrentOrder(restaurantId, *req.TableID, &order.ID); err != nil {
-			c.JSON(http.StatusCreated, gin.H{
-				"order":   order,
-				"warning": "order created but failed to link order to table",
-			})
+		orderItems = append(orderItems, orderItem)
+	}
+
+	// A criação do pedido e a ocupação da mesa acontecem na mesma transação
+	if err := h.orderService.CreateOrder(order, orderItems); err != nil {
+		if errors.Is(err, services.ErrTableNotAvailable) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
 
 	c.JSON(http.StatusCreated, order)
@@ -295,8 +205,6 @@ func (h *OrderHandler) CreateOrderDelivery(c *gin.Context) {
 	}
 	restaurantId = *restaurantIDPtr // Desreferencia o ponteiro para obter o valor uuid.UUID
 
-	orderCode := h.codeGenerator.GenerateCode()
-
 	order := &models.Order{
 		UserID:        userID,
 		RestaurantID:  restaurantId,
@@ -305,7 +213,6 @@ func (h *OrderHandler) CreateOrderDelivery(c *gin.Context) {
 		CustomerName:  req.CustomerName,
 		CustomerPhone: req.CustomerPhone,
 		CustomerEmail: req.CustomerEmail,
-		Code:          orderCode,
 		Type:          models.OrderTypeDelivery,
 	}
 
@@ -322,12 +229,17 @@ func (h *OrderHandler) CreateOrderDelivery(c *gin.Context) {
 			return
 		}
 
-		orderItems = append(orderItems, models.OrderItem{
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
-			Price:     product.Price,
-			Notes:     item.Notes,
-		})
+		orderItem, err := h.orderService.BuildItem(restaurantId, product, item.Quantity, item.Notes, item.selections())
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidAddonSelection) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		orderItems = append(orderItems, orderItem)
 	}
 
 	if err := h.orderService.CreateOrder(order, orderItems); err != nil {
@@ -337,8 +249,6 @@ func (h *OrderHandler) CreateOrderDelivery(c *gin.Context) {
 
 	order.OrderItems = orderItems
 
-	h.webSocketManager.broadcast <- order
-
 	c.JSON(http.StatusCreated, order)
 }
 
@@ -460,6 +370,8 @@ func (h *OrderHandler) UpdateStatus(c *gin.Context) {
 
 	var req struct {
 		Status string `json:"status" binding:"required"`
+		// Opcional ao marcar como pago: quita o saldo restante com esta forma de pagamento
+		PaymentMethod models.PaymentMethod `json:"payment_method"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -490,55 +402,98 @@ func (h *OrderHandler) UpdateStatus(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order ID"})
 		return
 	}
-	order, err := h.orderService.GetByID(restaurantID, orderID)
+
+	userUUID, err := uuid.Parse(c.GetString("user_id"))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "order not found"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
 		return
 	}
 
-	// Lógica especial para marcar como pago
-	if status == models.OrderStatusPaid && order.Status != models.OrderStatusPaid {
-		now := time.Now()
-		order.PaidAt = &now
+	// O cancelamento depende de permissão própria, as demais mudanças de status não
+	if status == models.OrderStatusCancelled && !requirePermission(c, h.roleService, restaurantID, models.PermissionOrdersCancel) {
+		return
+	}
 
-		// Criar transação financeira
-		userID := c.GetString("user_id")
-		userUUID, err := uuid.Parse(userID)
+	// Quitar o saldo com um único pagamento; o pedido é fechado pelo próprio pagamento
+	if status == models.OrderStatusPaid && req.PaymentMethod != "" {
+		current, err := h.orderService.GetByID(restaurantID, orderID)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
-			return
-		}
-		if err := h.orderService.RegisterPayment(order, userUUID); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to register payment"})
+			c.JSON(http.StatusNotFound, gin.H{"error": "order not found"})
 			return
 		}
 
-		// Liberar mesa se o pedido estiver vinculado a uma
-		if order.TableID == nil {
-			if err := h.tableService.UpdateStatus(restaurantID, *order.TableID, models.TableStatusFree); err != nil {
-				c.JSON(http.StatusOK, gin.H{
-					"message": "order status updated but failed to free table",
-				})
+		if current.Balance() > 0 {
+			_, order, err := h.paymentService.Register(restaurantID, orderID, services.PaymentInput{
+				Method:  req.PaymentMethod,
+				ActorID: userUUID,
+			})
+			if err != nil {
+				paymentError(c, err)
 				return
 			}
 
-			// Remover associação com o pedido atual
-			var nilOrderID *uuid.UUID
-			if err := h.tableService.SetCurrentOrder(restaurantID, *order.TableID, nilOrderID); err != nil {
+			if order.Status == models.OrderStatusPaid {
 				c.JSON(http.StatusOK, gin.H{
-					"message": "order status updated but failed to unlink order from table",
+					"message": "order status updated successfully",
+					"order":   order,
 				})
 				return
 			}
 		}
 	}
 
-	if err := h.orderService.UpdateStatus(restaurantID, orderID, status); err != nil {
+	order, err := h.orderService.UpdateStatus(restaurantID, orderID, status, userUUID)
+	if err != nil {
+		var transitionErr *services.InvalidTransitionError
+		if errors.As(err, &transitionErr) || errors.Is(err, services.ErrOutstandingBalance) || errors.Is(err, services.ErrOrderHasPayments) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "order status updated successfully"})
+	c.JSON(http.StatusOK, gin.H{
+		"message": "order status updated successfully",
+		"order":   order,
+	})
+}
+
+// GetStatusHistory retorna o histórico de status do pedido e os próximos status permitidos
+func (h *OrderHandler) GetStatusHistory(c *gin.Context) {
+	orderID, err := uuid.Parse(c.Param("order_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order ID"})
+		return
+	}
+
+	restaurantIDRaw, _ := c.Get("restaurant_id")
+	restaurantIDPtr, ok := restaurantIDRaw.(*uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "restaurant ID is nil"})
+		return
+	}
+
+	restaurantID := *restaurantIDPtr
+
+	order, err := h.orderService.GetByID(restaurantID, orderID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "order not found"})
+		return
+	}
+
+	history, err := h.orderService.GetStatusHistory(restaurantID, orderID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch order history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"order_id":            order.ID,
+		"status":              order.Status,
+		"allowed_transitions": h.orderService.AllowedTransitions(order),
+		"history":             history,
+	})
 }
 
 func (h *OrderHandler) AddItem(c *gin.Context) {
@@ -554,15 +509,18 @@ func (h *OrderHandler) AddItem(c *gin.Context) {
 		return
 	}
 
-	restaurant_id := c.Param("restaurant_id")
-	if restaurant_id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid table ID"})
+	restaurantIDRaw, _ := c.Get("restaurant_id")
+	restaurantIDPtr, ok := restaurantIDRaw.(*uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "restaurant ID is nil"})
 		return
 	}
 
-	restaurant_uuid, errRes := uuid.Parse(restaurant_id)
-	if errRes != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid table ID"})
+	restaurant_uuid := *restaurantIDPtr
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
 
@@ -594,15 +552,22 @@ func (h *OrderHandler) AddItem(c *gin.Context) {
 		return
 	}
 
-	item := &models.OrderItem{
-		OrderID:   orderUUID,
-		ProductID: req.ProductID,
-		Quantity:  req.Quantity,
-		Price:     product.Price,
-		Notes:     req.Notes,
+	item, err := h.orderService.BuildItem(restaurant_uuid, product, req.Quantity, req.Notes, req.selections())
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidAddonSelection) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
+	item.OrderID = orderUUID
 
-	if err := h.orderService.AddItem(restaurant_uuid, item); err != nil {
+	if err := h.orderService.AddItem(restaurant_uuid, &item, userID); err != nil {
+		if errors.Is(err, services.ErrOrderClosed) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -635,15 +600,14 @@ func (h *OrderHandler) RemoveItem(c *gin.Context) {
 		return
 	}
 
-	restaurant_id := c.Param("restaurant_id")
-	if restaurant_id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid table ID"})
+	restaurant_uuid, ok := restaurantIDFromContext(c)
+	if !ok {
 		return
 	}
 
-	restaurant_uuid, errRes := uuid.Parse(restaurant_id)
-	if errRes != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid table ID"})
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
 
@@ -658,7 +622,11 @@ func (h *OrderHandler) RemoveItem(c *gin.Context) {
 		return
 	}
 
-	if err := h.orderService.RemoveItem(restaurant_uuid, orderUUID, itemUUID); err != nil {
+	if err := h.orderService.RemoveItem(restaurant_uuid, orderUUID, itemUUID, userID); err != nil {
+		if errors.Is(err, services.ErrOrderClosed) || errors.Is(err, services.ErrOrderHasPayments) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -886,3 +854,118 @@ func (h *OrderHandler) FindOrdersByDateRangeAndType(c *gin.Context) {
 		"count":      len(orders),
 	})
 }
+
+// transferError traduz os erros de transferência e junção de pedidos para respostas HTTP
+func transferError(c *gin.Context, err error) {
+	var transitionErr *services.InvalidTransitionError
+	switch {
+	case errors.Is(err, services.ErrInvalidOrderTransfer), errors.Is(err, services.ErrOrderClosed),
+		errors.Is(err, services.ErrOrderHasPayments):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrTableNotAvailable), errors.As(err, &transitionErr):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrOrderNotFound), errors.Is(err, repositories.ErrTableNotFound),
+		errors.Is(err, repositories.ErrOrderItemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// TransferOrder leva o pedido para outra mesa, liberando a mesa atual
+func (h *OrderHandler) TransferOrder(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	orderID, ok := uuidParam(c, "order_id", "order")
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req TransferOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := h.orderService.TransferOrder(restaurantID, orderID, req.TableID, userID)
+	if err != nil {
+		transferError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// MoveItems passa itens do pedido da URL para outro pedido aberto
+func (h *OrderHandler) MoveItems(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	orderID, ok := uuidParam(c, "order_id", "order")
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req MoveItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.orderService.MoveItems(restaurantID, orderID, req.ToOrderID, req.ItemIDs, userID); err != nil {
+		transferError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "items moved successfully"})
+}
+
+// MergeOrders junta outro pedido aberto ao pedido da URL
+func (h *OrderHandler) MergeOrders(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	orderID, ok := uuidParam(c, "order_id", "order")
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req MergeOrdersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := h.orderService.MergeOrders(restaurantID, orderID, req.SourceOrderID, userID)
+	if err != nil {
+		transferError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
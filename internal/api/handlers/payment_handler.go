@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type PaymentItemRequest struct {
+	OrderItemID uuid.UUID `json:"order_item_id" binding:"required"`
+	Quantity    int       `json:"quantity" binding:"omitempty,min=1"`
+}
+
+type PaymentRequest struct {
+	Method        models.PaymentMethod `json:"method" binding:"required"`
+	Amount        models.Money         `json:"amount" binding:"min=0"`   // Zero paga o valor dos itens ou o saldo restante
+	Tendered      models.Money         `json:"tendered" binding:"min=0"` // Valor entregue pelo cliente, para cálculo do troco
+	PayerLabel    string               `json:"payer_label"`
+	Items         []PaymentItemRequest `json:"items" binding:"dive"`
+	CashSessionID *uuid.UUID           `json:"cash_session_id"` // Vazio usa o turno de caixa do operador ou o único aberto
+}
+
+type PaymentHandler struct {
+	paymentService *services.PaymentService
+}
+
+func NewPaymentHandler(paymentService *services.PaymentService) *PaymentHandler {
+	return &PaymentHandler{
+		paymentService: paymentService,
+	}
+}
+
+// paymentError traduz os erros de pagamento para respostas HTTP
+func paymentError(c *gin.Context, err error) {
+	var transitionErr *services.InvalidTransitionError
+	switch {
+	case errors.Is(err, services.ErrInvalidPayment), errors.Is(err, services.ErrOrderClosed):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.As(err, &transitionErr), errors.Is(err, services.ErrCashSessionRequired),
+		errors.Is(err, services.ErrCashSessionClosed):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrOrderNotFound), errors.Is(err, repositories.ErrPaymentNotFound),
+		errors.Is(err, repositories.ErrCashSessionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// Create registra um pagamento parcial do pedido; o pedido é fechado quando o saldo zera
+func (h *PaymentHandler) Create(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	orderID, ok := uuidParam(c, "order_id", "order")
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req PaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input := services.PaymentInput{
+		Method:        req.Method,
+		Amount:        req.Amount,
+		Tendered:      req.Tendered,
+		PayerLabel:    req.PayerLabel,
+		ActorID:       userID,
+		CashSessionID: req.CashSessionID,
+	}
+	for _, item := range req.Items {
+		input.Items = append(input.Items, services.PaymentItemInput{
+			OrderItemID: item.OrderItemID,
+			Quantity:    item.Quantity,
+		})
+	}
+
+	payment, order, err := h.paymentService.Register(restaurantID, orderID, input)
+	if err != nil {
+		paymentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"payment": payment,
+		"order":   order,
+		"balance": order.Balance(),
+	})
+}
+
+// List retorna os pagamentos do pedido e o saldo restante
+func (h *PaymentHandler) List(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	orderID, ok := uuidParam(c, "order_id", "order")
+	if !ok {
+		return
+	}
+
+	payments, err := h.paymentService.ListByOrder(restaurantID, orderID)
+	if err != nil {
+		paymentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, payments)
+}
+
+// Void estorna um pagamento de um pedido ainda aberto
+func (h *PaymentHandler) Void(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	orderID, ok := uuidParam(c, "order_id", "order")
+	if !ok {
+		return
+	}
+
+	paymentID, ok := uuidParam(c, "payment_id", "payment")
+	if !ok {
+		return
+	}
+
+	order, err := h.paymentService.Void(restaurantID, orderID, paymentID)
+	if err != nil {
+		paymentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "payment voided successfully",
+		"order":   order,
+		"balance": order.Balance(),
+	})
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type PINLoginRequest struct {
+	UserID uuid.UUID `json:"user_id" binding:"required"`
+	PIN    string    `json:"pin" binding:"required"`
+}
+
+type SetPINRequest struct {
+	Password string `json:"password" binding:"required"`
+	PIN      string `json:"pin" binding:"required"`
+}
+
+// ResetPINRequest define o PIN de um funcionário; PIN vazio remove o PIN
+type ResetPINRequest struct {
+	PIN string `json:"pin"`
+}
+
+type PINHandler struct {
+	pinService        *services.PINService
+	deviceService     *services.DeviceService
+	restaurantService *services.RestaurantService
+}
+
+func NewPINHandler(pinService *services.PINService, deviceService *services.DeviceService, restaurantService *services.RestaurantService) *PINHandler {
+	return &PINHandler{
+		pinService:        pinService,
+		deviceService:     deviceService,
+		restaurantService: restaurantService,
+	}
+}
+
+// pinError traduz os erros do serviço de PIN para respostas HTTP
+func pinError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidPIN):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrPINLoginFailed):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidPassword):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrPINLocked):
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrDeviceScope), errors.Is(err, repositories.ErrDeviceNotFound):
+		deviceError(c, err)
+	default:
+		membershipError(c, err)
+	}
+}
+
+// Members lista os funcionários que podem entrar no terminal com PIN, para a tela de desbloqueio
+func (h *PINHandler) Members(c *gin.Context) {
+	device, ok := deviceFromContext(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.pinService.Members(device)
+	if err != nil {
+		pinError(c, err)
+		return
+	}
+
+	response := make([]gin.H, 0, len(members))
+	for _, member := range members {
+		response = append(response, gin.H{
+			"id":   member.UserID,
+			"name": member.User.Name,
+			"type": member.Type,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"device":  gin.H{"id": device.ID, "name": device.Name, "active_user_id": device.ActiveUserID},
+		"members": response,
+	})
+}
+
+// Login desbloqueia o terminal com o PIN do funcionário. O token é de curta duração, não tem
+// refresh token e deixa de valer quando o terminal bloqueia.
+func (h *PINHandler) Login(c *gin.Context) {
+	current, ok := deviceFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req PINLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, user, device, err := h.pinService.Login(current, req.UserID, req.PIN)
+	if err != nil {
+		pinError(c, err)
+		return
+	}
+
+	if !restaurantActive(c, h.restaurantService, user) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token":      session.AccessToken,
+		"expires_at": session.ExpiresAt,
+		"user": gin.H{
+			"id":            user.ID,
+			"name":          user.Name,
+			"email":         user.Email,
+			"type":          user.Type,
+			"restaurant_id": user.RestaurantID,
+		},
+		"device": gin.H{
+			"id":                device.ID,
+			"name":              device.Name,
+			"auto_lock_minutes": device.AutoLockMinutes,
+		},
+	})
+}
+
+// Lock bloqueia o terminal; o token do funcionário conectado deixa de valer
+func (h *PINHandler) Lock(c *gin.Context) {
+	device, ok := deviceFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.deviceService.Lock(device); err != nil {
+		pinError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "device locked"})
+}
+
+// SetMyPIN cadastra ou troca o PIN do usuário autenticado, que confirma a senha
+func (h *PINHandler) SetMyPIN(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req SetPINRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.pinService.SetPIN(userID, req.Password, req.PIN); err != nil {
+		pinError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "PIN updated successfully"})
+}
+
+func (h *PINHandler) ClearMyPIN(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.pinService.ClearPIN(userID); err != nil {
+		pinError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "PIN removed successfully"})
+}
+
+// ResetMemberPIN define ou remove o PIN de um funcionário do restaurante, por exemplo quando ele o esquece
+func (h *PINHandler) ResetMemberPIN(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	userID, ok := uuidParam(c, "user_id", "user")
+	if !ok {
+		return
+	}
+
+	var req ResetPINRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.pinService.ResetMemberPIN(restaurantID, userID, actor, req.PIN); err != nil {
+		pinError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "PIN updated successfully"})
+}
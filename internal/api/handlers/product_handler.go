@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -12,13 +13,13 @@ import (
 )
 
 type ProductRequest struct {
-	Name        string  `json:"name" binding:"required"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" binding:"required,gt=0"`
-	CategoryID  string  `json:"category_id" binding:"required"`
-	InStock     bool    `json:"in_stock"`
-	ImageURL    string  `json:"image_url"`
-	Type        string  `json:"type"` // Mantido para compatibilidade
+	Name        string       `json:"name" binding:"required"`
+	Description string       `json:"description"`
+	Price       models.Money `json:"price" binding:"required,gt=0"`
+	CategoryID  string       `json:"category_id" binding:"required"`
+	InStock     bool         `json:"in_stock"`
+	ImageURL    string       `json:"image_url"`
+	Type        string       `json:"type"` // Mantido para compatibilidade
 }
 
 type ProductHandler struct {
@@ -437,6 +438,10 @@ func (h *ProductHandler) UpdateStock(c *gin.Context) {
 	}
 
 	if err := h.productService.UpdateStock(restaurant_uuid, prodUUID, req.InStock); err != nil {
+		if errors.Is(err, services.ErrStockFromRecipe) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
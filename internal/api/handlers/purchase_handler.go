@@ -0,0 +1,411 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SupplierRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Document    string `json:"document"`
+	ContactName string `json:"contact_name"`
+	Phone       string `json:"phone"`
+	Email       string `json:"email"`
+	Notes       string `json:"notes"`
+	Active      *bool  `json:"active"`
+}
+
+type PurchaseOrderItemRequest struct {
+	IngredientID uuid.UUID       `json:"ingredient_id" binding:"required"`
+	Quantity     models.Quantity `json:"quantity"`
+	UnitCost     models.Money    `json:"unit_cost"`
+}
+
+type PurchaseOrderRequest struct {
+	SupplierID uuid.UUID                  `json:"supplier_id" binding:"required"`
+	Notes      string                     `json:"notes"`
+	ExpectedAt *time.Time                 `json:"expected_at"`
+	Items      []PurchaseOrderItemRequest `json:"items"`
+}
+
+func (r PurchaseOrderRequest) input() services.PurchaseOrderInput {
+	items := make([]services.PurchaseOrderItemInput, 0, len(r.Items))
+	for _, item := range r.Items {
+		items = append(items, services.PurchaseOrderItemInput{
+			IngredientID: item.IngredientID,
+			Quantity:     item.Quantity,
+			UnitCost:     item.UnitCost,
+		})
+	}
+	return services.PurchaseOrderInput{
+		SupplierID: r.SupplierID,
+		Notes:      r.Notes,
+		ExpectedAt: r.ExpectedAt,
+		Items:      items,
+	}
+}
+
+type ReceiptLineRequest struct {
+	ItemID   uuid.UUID       `json:"item_id" binding:"required"`
+	Quantity models.Quantity `json:"quantity"`
+}
+
+// ReceiptRequest registra mercadorias recebidas; sem itens, tudo o que falta é recebido
+type ReceiptRequest struct {
+	Items         []ReceiptLineRequest `json:"items"`
+	PaymentMethod string               `json:"payment_method"`
+}
+
+type PurchaseHandler struct {
+	purchaseService *services.PurchaseService
+}
+
+func NewPurchaseHandler(purchaseService *services.PurchaseService) *PurchaseHandler {
+	return &PurchaseHandler{
+		purchaseService: purchaseService,
+	}
+}
+
+// purchaseError traduz os erros do serviço de compras para respostas HTTP
+func purchaseError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidSupplier), errors.Is(err, services.ErrInvalidPurchaseOrder):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrSupplierInUse), errors.Is(err, services.ErrPurchaseOrderStatus):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrSupplierNotFound), errors.Is(err, repositories.ErrPurchaseOrderNotFound),
+		errors.Is(err, repositories.ErrIngredientNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+func (h *PurchaseHandler) CreateSupplier(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req SupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	supplier := &models.Supplier{
+		RestaurantID: restaurantID,
+		Name:         req.Name,
+		Document:     req.Document,
+		ContactName:  req.ContactName,
+		Phone:        req.Phone,
+		Email:        req.Email,
+		Notes:        req.Notes,
+		Active:       true,
+	}
+	if req.Active != nil {
+		supplier.Active = *req.Active
+	}
+
+	if err := h.purchaseService.CreateSupplier(supplier); err != nil {
+		purchaseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, supplier)
+}
+
+// ListSuppliers aceita ?active=true para listar apenas os fornecedores ativos
+func (h *PurchaseHandler) ListSuppliers(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	suppliers, err := h.purchaseService.ListSuppliers(restaurantID, c.Query("active") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, suppliers)
+}
+
+func (h *PurchaseHandler) GetSupplier(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	supplierID, ok := uuidParam(c, "supplier_id", "supplier")
+	if !ok {
+		return
+	}
+
+	supplier, err := h.purchaseService.GetSupplier(restaurantID, supplierID)
+	if err != nil {
+		purchaseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, supplier)
+}
+
+func (h *PurchaseHandler) UpdateSupplier(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	supplierID, ok := uuidParam(c, "supplier_id", "supplier")
+	if !ok {
+		return
+	}
+
+	var req SupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	supplier, err := h.purchaseService.GetSupplier(restaurantID, supplierID)
+	if err != nil {
+		purchaseError(c, err)
+		return
+	}
+
+	supplier.Name = req.Name
+	supplier.Document = req.Document
+	supplier.ContactName = req.ContactName
+	supplier.Phone = req.Phone
+	supplier.Email = req.Email
+	supplier.Notes = req.Notes
+	if req.Active != nil {
+		supplier.Active = *req.Active
+	}
+
+	if err := h.purchaseService.UpdateSupplier(supplier); err != nil {
+		purchaseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, supplier)
+}
+
+func (h *PurchaseHandler) DeleteSupplier(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	supplierID, ok := uuidParam(c, "supplier_id", "supplier")
+	if !ok {
+		return
+	}
+
+	if err := h.purchaseService.DeleteSupplier(restaurantID, supplierID); err != nil {
+		purchaseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "supplier deleted successfully"})
+}
+
+func (h *PurchaseHandler) CreatePurchaseOrder(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req PurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := h.purchaseService.CreatePurchaseOrder(restaurantID, userID, req.input())
+	if err != nil {
+		purchaseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+// ListPurchaseOrders aceita os filtros ?supplier_id= e ?status=
+func (h *PurchaseHandler) ListPurchaseOrders(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var filter repositories.PurchaseOrderFilter
+	if value := c.Query("supplier_id"); value != "" {
+		supplierID, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid supplier ID"})
+			return
+		}
+		filter.SupplierID = &supplierID
+	}
+
+	switch status := models.PurchaseOrderStatus(c.Query("status")); status {
+	case "", models.PurchaseOrderStatusDraft, models.PurchaseOrderStatusOrdered, models.PurchaseOrderStatusPartiallyReceived,
+		models.PurchaseOrderStatusReceived, models.PurchaseOrderStatusCancelled:
+		filter.Status = status
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+		return
+	}
+
+	orders, err := h.purchaseService.ListPurchaseOrders(restaurantID, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, orders)
+}
+
+func (h *PurchaseHandler) GetPurchaseOrder(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	orderID, ok := uuidParam(c, "purchase_order_id", "purchase order")
+	if !ok {
+		return
+	}
+
+	order, err := h.purchaseService.GetPurchaseOrder(restaurantID, orderID)
+	if err != nil {
+		purchaseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+func (h *PurchaseHandler) UpdatePurchaseOrder(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	orderID, ok := uuidParam(c, "purchase_order_id", "purchase order")
+	if !ok {
+		return
+	}
+
+	var req PurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := h.purchaseService.UpdatePurchaseOrder(restaurantID, orderID, req.input())
+	if err != nil {
+		purchaseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+func (h *PurchaseHandler) PlaceOrder(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	orderID, ok := uuidParam(c, "purchase_order_id", "purchase order")
+	if !ok {
+		return
+	}
+
+	order, err := h.purchaseService.PlaceOrder(restaurantID, orderID)
+	if err != nil {
+		purchaseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+func (h *PurchaseHandler) CancelPurchaseOrder(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	orderID, ok := uuidParam(c, "purchase_order_id", "purchase order")
+	if !ok {
+		return
+	}
+
+	order, err := h.purchaseService.Cancel(restaurantID, orderID)
+	if err != nil {
+		purchaseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// Receive dá entrada das mercadorias no estoque e lança a despesa no financeiro
+func (h *PurchaseHandler) Receive(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	orderID, ok := uuidParam(c, "purchase_order_id", "purchase order")
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	// O corpo é opcional: sem ele, tudo o que falta é recebido
+	var req ReceiptRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	lines := make([]services.ReceiptLine, 0, len(req.Items))
+	for _, item := range req.Items {
+		lines = append(lines, services.ReceiptLine{ItemID: item.ItemID, Quantity: item.Quantity})
+	}
+
+	order, err := h.purchaseService.Receive(restaurantID, orderID, userID, services.ReceiptInput{
+		Items:         lines,
+		PaymentMethod: req.PaymentMethod,
+	})
+	if err != nil {
+		purchaseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
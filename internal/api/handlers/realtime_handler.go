@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/infrastructure/realtime"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// defaultConnectionLifetime limita conexões abertas com tokens sem data de expiração
+const defaultConnectionLifetime = 24 * time.Hour
+
+type RealtimeHandler struct {
+	hub      *realtime.Hub
+	upgrader websocket.Upgrader
+}
+
+func NewRealtimeHandler(hub *realtime.Hub) *RealtimeHandler {
+	return &RealtimeHandler{
+		hub: hub,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			// A autenticação é feita pelo token e não por cookies, então qualquer origem é aceita
+			CheckOrigin: func(r *http.Request) bool {
+				return true
+			},
+		},
+	}
+}
+
+// parseTopics lê a lista de tópicos separados por vírgula; vazia assina todos
+func parseTopics(value string) ([]realtime.Topic, bool) {
+	if value == "" {
+		return realtime.Topics, true
+	}
+
+	var topics []realtime.Topic
+	for _, name := range strings.Split(value, ",") {
+		topic := realtime.Topic(strings.TrimSpace(name))
+		if !topic.Valid() {
+			return nil, false
+		}
+		topics = append(topics, topic)
+	}
+	return topics, true
+}
+
+// Connect abre a conexão WebSocket de eventos do restaurante do usuário autenticado.
+// Os tópicos iniciais vêm de ?topics=orders,kitchen e podem ser alterados enviando
+// {"action": "subscribe"|"unsubscribe", "topics": [...]}.
+// Superadmins informam o restaurante em ?restaurant_id=.
+func (h *RealtimeHandler) Connect(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	restaurantIDRaw, _ := c.Get("restaurant_id")
+	restaurantIDPtr, _ := restaurantIDRaw.(*uuid.UUID)
+
+	var restaurantID uuid.UUID
+	if restaurantIDPtr != nil {
+		restaurantID = *restaurantIDPtr
+	} else if userType, _ := c.Get("user_type"); userType == models.UserTypeSuperAdmin {
+		restaurantID, err = uuid.Parse(c.Query("restaurant_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid restaurant ID"})
+			return
+		}
+	} else {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "restaurant ID is nil"})
+		return
+	}
+
+	topics, ok := parseTopics(c.Query("topics"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid topic"})
+		return
+	}
+
+	expiresAt := time.Now().Add(defaultConnectionLifetime)
+	if value, ok := c.Get("token_expires_at"); ok {
+		expiresAt = value.(time.Time)
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// O upgrader já respondeu ao cliente com o erro
+		log.Printf("Erro ao conectar WebSocket: %v", err)
+		return
+	}
+
+	h.hub.Serve(conn, restaurantID, userID, expiresAt, topics)
+}
@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// defaultUpcomingDays é o horizonte da prévia quando ?until= não é informado
+const defaultUpcomingDays = 30
+
+// RecurringTransactionRequest usa datas no formato YYYY-MM-DD, como os lançamentos manuais
+type RecurringTransactionRequest struct {
+	Type          models.TransactionType     `json:"type" binding:"required"`
+	Category      models.TransactionCategory `json:"category" binding:"required"`
+	Amount        models.Money               `json:"amount" binding:"required,gt=0"`
+	Description   string                     `json:"description" binding:"required"`
+	PaymentMethod string                     `json:"payment_method"`
+	Frequency     models.RecurrenceFrequency `json:"frequency" binding:"required"`
+	Interval      int                        `json:"interval"`
+	DayOfMonth    *int                       `json:"day_of_month"`
+	Weekday       *int                       `json:"weekday"`
+	StartDate     string                     `json:"start_date" binding:"required"`
+	EndDate       *string                    `json:"end_date"`
+	Active        *bool                      `json:"active"`
+}
+
+// input converte a requisição; em caso de falha a resposta de erro já é escrita e ok retorna false
+func (req *RecurringTransactionRequest) input(c *gin.Context) (services.RecurringTransactionInput, bool) {
+	input := services.RecurringTransactionInput{
+		Type:          req.Type,
+		Category:      req.Category,
+		Amount:        req.Amount,
+		Description:   req.Description,
+		PaymentMethod: req.PaymentMethod,
+		Frequency:     req.Frequency,
+		Interval:      req.Interval,
+		DayOfMonth:    req.DayOfMonth,
+		Weekday:       req.Weekday,
+		Active:        req.Active,
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start date format (required: YYYY-MM-DD)"})
+		return input, false
+	}
+	input.StartDate = startDate
+
+	if req.EndDate != nil && *req.EndDate != "" {
+		endDate, err := time.Parse("2006-01-02", *req.EndDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end date format (required: YYYY-MM-DD)"})
+			return input, false
+		}
+		input.EndDate = &endDate
+	}
+	return input, true
+}
+
+type RecurringTransactionHandler struct {
+	recurringService *services.RecurringTransactionService
+}
+
+func NewRecurringTransactionHandler(recurringService *services.RecurringTransactionService) *RecurringTransactionHandler {
+	return &RecurringTransactionHandler{
+		recurringService: recurringService,
+	}
+}
+
+// recurringError traduz os erros do serviço de lançamentos recorrentes para respostas HTTP
+func recurringError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidRecurringTransaction):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrRecurringTransactionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+func (h *RecurringTransactionHandler) Create(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req RecurringTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input, ok := req.input(c)
+	if !ok {
+		return
+	}
+
+	recurring, err := h.recurringService.Create(restaurantID, userID, input)
+	if err != nil {
+		recurringError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, recurring)
+}
+
+func (h *RecurringTransactionHandler) List(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	recurring, err := h.recurringService.List(restaurantID)
+	if err != nil {
+		recurringError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurring)
+}
+
+func (h *RecurringTransactionHandler) GetByID(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, ok := uuidParam(c, "recurring_id", "recurring transaction")
+	if !ok {
+		return
+	}
+
+	recurring, err := h.recurringService.Get(restaurantID, id)
+	if err != nil {
+		recurringError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurring)
+}
+
+func (h *RecurringTransactionHandler) Update(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, ok := uuidParam(c, "recurring_id", "recurring transaction")
+	if !ok {
+		return
+	}
+
+	var req RecurringTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input, ok := req.input(c)
+	if !ok {
+		return
+	}
+
+	recurring, err := h.recurringService.Update(restaurantID, id, input)
+	if err != nil {
+		recurringError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurring)
+}
+
+func (h *RecurringTransactionHandler) Delete(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, ok := uuidParam(c, "recurring_id", "recurring transaction")
+	if !ok {
+		return
+	}
+
+	if err := h.recurringService.Delete(restaurantID, id); err != nil {
+		recurringError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "recurring transaction deleted successfully"})
+}
+
+// Upcoming lista os lançamentos previstos até ?until=YYYY-MM-DD (padrão: próximos 30 dias)
+func (h *RecurringTransactionHandler) Upcoming(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	until := time.Now().AddDate(0, 0, defaultUpcomingDays)
+	if value := c.Query("until"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid until date format (required: YYYY-MM-DD)"})
+			return
+		}
+		until = parsed
+	}
+
+	upcoming, err := h.recurringService.Upcoming(restaurantID, until)
+	if err != nil {
+		recurringError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, upcoming)
+}
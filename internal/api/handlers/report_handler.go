@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"api-jet-manager/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportHandler struct {
+	reportService *services.ReportService
+}
+
+func NewReportHandler(reportService *services.ReportService) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+	}
+}
+
+// reportError traduz os erros do serviço de relatórios para respostas HTTP
+func reportError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidReportPeriod):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// reportPeriod lê ?from=YYYY-MM-DD&to=YYYY-MM-DD, ambos inclusivos, no fuso de ?timezone= (padrão UTC).
+// Em caso de falha a resposta de erro já é escrita e ok retorna false.
+func reportPeriod(c *gin.Context) (services.ReportPeriod, bool) {
+	location := time.UTC
+	if name := c.Query("timezone"); name != "" {
+		loaded, err := time.LoadLocation(name)
+		if err != nil || name == "Local" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid timezone"})
+			return services.ReportPeriod{}, false
+		}
+		location = loaded
+	}
+
+	if c.Query("from") == "" || c.Query("to") == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to parameters are required"})
+		return services.ReportPeriod{}, false
+	}
+
+	var period services.ReportPeriod
+	for param, target := range map[string]*time.Time{"from": &period.From, "to": &period.To} {
+		parsed, err := time.ParseInLocation("2006-01-02", c.Query(param), location)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param + " date format (required: YYYY-MM-DD)"})
+			return services.ReportPeriod{}, false
+		}
+		*target = parsed
+	}
+	period.To = period.To.AddDate(0, 0, 1)
+	return period, true
+}
+
+// ProfitAndLoss retorna a DRE por categoria comparada ao período anterior de mesma duração
+func (h *ReportHandler) ProfitAndLoss(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	period, ok := reportPeriod(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.reportService.ProfitAndLoss(restaurantID, period)
+	if err != nil {
+		reportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// CashFlow retorna a série diária de entradas e saídas
+func (h *ReportHandler) CashFlow(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	period, ok := reportPeriod(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.reportService.CashFlow(restaurantID, period)
+	if err != nil {
+		reportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// PaymentMethods retorna os lançamentos agrupados por forma de pagamento
+func (h *ReportHandler) PaymentMethods(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	period, ok := reportPeriod(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.reportService.PaymentMethods(restaurantID, period)
+	if err != nil {
+		reportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ReservationRequest struct {
+	CustomerName    string      `json:"customer_name" binding:"required"`
+	CustomerPhone   string      `json:"customer_phone" binding:"required"`
+	PartySize       int         `json:"party_size" binding:"required,min=1"`
+	StartsAt        time.Time   `json:"starts_at" binding:"required"`
+	DurationMinutes int         `json:"duration_minutes" binding:"omitempty,min=1"` // Padrão de 120 minutos
+	TableIDs        []uuid.UUID `json:"table_ids" binding:"required,min=1"`
+	Notes           string      `json:"notes"`
+}
+
+func (r ReservationRequest) input() services.ReservationInput {
+	return services.ReservationInput{
+		CustomerName:    r.CustomerName,
+		CustomerPhone:   r.CustomerPhone,
+		PartySize:       r.PartySize,
+		StartsAt:        r.StartsAt,
+		DurationMinutes: r.DurationMinutes,
+		TableIDs:        r.TableIDs,
+		Notes:           r.Notes,
+	}
+}
+
+type ReservationHandler struct {
+	reservationService *services.ReservationService
+}
+
+func NewReservationHandler(reservationService *services.ReservationService) *ReservationHandler {
+	return &ReservationHandler{
+		reservationService: reservationService,
+	}
+}
+
+// reservationError traduz os erros do serviço de reservas para respostas HTTP
+func reservationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidReservation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrReservationConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrReservationNotFound), errors.Is(err, repositories.ErrTableNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+func (h *ReservationHandler) Create(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req ReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reservation, err := h.reservationService.Create(restaurantID, userID, req.input())
+	if err != nil {
+		reservationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, reservation)
+}
+
+// List aceita os filtros ?from= e ?to= (RFC3339) sobre o horário de início e ?status=
+func (h *ReservationHandler) List(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var filter repositories.ReservationFilter
+	for param, target := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param + " date"})
+			return
+		}
+		*target = &parsed
+	}
+
+	switch status := models.ReservationStatus(c.Query("status")); status {
+	case "", models.ReservationStatusBooked, models.ReservationStatusSeated, models.ReservationStatusCompleted,
+		models.ReservationStatusCancelled, models.ReservationStatusNoShow:
+		filter.Status = status
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+		return
+	}
+
+	reservations, err := h.reservationService.List(restaurantID, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reservations)
+}
+
+func (h *ReservationHandler) GetByID(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, ok := uuidParam(c, "reservation_id", "reservation")
+	if !ok {
+		return
+	}
+
+	reservation, err := h.reservationService.GetByID(restaurantID, id)
+	if err != nil {
+		reservationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+func (h *ReservationHandler) Update(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, ok := uuidParam(c, "reservation_id", "reservation")
+	if !ok {
+		return
+	}
+
+	var req ReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reservation, err := h.reservationService.Update(restaurantID, id, req.input())
+	if err != nil {
+		reservationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+// reservationAction executa uma ação de status sobre a reserva da URL
+func (h *ReservationHandler) reservationAction(c *gin.Context, action func(restaurantID, id uuid.UUID) (*models.Reservation, error)) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, ok := uuidParam(c, "reservation_id", "reservation")
+	if !ok {
+		return
+	}
+
+	reservation, err := action(restaurantID, id)
+	if err != nil {
+		reservationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+func (h *ReservationHandler) Seat(c *gin.Context) {
+	h.reservationAction(c, h.reservationService.Seat)
+}
+
+func (h *ReservationHandler) Cancel(c *gin.Context) {
+	h.reservationAction(c, h.reservationService.Cancel)
+}
+
+func (h *ReservationHandler) NoShow(c *gin.Context) {
+	h.reservationAction(c, h.reservationService.NoShow)
+}
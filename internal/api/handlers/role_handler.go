@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RoleRequest struct {
+	Name        string              `json:"name" binding:"required"`
+	Description string              `json:"description"`
+	Permissions []models.Permission `json:"permissions"`
+}
+
+// AssignRoleRequest define o papel do usuário; role_id nulo volta ao papel padrão do tipo
+type AssignRoleRequest struct {
+	RoleID *uuid.UUID `json:"role_id"`
+}
+
+func (req *RoleRequest) input() services.RoleInput {
+	return services.RoleInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	}
+}
+
+type RoleHandler struct {
+	roleService *services.RoleService
+}
+
+func NewRoleHandler(roleService *services.RoleService) *RoleHandler {
+	return &RoleHandler{
+		roleService: roleService,
+	}
+}
+
+// roleError traduz os erros do serviço de papéis para respostas HTTP
+func roleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidRole):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrPermissionDenied):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrRoleBuiltIn):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrRoleNotFound), errors.Is(err, repositories.ErrUserNotFound),
+		errors.Is(err, repositories.ErrMembershipNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// Catalog lista todas as permissões que podem ser concedidas
+func (h *RoleHandler) Catalog(c *gin.Context) {
+	c.JSON(http.StatusOK, models.AllPermissions)
+}
+
+// MyPermissions lista as permissões do usuário autenticado no seu restaurante
+func (h *RoleHandler) MyPermissions(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var restaurantID uuid.UUID
+	if actor.Type != models.UserTypeSuperAdmin {
+		if restaurantID, ok = restaurantIDFromContext(c); !ok {
+			return
+		}
+	}
+
+	permissions, err := h.roleService.Permissions(restaurantID, actor)
+	if err != nil {
+		roleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"permissions": permissions})
+}
+
+func (h *RoleHandler) List(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	roles, err := h.roleService.List(restaurantID)
+	if err != nil {
+		roleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, roles)
+}
+
+func (h *RoleHandler) GetByID(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, ok := uuidParam(c, "role_id", "role")
+	if !ok {
+		return
+	}
+
+	role, err := h.roleService.Get(restaurantID, id)
+	if err != nil {
+		roleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, role)
+}
+
+func (h *RoleHandler) Create(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, err := h.roleService.Create(restaurantID, actor, req.input())
+	if err != nil {
+		roleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, role)
+}
+
+func (h *RoleHandler) Update(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, ok := uuidParam(c, "role_id", "role")
+	if !ok {
+		return
+	}
+
+	var req RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, err := h.roleService.Update(restaurantID, id, actor, req.input())
+	if err != nil {
+		roleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, role)
+}
+
+func (h *RoleHandler) Delete(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, ok := uuidParam(c, "role_id", "role")
+	if !ok {
+		return
+	}
+
+	if err := h.roleService.Delete(restaurantID, id); err != nil {
+		roleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "role deleted successfully"})
+}
+
+// AssignRole define o papel de um usuário no restaurante
+func (h *RoleHandler) AssignRole(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	userID, ok := uuidParam(c, "user_id", "user")
+	if !ok {
+		return
+	}
+
+	var req AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	membership, err := h.roleService.AssignRole(restaurantID, userID, actor, req.RoleID)
+	if err != nil {
+		roleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, membership)
+}
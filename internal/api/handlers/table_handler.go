@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
 	"api-jet-manager/internal/services"
 
 	"github.com/gin-gonic/gin"
@@ -15,6 +17,39 @@ type TableRequest struct {
 	Capacity int `json:"capacity" binding:"required,min=1"`
 }
 
+type AreaRequest struct {
+	Name      string `json:"name" binding:"required"`
+	SortOrder int    `json:"sort_order"`
+	Active    *bool  `json:"active"`
+}
+
+// TableLayoutRequest é a posição de uma mesa enviada pelo editor da planta
+type TableLayoutRequest struct {
+	TableID  uuid.UUID  `json:"table_id" binding:"required"`
+	AreaID   *uuid.UUID `json:"area_id"`
+	Shape    string     `json:"shape" binding:"required"`
+	PosX     float64    `json:"pos_x"`
+	PosY     float64    `json:"pos_y"`
+	Width    float64    `json:"width" binding:"required"`
+	Height   float64    `json:"height" binding:"required"`
+	Rotation float64    `json:"rotation"`
+}
+
+type FloorPlanLayoutRequest struct {
+	Tables []TableLayoutRequest `json:"tables" binding:"required,min=1,dive"`
+}
+
+// MergeTablesRequest lista as mesas que serão juntadas à mesa da URL
+type MergeTablesRequest struct {
+	TableIDs []uuid.UUID `json:"table_ids" binding:"required,min=1"`
+}
+
+// FloorPlanResponse traz os ambientes e as mesas para desenhar a planta do salão
+type FloorPlanResponse struct {
+	Areas  []models.DiningArea `json:"areas"`
+	Tables []models.Table      `json:"tables"`
+}
+
 type TableHandler struct {
 	tableService *services.TableService
 }
@@ -243,3 +278,218 @@ func (h *TableHandler) UpdateStatus(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "table status updated successfully"})
 }
+
+// floorPlanError traduz os erros da planta do salão para respostas HTTP
+func floorPlanError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidTableMerge), errors.Is(err, services.ErrInvalidTableLayout):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrTableNotFound), errors.Is(err, repositories.ErrAreaNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+func (h *TableHandler) FloorPlan(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	areas, err := h.tableService.ListAreas(restaurantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	tables, err := h.tableService.List(restaurantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, FloorPlanResponse{Areas: areas, Tables: tables})
+}
+
+func (h *TableHandler) UpdateLayout(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req FloorPlanLayoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	layouts := make([]services.TableLayout, 0, len(req.Tables))
+	for _, table := range req.Tables {
+		layouts = append(layouts, services.TableLayout{
+			TableID:  table.TableID,
+			AreaID:   table.AreaID,
+			Shape:    models.TableShape(table.Shape),
+			PosX:     table.PosX,
+			PosY:     table.PosY,
+			Width:    table.Width,
+			Height:   table.Height,
+			Rotation: table.Rotation,
+		})
+	}
+
+	tables, err := h.tableService.UpdateLayout(restaurantID, layouts)
+	if err != nil {
+		floorPlanError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tables)
+}
+
+func (h *TableHandler) Merge(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	tableID, ok := uuidParam(c, "table_id", "table")
+	if !ok {
+		return
+	}
+
+	var req MergeTablesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tables, err := h.tableService.Merge(restaurantID, tableID, req.TableIDs)
+	if err != nil {
+		floorPlanError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tables)
+}
+
+func (h *TableHandler) Split(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	tableID, ok := uuidParam(c, "table_id", "table")
+	if !ok {
+		return
+	}
+
+	tables, err := h.tableService.Split(restaurantID, tableID)
+	if err != nil {
+		floorPlanError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tables)
+}
+
+func (h *TableHandler) CreateArea(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req AreaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	area := &models.DiningArea{
+		RestaurantID: restaurantID,
+		Name:         req.Name,
+		SortOrder:    req.SortOrder,
+		Active:       true,
+	}
+	if req.Active != nil {
+		area.Active = *req.Active
+	}
+
+	if err := h.tableService.CreateArea(area); err != nil {
+		floorPlanError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, area)
+}
+
+func (h *TableHandler) ListAreas(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	areas, err := h.tableService.ListAreas(restaurantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, areas)
+}
+
+func (h *TableHandler) UpdateArea(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	areaID, ok := uuidParam(c, "area_id", "area")
+	if !ok {
+		return
+	}
+
+	var req AreaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	area, err := h.tableService.GetArea(restaurantID, areaID)
+	if err != nil {
+		floorPlanError(c, err)
+		return
+	}
+
+	area.Name = req.Name
+	area.SortOrder = req.SortOrder
+	if req.Active != nil {
+		area.Active = *req.Active
+	}
+
+	if err := h.tableService.UpdateArea(area); err != nil {
+		floorPlanError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, area)
+}
+
+func (h *TableHandler) DeleteArea(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	areaID, ok := uuidParam(c, "area_id", "area")
+	if !ok {
+		return
+	}
+
+	if err := h.tableService.DeleteArea(restaurantID, areaID); err != nil {
+		floorPlanError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "area deleted successfully"})
+}
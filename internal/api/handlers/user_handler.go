@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"fmt"
 	"net/http"
 
 	"api-jet-manager/internal/domain/models"
@@ -22,17 +21,22 @@ type RegisterRequest struct {
 	Password     string     `json:"password" binding:"required,min=6"`
 	Type         string     `json:"type"`
 	RestaurantID *uuid.UUID `json:"restaurant_id"`
+	RoleID       *uuid.UUID `json:"role_id"`
 }
 
 type UserHandler struct {
 	userService       *services.UserService
 	restaurantService *services.RestaurantService
+	roleService       *services.RoleService
+	membershipService *services.MembershipService
 }
 
-func NewUserHandler(userService *services.UserService, restaurantService *services.RestaurantService) *UserHandler {
+func NewUserHandler(userService *services.UserService, restaurantService *services.RestaurantService, roleService *services.RoleService, membershipService *services.MembershipService) *UserHandler {
 	return &UserHandler{
 		userService:       userService,
 		restaurantService: restaurantService,
+		roleService:       roleService,
+		membershipService: membershipService,
 	}
 }
 
@@ -43,28 +47,23 @@ func (h *UserHandler) Login(c *gin.Context) {
 		return
 	}
 
-	token, user, err := h.userService.Login(req.Email, req.Password)
+	tokens, user, err := h.userService.Login(req.Email, req.Password, clientInfo(c))
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
 		return
 	}
 
 	// Verificar se o restaurante está ativo (exceto para superadmin)
-	if user.Type != models.UserTypeSuperAdmin && user.RestaurantID != nil {
-		isActive, err := h.restaurantService.IsRestaurantActive(*user.RestaurantID)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "error verifying restaurant status"})
-			return
-		}
-		if !isActive {
-			c.JSON(http.StatusForbidden, gin.H{"error": "your restaurant subscription is inactive or expired"})
-			return
-		}
+	if !restaurantActive(c, h.restaurantService, user) {
+		return
 	}
 
 	// Resposta adaptada para o modelo multitenancy
 	response := gin.H{
-		"token": token,
+		"token":              tokens.AccessToken,
+		"expires_at":         tokens.ExpiresAt,
+		"refresh_token":      tokens.RefreshToken,
+		"refresh_expires_at": tokens.RefreshExpiresAt,
 		"user": gin.H{
 			"id":    user.ID,
 			"name":  user.Name,
@@ -83,6 +82,12 @@ func (h *UserHandler) Login(c *gin.Context) {
 		}
 	}
 
+	// Restaurantes disponíveis para troca do restaurante ativo
+	memberships, err := h.membershipService.ListForUser(user.ID)
+	if err == nil {
+		response["restaurants"] = restaurantOptions(memberships)
+	}
+
 	c.JSON(http.StatusOK, response)
 }
 
@@ -95,78 +100,55 @@ func (h *UserHandler) Register(c *gin.Context) {
 
 	// Definir tipo de usuário
 	userType := models.UserType(req.Type)
+	if userType == "" {
+		userType = models.UserTypeStaff
+	}
 
-	// Verificar permissões para criação de usuários
-	currentUserType, exists := c.Get("user_type")
-
-	// Se não existir contexto de usuário, só permite criar staff
-	if !exists && userType != models.UserTypeStaff {
+	// A rota autenticada exige users.write; sem usuário no contexto só permite criar staff
+	actor, authenticated := actorFromContext(c)
+	restaurantID := req.RestaurantID
+	if !authenticated {
 		userType = models.UserTypeStaff
-	} else if exists {
-		// Verificar permissões com base no tipo do usuário atual
-		currentType := currentUserType.(models.UserType)
-
-		// SuperAdmin pode criar qualquer tipo
-		if currentType == models.UserTypeSuperAdmin {
-			// Permite o tipo solicitado
-		} else if currentType == models.UserTypeAdmin {
-			// Admin só pode criar manager ou staff
-			if userType != models.UserTypeManager && userType != models.UserTypeStaff {
-				userType = models.UserTypeStaff
-			}
-		} else if currentType == models.UserTypeManager {
-			// Manager só pode criar staff
-			userType = models.UserTypeStaff
-		} else {
-			// Staff não pode criar usuários
-			c.JSON(http.StatusForbidden, gin.H{"error": "you don't have permission to create users"})
+	} else if actor.Type != models.UserTypeSuperAdmin {
+		// Usuários de restaurante só cadastram usuários do próprio restaurante
+		currentRestaurantID, ok := restaurantIDFromContext(c)
+		if !ok {
+			return
+		}
+		if restaurantID != nil && *restaurantID != currentRestaurantID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "you can only create users for your own restaurant"})
 			return
 		}
+		restaurantID = &currentRestaurantID
 	}
 
-	// Verificar associação com restaurante
-	var restaurantID *uuid.UUID
-
-	// Se for superadmin, não precisa de restaurante
+	// Superadmin não pertence a restaurante
 	if userType == models.UserTypeSuperAdmin {
 		restaurantID = nil
 	} else {
-		// Se um restaurant_id foi fornecido
-		if req.RestaurantID != nil {
-			restaurantID = req.RestaurantID
-			fmt.Println("restaurant", *restaurantID)
-
-			// Verificar se o restaurante existe
-			_, err := h.restaurantService.GetByID(*restaurantID)
-			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "restaurant not found"})
-				return
-			}
-
-			// Se não for superadmin, verificar se o usuário atual pertence ao mesmo restaurante
-			if exists && currentUserType.(models.UserType) != models.UserTypeSuperAdmin {
-				currentRestaurantID, hasRestaurant := c.Get("restaurant_id")
-				if !hasRestaurant || currentRestaurantID != *restaurantID {
-					c.JSON(http.StatusForbidden, gin.H{"error": "you can only create users for your own restaurant"})
-					return
-				}
-			}
-		} else {
-			// Se nenhum restaurant_id foi fornecido, usar o do usuário atual
-			if exists && currentUserType.(models.UserType) != models.UserTypeSuperAdmin {
-				currentRestaurantID, hasRestaurant := c.Get("restaurant_id")
-				if hasRestaurant {
-					restaurantID = new(uuid.UUID)
-					*restaurantID = currentRestaurantID.(uuid.UUID)
-				} else {
-					c.JSON(http.StatusBadRequest, gin.H{"error": "restaurant_id is required"})
-					return
-				}
-			} else {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "restaurant_id is required"})
-				return
-			}
+		if restaurantID == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "restaurant_id is required"})
+			return
+		}
+		if _, err := h.restaurantService.GetByID(*restaurantID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "restaurant not found"})
+			return
+		}
+	}
+
+	// O tipo e o papel atribuídos seguem a hierarquia e as permissões de quem cadastra
+	if authenticated {
+		var roleRestaurantID uuid.UUID
+		if restaurantID != nil {
+			roleRestaurantID = *restaurantID
+		}
+		if err := h.roleService.CheckAssignment(roleRestaurantID, actor, userType, req.RoleID); err != nil {
+			roleError(c, err)
+			return
 		}
+	} else if req.RoleID != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you don't have permission to assign roles"})
+		return
 	}
 
 	user := &models.User{
@@ -176,6 +158,9 @@ func (h *UserHandler) Register(c *gin.Context) {
 		Type:         userType,
 		RestaurantID: restaurantID,
 	}
+	if restaurantID != nil {
+		user.Memberships = []models.Membership{{RestaurantID: *restaurantID, Type: userType, RoleID: req.RoleID}}
+	}
 
 	if err := h.userService.Register(user); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -188,6 +173,7 @@ func (h *UserHandler) Register(c *gin.Context) {
 		"email":         user.Email,
 		"type":          user.Type,
 		"restaurant_id": user.RestaurantID,
+		"role_id":       req.RoleID,
 	})
 }
 
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WaitlistRequest struct {
+	CustomerName  string `json:"customer_name" binding:"required"`
+	CustomerPhone string `json:"customer_phone" binding:"required"`
+	PartySize     int    `json:"party_size" binding:"required,min=1"`
+	Notes         string `json:"notes"`
+}
+
+// SeatWaitlistRequest indica a mesa escolhida e se um pedido deve ser aberto para ela
+type SeatWaitlistRequest struct {
+	TableID   uuid.UUID `json:"table_id" binding:"required"`
+	OpenOrder bool      `json:"open_order"`
+}
+
+// WaitlistEntryResponse devolve o grupo criado junto com a estimativa calculada
+type WaitlistEntryResponse struct {
+	*models.WaitlistEntry
+	Quote *services.WaitQuote `json:"quote"`
+}
+
+type WaitlistHandler struct {
+	waitlistService *services.WaitlistService
+}
+
+func NewWaitlistHandler(waitlistService *services.WaitlistService) *WaitlistHandler {
+	return &WaitlistHandler{
+		waitlistService: waitlistService,
+	}
+}
+
+// waitlistError traduz os erros do serviço da fila de espera para respostas HTTP
+func waitlistError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidWaitlistEntry):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrTableNotAvailable):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrWaitlistEntryNotFound), errors.Is(err, repositories.ErrTableNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+func (h *WaitlistHandler) Add(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req WaitlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry := &models.WaitlistEntry{
+		RestaurantID:  restaurantID,
+		CustomerName:  req.CustomerName,
+		CustomerPhone: req.CustomerPhone,
+		PartySize:     req.PartySize,
+		Notes:         req.Notes,
+		UserID:        userID,
+	}
+
+	quote, err := h.waitlistService.Add(entry)
+	if err != nil {
+		waitlistError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, WaitlistEntryResponse{WaitlistEntry: entry, Quote: quote})
+}
+
+// Quote estima a espera de um grupo de ?party_size= antes de colocá-lo na fila
+func (h *WaitlistHandler) Quote(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	partySize, err := strconv.Atoi(c.Query("party_size"))
+	if err != nil || partySize <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid party size"})
+		return
+	}
+
+	quote, err := h.waitlistService.Quote(restaurantID, partySize)
+	if err != nil {
+		waitlistError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
+
+// List retorna a fila atual. Com ?since= (RFC3339) retorna também os grupos já atendidos.
+func (h *WaitlistHandler) List(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var (
+		entries []models.WaitlistEntry
+		err     error
+	)
+	if value := c.Query("since"); value != "" {
+		since, parseErr := time.Parse(time.RFC3339, value)
+		if parseErr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid since date"})
+			return
+		}
+		entries, err = h.waitlistService.ListSince(restaurantID, since)
+	} else {
+		entries, err = h.waitlistService.ListActive(restaurantID)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+func (h *WaitlistHandler) GetByID(c *gin.Context) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, ok := uuidParam(c, "entry_id", "waitlist entry")
+	if !ok {
+		return
+	}
+
+	entry, err := h.waitlistService.GetByID(restaurantID, id)
+	if err != nil {
+		waitlistError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// waitlistAction executa uma ação de status sobre o grupo da URL
+func (h *WaitlistHandler) waitlistAction(c *gin.Context, action func(restaurantID, id uuid.UUID) (*models.WaitlistEntry, error)) {
+	restaurantID, ok := restaurantIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, ok := uuidParam(c, "entry_id", "waitlist entry")
+	if !ok {
+		return
+	}
+
+	entry, err := action(restaurantID, id)
+	if err != nil {
+		waitlistError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *WaitlistHandler) Notify(c *gin.Context) {
+	h.waitlistAction(c, h.waitlistService.Notify)
+}
+
+func (h *WaitlistHandler) Drop(c *gin.Context) {
+	h.waitlistAction(c, h.waitlistService.Drop)
+}
+
+func (h *WaitlistHandler) Seat(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req SeatWaitlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.waitlistAction(c, func(restaurantID, id uuid.UUID) (*models.WaitlistEntry, error) {
+		return h.waitlistService.Seat(restaurantID, id, req.TableID, userID, req.OpenOrder)
+	})
+}
@@ -9,70 +9,91 @@ import (
 	"api-jet-manager/internal/infrastructure/auth"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
-func AuthMiddleware(jwtService *auth.JWTService) gin.HandlerFunc {
+// TokenDenylist informa se um token de acesso foi revogado antes de expirar
+type TokenDenylist interface {
+	IsRevoked(jti string) (bool, error)
+}
+
+// DeviceSessions informa se o token emitido no login por PIN ainda vale no terminal
+type DeviceSessions interface {
+	SessionActive(restaurantID *uuid.UUID, deviceID, jti string) (bool, error)
+}
+
+func AuthMiddleware(jwtService *auth.JWTService, denylist TokenDenylist, devices DeviceSessions) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authorization header is required"})
+		tokenString, ok := bearerToken(c)
+		if !ok {
 			return
 		}
 
-		parts := strings.Split(authHeader, "Bearer ")
-		if len(parts) != 2 {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid authorization format"})
+		claims, err := jwtService.ValidateToken(tokenString)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
 			return
 		}
 
-		tokenString := parts[1]
-		claims, err := jwtService.ValidateToken(tokenString)
+		// Tokens sem jti são anteriores à revogação e não podem ser encerrados, então são recusados
+		revoked, err := denylist.IsRevoked(claims.ID)
 		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "error verifying token"})
+			return
+		}
+		if revoked {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
 			return
 		}
 
+		// Tokens do login por PIN deixam de valer quando o terminal é bloqueado ou fica inativo
+		if claims.DeviceID != "" {
+			active, err := devices.SessionActive(claims.RestaurantID, claims.DeviceID, claims.ID)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "error verifying device session"})
+				return
+			}
+			if !active {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "device locked, enter your PIN again"})
+				return
+			}
+		}
+
 		// Armazena informações do usuário no contexto para uso posterior
 		c.Set("user_id", claims.UserID)
 		c.Set("email", claims.Email)
 		c.Set("user_type", claims.UserType)
 		c.Set("restaurant_id", claims.RestaurantID)
+		c.Set("token_id", claims.ID)
+		c.Set("session_id", claims.SessionID)
+		c.Set("device_id", claims.DeviceID)
+		if claims.ExpiresAt != nil {
+			c.Set("token_expires_at", claims.ExpiresAt.Time)
+		}
 
 		c.Next()
 	}
 }
 
-// Middleware para verificar se o usuário tem um tipo específico
-func UserTypeMiddleware(allowedTypes ...models.UserType) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		userType, exists := c.Get("user_type")
-		if !exists {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
-			return
-		}
-
-		userTypeValue, ok := userType.(models.UserType)
-		if !ok {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
-			return
-		}
-
-		// Superadmin sempre tem acesso
-		if userTypeValue == models.UserTypeSuperAdmin {
-			c.Next()
-			return
-		}
-
-		// Verifica se o tipo do usuário está entre os permitidos
-		for _, allowedType := range allowedTypes {
-			if userTypeValue == allowedType {
-				c.Next()
-				return
-			}
+// bearerToken lê o token do cabeçalho Authorization. Navegadores não permitem cabeçalhos
+// customizados ao abrir um WebSocket, então nesse caso o token também é aceito em ?access_token=.
+func bearerToken(c *gin.Context) (string, bool) {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		if strings.EqualFold(c.GetHeader("Upgrade"), "websocket") && c.Query("access_token") != "" {
+			return c.Query("access_token"), true
 		}
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authorization header is required"})
+		return "", false
+	}
 
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+	parts := strings.Split(authHeader, "Bearer ")
+	if len(parts) != 2 {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid authorization format"})
+		return "", false
 	}
+
+	return parts[1], true
 }
 
 // Middleware que restringe acesso apenas para superadmins
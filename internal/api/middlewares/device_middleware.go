@@ -0,0 +1,64 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"api-jet-manager/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeviceTokenHeader é o cabeçalho em que o terminal envia a credencial recebida no pareamento
+const DeviceTokenHeader = "X-Device-Token"
+
+// DeviceAuthenticator localiza o terminal pareado pela sua credencial; retorna nil se ela não valer
+type DeviceAuthenticator interface {
+	Authenticate(token, ip string) (*models.Device, error)
+}
+
+// DeviceAuthMiddleware autentica o próprio terminal, sem um usuário conectado. O restaurante das
+// rotas é o do terminal, e as ações que precisam de um responsável ficam em nome do terminal.
+func DeviceAuthMiddleware(devices DeviceAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader(DeviceTokenHeader)
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": DeviceTokenHeader + " header is required"})
+			return
+		}
+
+		device, err := devices.Authenticate(token, c.ClientIP())
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "error verifying device"})
+			return
+		}
+		if device == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or revoked device credential"})
+			return
+		}
+
+		c.Set("device", device)
+		c.Set("device_id", device.ID.String())
+		c.Set("restaurant_id", &device.RestaurantID)
+
+		c.Next()
+	}
+}
+
+// RequireDeviceScope libera a rota apenas para terminais cujo tipo permite a ação
+func RequireDeviceScope(scope models.DeviceScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, _ := c.Get("device")
+		device, ok := value.(*models.Device)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+
+		if !device.Can(scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "device is not allowed to perform this action", "scope": scope})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,64 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"api-jet-manager/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PermissionChecker resolve as permissões do usuário conforme o seu papel no restaurante
+type PermissionChecker interface {
+	HasPermission(restaurantID, userID uuid.UUID, userType models.UserType, permission models.Permission) (bool, error)
+}
+
+// RequirePermission libera a rota apenas para usuários cujo papel no restaurante concede a permissão.
+// Superadmins têm todas as permissões.
+func RequirePermission(checker PermissionChecker, permission models.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userType, exists := c.Get("user_type")
+		if !exists {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+
+		userTypeValue, ok := userType.(models.UserType)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+
+		// Superadmin sempre tem acesso
+		if userTypeValue == models.UserTypeSuperAdmin {
+			c.Next()
+			return
+		}
+
+		userID, err := uuid.Parse(c.GetString("user_id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+
+		restaurantIDRaw, _ := c.Get("restaurant_id")
+		restaurantID, ok := restaurantIDRaw.(*uuid.UUID)
+		if !ok || restaurantID == nil {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+			return
+		}
+
+		allowed, err := checker.HasPermission(*restaurantID, userID, userTypeValue, permission)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "error verifying permissions"})
+			return
+		}
+		if !allowed {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient permissions", "permission": permission})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,61 @@
+package middlewares
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ipWindow conta as requisições de um IP na janela atual
+type ipWindow struct {
+	start time.Time
+	count int
+}
+
+// RateLimitByIP limita cada IP a max requisições por janela. O IP vem de c.ClientIP, que só
+// considera o X-Forwarded-For dos proxies confiáveis do roteador. O contador fica na memória da
+// instância, então com várias instâncias o limite vale para cada uma delas.
+func RateLimitByIP(max int, window time.Duration) gin.HandlerFunc {
+	var (
+		mu        sync.Mutex
+		windows   = make(map[string]*ipWindow)
+		lastSweep = time.Now()
+	)
+
+	return func(c *gin.Context) {
+		now := time.Now()
+		ip := c.ClientIP()
+
+		mu.Lock()
+		// As janelas vencidas são descartadas uma vez por janela, para o mapa não crescer
+		// indefinidamente sem percorrê-lo a cada requisição
+		if now.Sub(lastSweep) >= window {
+			for key, w := range windows {
+				if now.Sub(w.start) >= window {
+					delete(windows, key)
+				}
+			}
+			lastSweep = now
+		}
+
+		w, ok := windows[ip]
+		if !ok || now.Sub(w.start) >= window {
+			w = &ipWindow{start: now}
+			windows[ip] = w
+		}
+		w.count++
+		exceeded, retryAfter := w.count > max, w.start.Add(window).Sub(now)
+		mu.Unlock()
+
+		if exceeded {
+			c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "too many requests, try again later"})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -2,81 +2,185 @@
 package routes
 
 import (
+	"context"
+	"log"
+	"time"
+
 	"api-jet-manager/internal/api/handlers"
 	"api-jet-manager/internal/api/middlewares"
 	"api-jet-manager/internal/config"
 	"api-jet-manager/internal/domain/models"
 	"api-jet-manager/internal/infrastructure/auth"
 	"api-jet-manager/internal/infrastructure/database"
+	"api-jet-manager/internal/infrastructure/realtime"
 	repoImpl "api-jet-manager/internal/infrastructure/repositories"
 	"api-jet-manager/internal/services"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRouter(cfg *config.Config, db *database.PostgresDB) *gin.Engine {
+// SetupRouter monta as rotas da API. Os clientes de tempo real se conectam a hub,
+// e os serviços publicam seus eventos através de events. As rotinas em segundo plano
+// dos serviços rodam até ctx ser cancelado.
+func SetupRouter(ctx context.Context, cfg *config.Config, db *database.PostgresDB, hub *realtime.Hub, events realtime.Publisher) *gin.Engine {
 	// Configurar modo do Gin
 	gin.SetMode(cfg.GinMode)
 	router := gin.Default()
 
+	// Proxies confiáveis: o X-Forwarded-For só é aceito nas conexões vindas de um deles
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatalf("Proxies confiáveis inválidos: %v", err)
+	}
+
 	// Middleware CORS
 	router.Use(middlewares.CORSMiddleware())
 
 	// Inicialização do serviço JWT
 	jwtService := auth.NewJWTService(cfg.JWTSecret, cfg.JWTExpiration)
 
-	// Inicializa o WebSocketManager
-	wsManager := handlers.NewWebSocketManager()
-	go wsManager.Run()
-
-	router.GET("ws/devileries", wsManager.ServeWebSocket)
-
 	// Repositórios
+	unitOfWork := repoImpl.NewPostgresUnitOfWork(db)
 	userRepo := repoImpl.NewPostgresUserRepository(db)
+	authRepo := repoImpl.NewPostgresAuthRepository(db)
 	tableRepo := repoImpl.NewPostgresTableRepository(db)
 	orderRepo := repoImpl.NewPostgresOrderRepository(db)
 	financeRepo := repoImpl.NewPostgresFinanceRepository(db)
 	productRepo := repoImpl.NewPostgresProductRepository(db)
 	productCategoryRepo := repoImpl.NewPostgresProductCategoryRepository(db)
 	restaurantRepo := repoImpl.NewPostgresRestaurantRepository(db)
+	addonRepo := repoImpl.NewPostgresAddonRepository(db)
+	orderCodeRepo := repoImpl.NewPostgresOrderCodeRepository(db)
+	paymentRepo := repoImpl.NewPostgresPaymentRepository(db)
+	kitchenRepo := repoImpl.NewPostgresKitchenRepository(db)
+	reservationRepo := repoImpl.NewPostgresReservationRepository(db)
+	waitlistRepo := repoImpl.NewPostgresWaitlistRepository(db)
+	inventoryRepo := repoImpl.NewPostgresInventoryRepository(db)
+	purchaseRepo := repoImpl.NewPostgresPurchaseRepository(db)
+	cashRepo := repoImpl.NewPostgresCashRepository(db)
+	reportRepo := repoImpl.NewPostgresReportRepository(db)
+	recurringRepo := repoImpl.NewPostgresRecurringTransactionRepository(db)
+	billRepo := repoImpl.NewPostgresBillRepository(db)
+	roleRepo := repoImpl.NewPostgresRoleRepository(db)
+	membershipRepo := repoImpl.NewPostgresMembershipRepository(db)
+	deviceRepo := repoImpl.NewPostgresDeviceRepository(db)
 
 	// Serviços
-	userService := services.NewUserService(userRepo, jwtService)
-	tableService := services.NewTableService(tableRepo)
-	orderService := services.NewOrderService(orderRepo, tableRepo, financeRepo, productRepo)
+	authService := services.NewAuthService(unitOfWork, authRepo, userRepo, membershipRepo, deviceRepo, jwtService, cfg.JWTRefreshExpiration)
+	userService := services.NewUserService(userRepo, authService)
+	roleService := services.NewRoleService(unitOfWork, roleRepo, membershipRepo)
+	membershipService := services.NewMembershipService(membershipRepo, userRepo, roleService)
+	deviceService := services.NewDeviceService(unitOfWork, deviceRepo)
+	pinService := services.NewPINService(unitOfWork, deviceRepo, userRepo, membershipService, jwtService, cfg.PINTokenExpiration)
+	tableService := services.NewTableService(unitOfWork, tableRepo, events)
+	orderCodeService := services.NewOrderCodeService(orderCodeRepo)
+	orderService := services.NewOrderService(unitOfWork, orderRepo, tableRepo, productRepo, addonRepo, orderCodeService, events)
+	cashService := services.NewCashService(unitOfWork, cashRepo)
+	paymentService := services.NewPaymentService(unitOfWork, paymentRepo, orderRepo, financeRepo, cashService, orderService, events)
 	financeService := services.NewFinanceService(financeRepo)
-	productService := services.NewProductService(productRepo)
+	reportService := services.NewReportService(reportRepo)
+	recurringService := services.NewRecurringTransactionService(unitOfWork, recurringRepo)
+	productService := services.NewProductService(productRepo, inventoryRepo)
 	productCategoryService := services.NewProductCategoryService(productCategoryRepo)
 	restaurantService := services.NewRestaurantService(restaurantRepo)
+	addonService := services.NewAddonService(addonRepo, productRepo)
+	kitchenService := services.NewKitchenService(unitOfWork, kitchenRepo, orderRepo, orderService, services.NewKitchenFeed(hub), events)
+	reservationService := services.NewReservationService(unitOfWork, reservationRepo, tableRepo, events, cfg.ReservationHoldLead, cfg.ReservationNoShowGrace)
+	waitlistService := services.NewWaitlistService(unitOfWork, waitlistRepo, tableRepo, orderRepo, orderService, events)
+	inventoryService := services.NewInventoryService(unitOfWork, inventoryRepo, orderRepo, orderService)
+	purchaseService := services.NewPurchaseService(unitOfWork, purchaseRepo, inventoryRepo, inventoryService, financeService)
+	billService := services.NewBillService(unitOfWork, billRepo, purchaseRepo, financeService)
+
+	// Rotinas em segundo plano
+	go reservationService.RunScheduler(ctx)
+	go recurringService.RunScheduler(ctx)
+	go authService.RunCleanup(ctx)
 
 	// Handlers
-	userHandler := handlers.NewUserHandler(userService, restaurantService)
+	userHandler := handlers.NewUserHandler(userService, restaurantService, roleService, membershipService)
+	authHandler := handlers.NewAuthHandler(authService, restaurantService)
+	roleHandler := handlers.NewRoleHandler(roleService)
+	membershipHandler := handlers.NewMembershipHandler(membershipService)
+	deviceHandler := handlers.NewDeviceHandler(deviceService)
+	pinHandler := handlers.NewPINHandler(pinService, deviceService, restaurantService)
 	tableHandler := handlers.NewTableHandler(tableService)
-	orderHandler := handlers.NewOrderHandler(orderService, tableService, wsManager)
+	orderHandler := handlers.NewOrderHandler(orderService, tableService, paymentService, roleService)
 	financeHandler := handlers.NewFinanceHandler(financeService)
+	reportHandler := handlers.NewReportHandler(reportService)
+	recurringHandler := handlers.NewRecurringTransactionHandler(recurringService)
+	billHandler := handlers.NewBillHandler(billService)
 	productHandler := handlers.NewProductHandler(productService, productCategoryService)
 	productCategoryHandler := handlers.NewProductCategoryHandler(productCategoryService)
 	restaurantHandler := handlers.NewRestaurantHandler(restaurantService, userService)
+	addonHandler := handlers.NewAddonHandler(addonService)
+	orderCodeHandler := handlers.NewOrderCodeHandler(orderCodeService)
+	paymentHandler := handlers.NewPaymentHandler(paymentService)
+	kitchenHandler := handlers.NewKitchenHandler(kitchenService)
+	reservationHandler := handlers.NewReservationHandler(reservationService)
+	waitlistHandler := handlers.NewWaitlistHandler(waitlistService)
+	inventoryHandler := handlers.NewInventoryHandler(inventoryService)
+	purchaseHandler := handlers.NewPurchaseHandler(purchaseService)
+	cashHandler := handlers.NewCashHandler(cashService)
+	realtimeHandler := handlers.NewRealtimeHandler(hub)
 
 	// Rotas públicas
 	router.POST("/v1/auth/login", userHandler.Login)
+	router.POST("/v1/auth/refresh", authHandler.Refresh)
 	router.POST("/v1/auth/register-superadmin", userHandler.RegisterSuperAdmin) // Rota para o primeiro superadmin
 	router.POST("/v1/auth/register-admin", userHandler.Register)
 
+	// Pareamento: o terminal troca o código exibido no cadastro pela sua credencial. O limite por IP
+	// dificulta adivinhar códigos; cada código também deixa de valer após algumas tentativas erradas.
+	router.POST("/v1/devices/pair", middlewares.RateLimitByIP(10, 10*time.Minute), deviceHandler.Pair)
+
+	// Login por PIN nos terminais do salão; o terminal se identifica com o cabeçalho X-Device-Token
+	pinApi := router.Group("/v1/auth")
+	pinApi.Use(middlewares.DeviceAuthMiddleware(deviceService))
+	pinApi.Use(middlewares.RequireDeviceScope(models.DeviceScopePINLogin))
+	pinApi.GET("/pin-users", pinHandler.Members)
+	pinApi.POST("/pin-login", pinHandler.Login)
+	pinApi.POST("/pin-lock", pinHandler.Lock)
+
+	// Rotas acessadas pela credencial do próprio terminal, limitadas às ações do seu tipo
+	deviceApi := router.Group("/v1/device")
+	deviceApi.Use(middlewares.DeviceAuthMiddleware(deviceService))
+	deviceApi.GET("", deviceHandler.Me)
+
+	deviceKitchenApi := deviceApi.Group("/kitchen")
+	deviceKitchenApi.GET("/stations", middlewares.RequireDeviceScope(models.DeviceScopeKitchenRead), kitchenHandler.ListStations)
+	deviceKitchenApi.GET("/stations/:station_id/feed", middlewares.RequireDeviceScope(models.DeviceScopeKitchenRead), kitchenHandler.Feed)
+	deviceKitchenApi.GET("/tickets", middlewares.RequireDeviceScope(models.DeviceScopeKitchenRead), kitchenHandler.ListTickets)
+	deviceKitchenApi.POST("/tickets/:ticket_id/start", middlewares.RequireDeviceScope(models.DeviceScopeKitchenBump), kitchenHandler.StartTicket)
+	deviceKitchenApi.POST("/tickets/:ticket_id/bump", middlewares.RequireDeviceScope(models.DeviceScopeKitchenBump), kitchenHandler.BumpTicket)
+	deviceKitchenApi.POST("/tickets/:ticket_id/recall", middlewares.RequireDeviceScope(models.DeviceScopeKitchenBump), kitchenHandler.RecallTicket)
+
 	// Grupo de rotas autenticadas
 	api := router.Group("/v1")
-	api.Use(middlewares.AuthMiddleware(jwtService))
+	api.Use(middlewares.AuthMiddleware(jwtService, authService, deviceService))
+
+	// Encerramento de sessões
+	api.POST("/auth/logout", authHandler.Logout)
+	api.POST("/auth/logout-all", authHandler.LogoutAll)
+
+	// Troca do restaurante ativo entre os restaurantes do usuário
+	api.POST("/auth/switch-restaurant", authHandler.SwitchRestaurant)
 
 	// Rotas de perfil de usuário
 	api.GET("/profile", userHandler.GetProfile)
 	api.PUT("/profile", userHandler.UpdateProfile)
+	api.GET("/profile/permissions", middlewares.RestaurantMiddleware(membershipService), roleHandler.MyPermissions)
+	api.GET("/profile/restaurants", membershipHandler.MyRestaurants)
+	api.PUT("/profile/pin", pinHandler.SetMyPIN)
+	api.DELETE("/profile/pin", pinHandler.ClearMyPIN)
+
+	// Eventos em tempo real (WebSocket); o token pode ser enviado em ?access_token=
+	api.GET("/realtime", middlewares.RestaurantMiddleware(membershipService), realtimeHandler.Connect)
 
 	// Rotas de gestão de restaurantes
 	restaurantsApi := api.Group("/restaurants")
 	restaurantsApi.GET("", restaurantHandler.List) // Com filtro para usuários normais
 
 	// IMPORTANTE: Todas as rotas de restaurante usam o mesmo parâmetro ":restaurant_id"
-	restaurantsApi.GET("/:restaurant_id", middlewares.RestaurantMiddleware(), restaurantHandler.GetByID)
+	restaurantsApi.GET("/:restaurant_id", middlewares.RestaurantMiddleware(membershipService), restaurantHandler.GetByID)
 
 	// Operações que exigem superadmin
 	restaurantAdminApi := restaurantsApi.Group("/")
@@ -87,46 +191,127 @@ func SetupRouter(cfg *config.Config, db *database.PostgresDB) *gin.Engine {
 	// restaurantAdminApi.PATCH("/status", restaurantHandler.UpdateStatus)
 
 	// Rotas de usuário (agrupadas por restaurante)
-	restaurantsApi.POST("/users", middlewares.RestaurantMiddleware(),
-		middlewares.UserTypeMiddleware(models.UserTypeAdmin, models.UserTypeManager),
+	restaurantsApi.POST("/users", middlewares.RestaurantMiddleware(membershipService),
+		middlewares.RequirePermission(roleService, models.PermissionUsersWrite),
 		userHandler.Register)
+	restaurantsApi.PUT("/users/:user_id/role", middlewares.RestaurantMiddleware(membershipService),
+		middlewares.RequirePermission(roleService, models.PermissionUsersWrite),
+		roleHandler.AssignRole)
+	restaurantsApi.POST("/users/:user_id/revoke-sessions", middlewares.RestaurantMiddleware(membershipService),
+		middlewares.RequirePermission(roleService, models.PermissionUsersWrite),
+		authHandler.RevokeUserSessions)
+	restaurantsApi.PUT("/users/:user_id/pin", middlewares.RestaurantMiddleware(membershipService),
+		middlewares.RequirePermission(roleService, models.PermissionUsersWrite),
+		pinHandler.ResetMemberPIN)
+
+	// Vínculos de usuários já cadastrados com o restaurante
+	membersApi := restaurantsApi.Group("/members")
+	membersApi.Use(middlewares.RestaurantMiddleware(membershipService))
+	membersApi.Use(middlewares.RequirePermission(roleService, models.PermissionUsersWrite))
+	membersApi.GET("", membershipHandler.List)
+	membersApi.POST("", membershipHandler.Add)
+	membersApi.PUT("/:user_id", membershipHandler.Update)
+	membersApi.DELETE("/:user_id", membershipHandler.Remove)
+
+	// Terminais do restaurante: cadastro, pareamento e revogação das credenciais
+	devicesApi := restaurantsApi.Group("/devices")
+	devicesApi.Use(middlewares.RestaurantMiddleware(membershipService))
+	devicesApi.Use(middlewares.RequirePermission(roleService, models.PermissionDevicesWrite))
+	devicesApi.GET("", deviceHandler.List)
+	devicesApi.GET("/:device_id", deviceHandler.GetByID)
+	devicesApi.POST("", deviceHandler.Create)
+	devicesApi.PUT("/:device_id", deviceHandler.Update)
+	devicesApi.DELETE("/:device_id", deviceHandler.Delete)
+	devicesApi.POST("/:device_id/pairing-code", deviceHandler.RenewPairing)
+	devicesApi.POST("/:device_id/revoke", deviceHandler.Revoke)
+
+	// Rotas de papéis e permissões (agrupadas por restaurante)
+	rolesApi := restaurantsApi.Group("/roles")
+	rolesApi.Use(middlewares.RestaurantMiddleware(membershipService))
+	rolesApi.Use(middlewares.RequirePermission(roleService, models.PermissionUsersWrite))
+	rolesApi.GET("", roleHandler.List)
+	rolesApi.GET("/permissions", roleHandler.Catalog)
+	rolesApi.GET("/:role_id", roleHandler.GetByID)
+
+	rolesAdminApi := rolesApi.Group("")
+	rolesAdminApi.Use(middlewares.RequirePermission(roleService, models.PermissionRolesWrite))
+	rolesAdminApi.POST("", roleHandler.Create)
+	rolesAdminApi.PUT("/:role_id", roleHandler.Update)
+	rolesAdminApi.DELETE("/:role_id", roleHandler.Delete)
 
 	// Rotas de categorias (agrupadas por restaurante)
-	restaurantsApi.POST("/categories", middlewares.RestaurantMiddleware(), productCategoryHandler.Create)
-	restaurantsApi.GET("/categories", middlewares.RestaurantMiddleware(), productCategoryHandler.List)
-	restaurantsApi.GET("/categories/active", middlewares.RestaurantMiddleware(), productCategoryHandler.ListActive)
-	restaurantsApi.GET("/categories/:category_id", middlewares.RestaurantMiddleware(), productCategoryHandler.GetByID)
-	restaurantsApi.PUT("/categories/:category_id", middlewares.RestaurantMiddleware(), productCategoryHandler.Update)
-	restaurantsApi.DELETE("/categories/:category_id", middlewares.RestaurantMiddleware(), productCategoryHandler.Delete)
-	restaurantsApi.PATCH("/categories/:category_id/status", middlewares.RestaurantMiddleware(), productCategoryHandler.UpdateStatus)
+	restaurantsApi.POST("/categories", middlewares.RestaurantMiddleware(membershipService), productCategoryHandler.Create)
+	restaurantsApi.GET("/categories", middlewares.RestaurantMiddleware(membershipService), productCategoryHandler.List)
+	restaurantsApi.GET("/categories/active", middlewares.RestaurantMiddleware(membershipService), productCategoryHandler.ListActive)
+	restaurantsApi.GET("/categories/:category_id", middlewares.RestaurantMiddleware(membershipService), productCategoryHandler.GetByID)
+	restaurantsApi.PUT("/categories/:category_id", middlewares.RestaurantMiddleware(membershipService), productCategoryHandler.Update)
+	restaurantsApi.DELETE("/categories/:category_id", middlewares.RestaurantMiddleware(membershipService), productCategoryHandler.Delete)
+	restaurantsApi.PATCH("/categories/:category_id/status", middlewares.RestaurantMiddleware(membershipService), productCategoryHandler.UpdateStatus)
 
 	// Rotas de mesas (agrupadas por restaurante)
-	restaurantsApi.GET("/tables", middlewares.RestaurantMiddleware(), tableHandler.List)
-	restaurantsApi.GET("/tables/:table_id", middlewares.RestaurantMiddleware(), tableHandler.GetByID)
+	restaurantsApi.GET("/tables", middlewares.RestaurantMiddleware(membershipService), tableHandler.List)
+	restaurantsApi.GET("/tables/:table_id", middlewares.RestaurantMiddleware(membershipService), tableHandler.GetByID)
 	restaurantsApi.POST("/tables",
-		middlewares.RestaurantMiddleware(),
-		middlewares.UserTypeMiddleware(models.UserTypeAdmin, models.UserTypeManager),
+		middlewares.RestaurantMiddleware(membershipService),
+		middlewares.RequirePermission(roleService, models.PermissionTablesWrite),
 		tableHandler.Create)
 	restaurantsApi.PUT("/tables/:table_id",
-		middlewares.RestaurantMiddleware(),
-		middlewares.UserTypeMiddleware(models.UserTypeAdmin, models.UserTypeManager),
+		middlewares.RestaurantMiddleware(membershipService),
+		middlewares.RequirePermission(roleService, models.PermissionTablesWrite),
 		tableHandler.Update)
 	restaurantsApi.DELETE("/tables/:table_id",
-		middlewares.RestaurantMiddleware(),
-		middlewares.UserTypeMiddleware(models.UserTypeAdmin),
+		middlewares.RestaurantMiddleware(membershipService),
+		middlewares.RequirePermission(roleService, models.PermissionTablesDelete),
 		tableHandler.Delete)
 	restaurantsApi.PATCH("/tables/:table_id/status",
-		middlewares.RestaurantMiddleware(),
+		middlewares.RestaurantMiddleware(membershipService),
 		tableHandler.UpdateStatus)
+	restaurantsApi.POST("/tables/:table_id/merge",
+		middlewares.RestaurantMiddleware(membershipService),
+		middlewares.RequirePermission(roleService, models.PermissionTablesWrite),
+		tableHandler.Merge)
+	restaurantsApi.POST("/tables/:table_id/split",
+		middlewares.RestaurantMiddleware(membershipService),
+		middlewares.RequirePermission(roleService, models.PermissionTablesWrite),
+		tableHandler.Split)
+
+	// Rotas da planta do salão: ambientes e posições das mesas (agrupadas por restaurante)
+	restaurantsApi.GET("/floor-plan", middlewares.RestaurantMiddleware(membershipService), tableHandler.FloorPlan)
+	restaurantsApi.GET("/areas", middlewares.RestaurantMiddleware(membershipService), tableHandler.ListAreas)
+
+	floorPlanAdminApi := restaurantsApi.Group("")
+	floorPlanAdminApi.Use(middlewares.RestaurantMiddleware(membershipService))
+	floorPlanAdminApi.Use(middlewares.RequirePermission(roleService, models.PermissionFloorPlanWrite))
+	floorPlanAdminApi.PUT("/floor-plan", tableHandler.UpdateLayout)
+	floorPlanAdminApi.POST("/areas", tableHandler.CreateArea)
+	floorPlanAdminApi.PUT("/areas/:area_id", tableHandler.UpdateArea)
+	floorPlanAdminApi.DELETE("/areas/:area_id", tableHandler.DeleteArea)
 
 	// Rotas de pedidos (agrupadas por restaurante)
-	restaurantsApi.GET("/orders", middlewares.RestaurantMiddleware(), orderHandler.List)
-	restaurantsApi.GET("/orders/:order_id", middlewares.RestaurantMiddleware(), orderHandler.GetByID)
-	restaurantsApi.POST("/orders", middlewares.RestaurantMiddleware(), orderHandler.Create)
-	restaurantsApi.POST("/orders/delivery", middlewares.RestaurantMiddleware(), orderHandler.CreateOrderDelivery)
-	restaurantsApi.PATCH("/orders/:order_id/status", middlewares.RestaurantMiddleware(), orderHandler.UpdateStatus)
-	restaurantsApi.POST("/orders/:order_id/items", middlewares.RestaurantMiddleware(), orderHandler.AddItem)
-	restaurantsApi.DELETE("/orders/:order_id/items/:item_id", middlewares.RestaurantMiddleware(), orderHandler.RemoveItem)
+	restaurantsApi.GET("/orders", middlewares.RestaurantMiddleware(membershipService), orderHandler.List)
+	restaurantsApi.GET("/orders/:order_id", middlewares.RestaurantMiddleware(membershipService), orderHandler.GetByID)
+	restaurantsApi.POST("/orders", middlewares.RestaurantMiddleware(membershipService), orderHandler.Create)
+	restaurantsApi.POST("/orders/delivery", middlewares.RestaurantMiddleware(membershipService), orderHandler.CreateOrderDelivery)
+	restaurantsApi.PATCH("/orders/:order_id/status", middlewares.RestaurantMiddleware(membershipService), orderHandler.UpdateStatus)
+	restaurantsApi.GET("/orders/:order_id/history", middlewares.RestaurantMiddleware(membershipService), orderHandler.GetStatusHistory)
+	restaurantsApi.POST("/orders/:order_id/items", middlewares.RestaurantMiddleware(membershipService), orderHandler.AddItem)
+	restaurantsApi.DELETE("/orders/:order_id/items/:item_id", middlewares.RestaurantMiddleware(membershipService), orderHandler.RemoveItem)
+	restaurantsApi.POST("/orders/:order_id/items/move", middlewares.RestaurantMiddleware(membershipService), orderHandler.MoveItems)
+	restaurantsApi.POST("/orders/:order_id/transfer", middlewares.RestaurantMiddleware(membershipService), orderHandler.TransferOrder)
+	restaurantsApi.POST("/orders/:order_id/merge", middlewares.RestaurantMiddleware(membershipService), orderHandler.MergeOrders)
+	restaurantsApi.GET("/orders/:order_id/payments", middlewares.RestaurantMiddleware(membershipService), paymentHandler.List)
+	restaurantsApi.POST("/orders/:order_id/payments", middlewares.RestaurantMiddleware(membershipService), paymentHandler.Create)
+	restaurantsApi.DELETE("/orders/:order_id/payments/:payment_id",
+		middlewares.RestaurantMiddleware(membershipService),
+		middlewares.RequirePermission(roleService, models.PermissionPaymentsVoid),
+		paymentHandler.Void)
+
+	// Formato dos códigos dos pedidos
+	restaurantsApi.GET("/settings/order-code", middlewares.RestaurantMiddleware(membershipService), orderCodeHandler.GetSettings)
+	restaurantsApi.PUT("/settings/order-code",
+		middlewares.RestaurantMiddleware(membershipService),
+		middlewares.RequirePermission(roleService, models.PermissionSettingsWrite),
+		orderCodeHandler.UpdateSettings)
 
 	restaurantsApi.GET("/delivery/today", orderHandler.FindTodayDeliveryOrders)
 	restaurantsApi.GET("/delivery/by-date", orderHandler.FindDeliveryOrdersByDate)
@@ -134,38 +319,177 @@ func SetupRouter(cfg *config.Config, db *database.PostgresDB) *gin.Engine {
 	restaurantsApi.GET("/delivery/by-date-range", orderHandler.FindOrdersByDateRangeAndType)
 
 	// Rotas de produtos (agrupadas por restaurante)
-	restaurantsApi.GET("/products", middlewares.RestaurantMiddleware(), productHandler.List)
-	restaurantsApi.GET("/products/:product_id", middlewares.RestaurantMiddleware(), productHandler.GetByID)
+	restaurantsApi.GET("/products", middlewares.RestaurantMiddleware(membershipService), productHandler.List)
+	restaurantsApi.GET("/products/:product_id", middlewares.RestaurantMiddleware(membershipService), productHandler.GetByID)
 	restaurantsApi.POST("/products",
-		middlewares.RestaurantMiddleware(),
-		middlewares.UserTypeMiddleware(models.UserTypeAdmin, models.UserTypeManager),
+		middlewares.RestaurantMiddleware(membershipService),
+		middlewares.RequirePermission(roleService, models.PermissionProductsWrite),
 		productHandler.Create)
 	restaurantsApi.PUT("/products/:product_id",
-		middlewares.RestaurantMiddleware(),
-		middlewares.UserTypeMiddleware(models.UserTypeAdmin, models.UserTypeManager),
+		middlewares.RestaurantMiddleware(membershipService),
+		middlewares.RequirePermission(roleService, models.PermissionProductsWrite),
 		productHandler.Update)
 	restaurantsApi.DELETE("/products/:product_id",
-		middlewares.RestaurantMiddleware(),
-		middlewares.UserTypeMiddleware(models.UserTypeAdmin),
+		middlewares.RestaurantMiddleware(membershipService),
+		middlewares.RequirePermission(roleService, models.PermissionProductsDelete),
 		productHandler.Delete)
 	restaurantsApi.PATCH("/products/:product_id/stock",
-		middlewares.RestaurantMiddleware(),
-		middlewares.UserTypeMiddleware(models.UserTypeAdmin, models.UserTypeManager),
+		middlewares.RestaurantMiddleware(membershipService),
+		middlewares.RequirePermission(roleService, models.PermissionProductsWrite),
 		productHandler.UpdateStock)
 
+	// Rotas de complementos dos produtos
+	addonsApi := restaurantsApi.Group("/products/:product_id/addons")
+	addonsApi.Use(middlewares.RestaurantMiddleware(membershipService))
+	addonsApi.GET("", addonHandler.List)
+	addonsApi.GET("/:addon_id", addonHandler.GetByID)
+	addonsApi.GET("/:addon_id/options", addonHandler.ListOptions)
+
+	addonsAdminApi := addonsApi.Group("")
+	addonsAdminApi.Use(middlewares.RequirePermission(roleService, models.PermissionProductsWrite))
+	addonsAdminApi.POST("", addonHandler.Create)
+	addonsAdminApi.PUT("/:addon_id", addonHandler.Update)
+	addonsAdminApi.DELETE("/:addon_id", addonHandler.Delete)
+	addonsAdminApi.POST("/:addon_id/options", addonHandler.CreateOption)
+	addonsAdminApi.PUT("/:addon_id/options/:option_id", addonHandler.UpdateOption)
+	addonsAdminApi.DELETE("/:addon_id/options/:option_id", addonHandler.DeleteOption)
+
+	// Rotas da cozinha (KDS): praças de preparo, comandas e telas ao vivo
+	kitchenApi := restaurantsApi.Group("/kitchen")
+	kitchenApi.Use(middlewares.RestaurantMiddleware(membershipService))
+	kitchenApi.GET("/stations", kitchenHandler.ListStations)
+	kitchenApi.GET("/stations/:station_id/feed", kitchenHandler.Feed)
+	kitchenApi.GET("/tickets", kitchenHandler.ListTickets)
+
+	kitchenBumpApi := kitchenApi.Group("")
+	kitchenBumpApi.Use(middlewares.RequirePermission(roleService, models.PermissionKitchenBump))
+	kitchenBumpApi.POST("/tickets/:ticket_id/start", kitchenHandler.StartTicket)
+	kitchenBumpApi.POST("/tickets/:ticket_id/bump", kitchenHandler.BumpTicket)
+	kitchenBumpApi.POST("/tickets/:ticket_id/recall", kitchenHandler.RecallTicket)
+
+	kitchenAdminApi := kitchenApi.Group("")
+	kitchenAdminApi.Use(middlewares.RequirePermission(roleService, models.PermissionKitchenWrite))
+	kitchenAdminApi.POST("/stations", kitchenHandler.CreateStation)
+	kitchenAdminApi.PUT("/stations/:station_id", kitchenHandler.UpdateStation)
+	kitchenAdminApi.DELETE("/stations/:station_id", kitchenHandler.DeleteStation)
+	kitchenAdminApi.PUT("/routing/products/:product_id", kitchenHandler.SetProductStation)
+	kitchenAdminApi.PUT("/routing/categories/:category_id", kitchenHandler.SetCategoryStation)
+
+	// Rotas de reservas de mesas (agrupadas por restaurante)
+	reservationsApi := restaurantsApi.Group("/reservations")
+	reservationsApi.Use(middlewares.RestaurantMiddleware(membershipService))
+	reservationsApi.GET("", reservationHandler.List)
+	reservationsApi.GET("/:reservation_id", reservationHandler.GetByID)
+
+	reservationsAdminApi := reservationsApi.Group("")
+	reservationsAdminApi.Use(middlewares.RequirePermission(roleService, models.PermissionReservationsWrite))
+	reservationsAdminApi.POST("", reservationHandler.Create)
+	reservationsAdminApi.PUT("/:reservation_id", reservationHandler.Update)
+	reservationsAdminApi.POST("/:reservation_id/seat", reservationHandler.Seat)
+	reservationsAdminApi.POST("/:reservation_id/cancel", reservationHandler.Cancel)
+	reservationsAdminApi.POST("/:reservation_id/no-show", reservationHandler.NoShow)
+
+	// Rotas da fila de espera (agrupadas por restaurante)
+	waitlistApi := restaurantsApi.Group("/waitlist")
+	waitlistApi.Use(middlewares.RestaurantMiddleware(membershipService))
+	waitlistApi.GET("", waitlistHandler.List)
+	waitlistApi.GET("/quote", waitlistHandler.Quote)
+	waitlistApi.GET("/:entry_id", waitlistHandler.GetByID)
+
+	waitlistAdminApi := waitlistApi.Group("")
+	waitlistAdminApi.Use(middlewares.RequirePermission(roleService, models.PermissionReservationsWrite))
+	waitlistAdminApi.POST("", waitlistHandler.Add)
+	waitlistAdminApi.POST("/:entry_id/notify", waitlistHandler.Notify)
+	waitlistAdminApi.POST("/:entry_id/seat", waitlistHandler.Seat)
+	waitlistAdminApi.POST("/:entry_id/drop", waitlistHandler.Drop)
+
+	// Rotas de estoque: insumos, movimentações e fichas técnicas
+	inventoryApi := restaurantsApi.Group("/inventory")
+	inventoryApi.Use(middlewares.RestaurantMiddleware(membershipService))
+	inventoryApi.GET("/ingredients", inventoryHandler.ListIngredients)
+	inventoryApi.GET("/ingredients/:ingredient_id", inventoryHandler.GetIngredient)
+	inventoryApi.GET("/movements", inventoryHandler.ListMovements)
+	inventoryApi.GET("/recipes/products/:product_id", inventoryHandler.GetProductRecipe)
+	inventoryApi.GET("/recipes/options/:option_id", inventoryHandler.GetOptionRecipe)
+
+	inventoryAdminApi := inventoryApi.Group("")
+	inventoryAdminApi.Use(middlewares.RequirePermission(roleService, models.PermissionInventoryWrite))
+	inventoryAdminApi.POST("/ingredients", inventoryHandler.CreateIngredient)
+	inventoryAdminApi.PUT("/ingredients/:ingredient_id", inventoryHandler.UpdateIngredient)
+	inventoryAdminApi.DELETE("/ingredients/:ingredient_id", inventoryHandler.DeleteIngredient)
+	inventoryAdminApi.POST("/ingredients/:ingredient_id/adjustments", inventoryHandler.AdjustStock)
+	inventoryAdminApi.PUT("/recipes/products/:product_id", inventoryHandler.SetProductRecipe)
+	inventoryAdminApi.PUT("/recipes/options/:option_id", inventoryHandler.SetOptionRecipe)
+
+	// Rotas de compras: fornecedores e pedidos de compra
+	purchasingApi := restaurantsApi.Group("/purchasing")
+	purchasingApi.Use(middlewares.RestaurantMiddleware(membershipService))
+	purchasingApi.Use(middlewares.RequirePermission(roleService, models.PermissionPurchasingRead))
+	purchasingApi.GET("/suppliers", purchaseHandler.ListSuppliers)
+	purchasingApi.GET("/suppliers/:supplier_id", purchaseHandler.GetSupplier)
+	purchasingApi.GET("/orders", purchaseHandler.ListPurchaseOrders)
+	purchasingApi.GET("/orders/:purchase_order_id", purchaseHandler.GetPurchaseOrder)
+
+	purchasingAdminApi := purchasingApi.Group("")
+	purchasingAdminApi.Use(middlewares.RequirePermission(roleService, models.PermissionPurchasingWrite))
+	purchasingAdminApi.POST("/suppliers", purchaseHandler.CreateSupplier)
+	purchasingAdminApi.PUT("/suppliers/:supplier_id", purchaseHandler.UpdateSupplier)
+	purchasingAdminApi.DELETE("/suppliers/:supplier_id", purchaseHandler.DeleteSupplier)
+	purchasingAdminApi.POST("/orders", purchaseHandler.CreatePurchaseOrder)
+	purchasingAdminApi.PUT("/orders/:purchase_order_id", purchaseHandler.UpdatePurchaseOrder)
+	purchasingAdminApi.POST("/orders/:purchase_order_id/place", purchaseHandler.PlaceOrder)
+	purchasingAdminApi.POST("/orders/:purchase_order_id/receive", purchaseHandler.Receive)
+	purchasingAdminApi.POST("/orders/:purchase_order_id/cancel", purchaseHandler.CancelPurchaseOrder)
+
+	// Rotas dos turnos de caixa: abertura, suprimentos e sangrias e fechamento com conferência
+	cashApi := restaurantsApi.Group("/cash-sessions")
+	cashApi.Use(middlewares.RestaurantMiddleware(membershipService))
+	cashApi.GET("", cashHandler.List)
+	cashApi.GET("/:session_id", cashHandler.Summary)
+
+	cashAdminApi := cashApi.Group("")
+	cashAdminApi.Use(middlewares.RequirePermission(roleService, models.PermissionCashManage))
+	cashAdminApi.POST("", cashHandler.Open)
+	cashAdminApi.POST("/:session_id/movements", cashHandler.AddMovement)
+	cashAdminApi.POST("/:session_id/close", cashHandler.Close)
+
 	// Rotas de finanças (agrupadas por restaurante)
 	financeApi := restaurantsApi.Group("/finance")
-	financeApi.Use(middlewares.RestaurantMiddleware())
-	financeApi.Use(middlewares.UserTypeMiddleware(models.UserTypeAdmin, models.UserTypeManager))
+	financeApi.Use(middlewares.RestaurantMiddleware(membershipService))
+	financeApi.Use(middlewares.RequirePermission(roleService, models.PermissionFinanceRead))
 
 	financeApi.GET("/transactions", financeHandler.List)
 	financeApi.GET("/transactions/:transaction_id", financeHandler.GetByID)
-	financeApi.POST("/transactions", financeHandler.Create)
-	financeApi.PUT("/transactions/:transaction_id", financeHandler.Update)
-	financeApi.DELETE("/transactions/:transaction_id",
-		middlewares.UserTypeMiddleware(models.UserTypeAdmin),
-		financeHandler.Delete)
 	financeApi.GET("/summary", financeHandler.GetSummary)
+	financeApi.GET("/reports/profit-and-loss", reportHandler.ProfitAndLoss)
+	financeApi.GET("/reports/cash-flow", reportHandler.CashFlow)
+	financeApi.GET("/reports/payment-methods", reportHandler.PaymentMethods)
+	financeApi.GET("/recurring", recurringHandler.List)
+	financeApi.GET("/recurring/upcoming", recurringHandler.Upcoming)
+	financeApi.GET("/recurring/:recurring_id", recurringHandler.GetByID)
+	financeApi.GET("/bills", billHandler.List)
+	financeApi.GET("/bills/due-this-week", billHandler.DueThisWeek)
+	financeApi.GET("/bills/overdue", billHandler.Overdue)
+	financeApi.GET("/bills/:bill_id", billHandler.GetByID)
+
+	financeAdminApi := financeApi.Group("")
+	financeAdminApi.Use(middlewares.RequirePermission(roleService, models.PermissionFinanceWrite))
+	financeAdminApi.POST("/transactions", financeHandler.Create)
+	financeAdminApi.PUT("/transactions/:transaction_id", financeHandler.Update)
+	financeAdminApi.DELETE("/transactions/:transaction_id",
+		middlewares.RequirePermission(roleService, models.PermissionFinanceDelete),
+		financeHandler.Delete)
+
+	financeAdminApi.POST("/recurring", recurringHandler.Create)
+	financeAdminApi.PUT("/recurring/:recurring_id", recurringHandler.Update)
+	financeAdminApi.DELETE("/recurring/:recurring_id", recurringHandler.Delete)
+
+	financeAdminApi.POST("/bills", billHandler.Create)
+	financeAdminApi.PUT("/bills/:bill_id", billHandler.Update)
+	financeAdminApi.POST("/bills/:bill_id/settlements", billHandler.Settle)
+	financeAdminApi.POST("/bills/:bill_id/cancel", billHandler.Cancel)
+	financeAdminApi.POST("/bills/:bill_id/attachments", billHandler.AddAttachment)
+	financeAdminApi.DELETE("/bills/:bill_id/attachments/:attachment_id", billHandler.DeleteAttachment)
 
 	return router
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -12,8 +13,9 @@ import (
 
 type Config struct {
 	// Configurações do servidor
-	ServerAddress string
-	GinMode       string
+	ServerAddress  string
+	GinMode        string
+	TrustedProxies []string // Proxies cujo X-Forwarded-For é aceito como IP do cliente; vazio aceita nenhum
 
 	// Configurações do banco de dados
 	BLUEPRINT_DB_HOST     string
@@ -24,8 +26,14 @@ type Config struct {
 	DBSSLMode             string
 
 	// Configurações do JWT
-	JWTSecret     string
-	JWTExpiration time.Duration
+	JWTSecret            string
+	JWTExpiration        time.Duration // Validade do token de acesso
+	JWTRefreshExpiration time.Duration // Validade do refresh token, renovada a cada uso
+	PINTokenExpiration   time.Duration // Validade do token emitido no login por PIN nos terminais
+
+	// Configurações das reservas
+	ReservationHoldLead    time.Duration // Antecedência com que as mesas passam a reservadas
+	ReservationNoShowGrace time.Duration // Tolerância de atraso antes de liberar as mesas
 }
 
 func LoadConfig() (*Config, error) {
@@ -35,12 +43,17 @@ func LoadConfig() (*Config, error) {
 	}
 
 	dbPort, _ := strconv.Atoi(getEnv("DB_PORT", "5432"))
-	jwtExpiration, _ := strconv.Atoi(getEnv("JWT_EXPIRATION", "24"))
+	jwtExpiration, _ := strconv.Atoi(getEnv("JWT_EXPIRATION_MINUTES", "15"))
+	jwtRefreshExpiration, _ := strconv.Atoi(getEnv("JWT_REFRESH_EXPIRATION_DAYS", "30"))
+	pinTokenExpiration, _ := strconv.Atoi(getEnv("PIN_TOKEN_EXPIRATION_MINUTES", "10"))
+	reservationHoldLead, _ := strconv.Atoi(getEnv("RESERVATION_HOLD_MINUTES", "30"))
+	reservationNoShowGrace, _ := strconv.Atoi(getEnv("RESERVATION_GRACE_MINUTES", "15"))
 
 	return &Config{
 		// Servidor
-		ServerAddress: getEnv("SERVER_ADDRESS", "8080"),
-		GinMode:       getEnv("GIN_MODE", "debug"),
+		ServerAddress:  getEnv("SERVER_ADDRESS", "8080"),
+		GinMode:        getEnv("GIN_MODE", "debug"),
+		TrustedProxies: splitList(getEnv("TRUSTED_PROXIES", "")),
 
 		// Banco de dados
 		BLUEPRINT_DB_HOST:     getEnv("DB_HOST", "localhost"),
@@ -51,11 +64,23 @@ func LoadConfig() (*Config, error) {
 		DBSSLMode:             getEnv("DB_SSLMODE", "disable"),
 
 		// JWT
-		JWTSecret:     getEnv("JWT_SECRET", "25thiago99"),
-		JWTExpiration: time.Duration(jwtExpiration) * time.Hour,
+		JWTSecret:            getEnv("JWT_SECRET", "25thiago99"),
+		JWTExpiration:        time.Duration(jwtExpiration) * time.Minute,
+		JWTRefreshExpiration: time.Duration(jwtRefreshExpiration) * 24 * time.Hour,
+		PINTokenExpiration:   time.Duration(pinTokenExpiration) * time.Minute,
+
+		// Reservas
+		ReservationHoldLead:    time.Duration(reservationHoldLead) * time.Minute,
+		ReservationNoShowGrace: time.Duration(reservationNoShowGrace) * time.Minute,
 	}, nil
 }
 
+// DatabaseDSN monta a string de conexão do Postgres
+func (c *Config) DatabaseDSN() string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		c.BLUEPRINT_DB_HOST, c.BLUEPRINT_DB_PORT, c.BLUEPRINT_DB_USERNAME, c.BLUEPRINT_DB_PASSWORD, c.BLUEPRINT_DB_DATABASE, c.DBSSLMode)
+}
+
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
 	if value == "" {
@@ -63,3 +88,14 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+// splitList separa uma lista de valores por vírgula, ignorando os itens vazios
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
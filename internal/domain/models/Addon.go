@@ -32,7 +32,7 @@ type Option struct {
 	AddonID     uuid.UUID `gorm:"type:uuid;not null" json:"addon_id"`
 	Addon       *Addon    `json:"addon,omitempty" gorm:"foreignKey:AddonID"`
 	Name        string    `gorm:"size:100;not null" json:"name"`
-	Price       float64   `gorm:"not null" json:"price"`
+	Price       Money     `gorm:"not null" json:"price"`
 	Active      bool      `gorm:"default:true" json:"active"`
 	MaxQuantity int       `gorm:"default:1" json:"max_quantity"` // Quantidade máxima permitida por opção (para MultipleWithRepeat)
 	CreatedAt   time.Time `json:"created_at"`
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RefreshToken guarda o hash de um refresh token. Cada uso troca o token por um novo da mesma
+// família (a sessão); reapresentar um token já trocado revoga a família inteira.
+type RefreshToken struct {
+	ID           uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	UserID       uuid.UUID  `gorm:"type:uuid;not null" json:"user_id"`
+	FamilyID     uuid.UUID  `gorm:"type:uuid;not null" json:"family_id"`
+	RestaurantID *uuid.UUID `gorm:"type:uuid" json:"restaurant_id"` // Restaurante ativo da sessão
+	TokenHash    string     `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	AccessJTI    uuid.UUID  `gorm:"column:access_jti;type:uuid;not null" json:"-"` // Token de acesso emitido junto com este refresh token
+	UserAgent    string     `gorm:"size:255" json:"user_agent"`
+	IP           string     `gorm:"size:45" json:"ip"`
+	ExpiresAt    time.Time  `gorm:"not null" json:"expires_at"`
+	RotatedAt    *time.Time `json:"rotated_at"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// Usable indica se o token ainda pode ser trocado
+func (t *RefreshToken) Usable(now time.Time) bool {
+	return t.RotatedAt == nil && t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// RevokedToken é um token de acesso revogado antes de expirar, identificado pelo jti
+type RevokedToken struct {
+	JTI       uuid.UUID `gorm:"column:jti;primaryKey;type:uuid" json:"jti"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null" json:"user_id"`
+	ExpiresAt time.Time `gorm:"not null" json:"expires_at"` // Depois disso o token já é recusado pela assinatura
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (t *RefreshToken) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
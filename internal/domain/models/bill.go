@@ -0,0 +1,139 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BillKind string
+
+const (
+	BillKindPayable    BillKind = "payable"    // Conta a pagar; a baixa gera uma despesa
+	BillKindReceivable BillKind = "receivable" // Conta a receber; a baixa gera uma receita
+)
+
+func (k BillKind) Valid() bool {
+	return k == BillKindPayable || k == BillKindReceivable
+}
+
+// TransactionType retorna o tipo das transações geradas pelas baixas
+func (k BillKind) TransactionType() TransactionType {
+	if k == BillKindReceivable {
+		return TransactionTypeIncome
+	}
+	return TransactionTypeExpense
+}
+
+type BillStatus string
+
+const (
+	BillStatusOpen          BillStatus = "open"
+	BillStatusPartiallyPaid BillStatus = "partially_paid"
+	BillStatusPaid          BillStatus = "paid"
+	BillStatusOverdue       BillStatus = "overdue" // Calculado na leitura; nunca é gravado
+	BillStatusCancelled     BillStatus = "cancelled"
+)
+
+// Bill é uma conta a pagar ou a receber com vencimento. O dinheiro só entra no financeiro
+// quando a conta recebe baixas, que podem ser parciais.
+type Bill struct {
+	ID           uuid.UUID           `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RestaurantID uuid.UUID           `gorm:"type:uuid;not null" json:"restaurant_id"`
+	Kind         BillKind            `gorm:"size:20;not null" json:"kind"`
+	Status       BillStatus          `gorm:"size:20;not null;default:'open'" json:"status"`
+	Category     TransactionCategory `gorm:"size:30;not null" json:"category"`
+	Description  string              `gorm:"size:255;not null" json:"description"`
+	Counterparty string              `gorm:"size:100" json:"counterparty"` // Fornecedor ou cliente
+	SupplierID   *uuid.UUID          `gorm:"type:uuid" json:"supplier_id"`
+	Document     string              `gorm:"size:100" json:"document"` // Número da nota, boleto etc.
+	Amount       Money               `gorm:"not null" json:"amount"`
+	PaidAmount   Money               `gorm:"not null;default:0" json:"paid_amount"`
+	DueDate      time.Time           `gorm:"type:date;not null" json:"due_date"`
+	Notes        string              `gorm:"type:text" json:"notes"`
+	UserID       uuid.UUID           `gorm:"type:uuid;not null" json:"user_id"`
+	PaidAt       *time.Time          `json:"paid_at"`
+	CancelledAt  *time.Time          `json:"cancelled_at"`
+	Settlements  []BillSettlement    `json:"settlements,omitempty" gorm:"foreignKey:BillID"`
+	Attachments  []BillAttachment    `json:"attachments,omitempty" gorm:"foreignKey:BillID"`
+	CreatedAt    time.Time           `json:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+}
+
+// BillSettlement é uma baixa da conta, ligada à transação financeira que ela gerou
+type BillSettlement struct {
+	ID                     uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RestaurantID           uuid.UUID  `gorm:"type:uuid;not null" json:"restaurant_id"`
+	BillID                 uuid.UUID  `gorm:"type:uuid;not null" json:"bill_id"`
+	Amount                 Money      `gorm:"not null" json:"amount"`
+	PaymentMethod          string     `gorm:"size:30" json:"payment_method"`
+	PaidAt                 time.Time  `gorm:"not null" json:"paid_at"`
+	Notes                  string     `gorm:"size:255" json:"notes"`
+	FinancialTransactionID *uuid.UUID `gorm:"type:uuid" json:"financial_transaction_id"`
+	UserID                 uuid.UUID  `gorm:"type:uuid;not null" json:"user_id"`
+	CreatedAt              time.Time  `json:"created_at"`
+}
+
+// BillAttachment guarda apenas os dados de um arquivo armazenado fora da API
+type BillAttachment struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	BillID      uuid.UUID `gorm:"type:uuid;not null" json:"bill_id"`
+	FileName    string    `gorm:"size:255;not null" json:"file_name"`
+	ContentType string    `gorm:"size:100" json:"content_type"`
+	Size        int64     `json:"size"`
+	URL         string    `gorm:"size:1024;not null" json:"url"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null" json:"user_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Remaining é o valor que ainda falta baixar
+func (b *Bill) Remaining() Money {
+	return b.Amount.Sub(b.PaidAmount)
+}
+
+// Settleable indica se a conta ainda aceita baixas
+func (b *Bill) Settleable() bool {
+	return b.Status != BillStatusPaid && b.Status != BillStatusCancelled
+}
+
+// BeforeSave grava o status conforme o valor baixado; o atraso é calculado apenas na leitura
+func (b *Bill) BeforeSave(tx *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	if b.Status == BillStatusCancelled {
+		return nil
+	}
+	switch {
+	case b.Remaining() <= 0:
+		b.Status = BillStatusPaid
+	case b.PaidAmount > 0:
+		b.Status = BillStatusPartiallyPaid
+	default:
+		b.Status = BillStatusOpen
+	}
+	return nil
+}
+
+// AfterFind marca como vencidas as contas em aberto com vencimento anterior a hoje
+func (b *Bill) AfterFind(tx *gorm.DB) error {
+	if b.Settleable() && b.DueDate.Before(Day(time.Now())) {
+		b.Status = BillStatusOverdue
+	}
+	return nil
+}
+
+func (s *BillSettlement) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+func (a *BillAttachment) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
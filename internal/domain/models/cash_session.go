@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CashSessionStatus string
+
+const (
+	CashSessionStatusOpen   CashSessionStatus = "open"
+	CashSessionStatusClosed CashSessionStatus = "closed"
+)
+
+// DefaultCashRegister é o caixa usado quando o restaurante não identifica seus caixas
+const DefaultCashRegister = "main"
+
+// CashSession é o turno de um caixa: aberto com um fundo de troco, recebe os pagamentos feitos
+// enquanto está aberto e, no fechamento, compara o esperado com o contado em cada forma de pagamento
+type CashSession struct {
+	ID            uuid.UUID          `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RestaurantID  uuid.UUID          `gorm:"type:uuid;not null" json:"restaurant_id"`
+	Register      string             `gorm:"size:50;not null" json:"register"` // Identificação do caixa físico
+	Status        CashSessionStatus  `gorm:"size:20;not null;default:'open'" json:"status"`
+	OpeningFloat  Money              `gorm:"not null;default:0" json:"opening_float"` // Fundo de troco
+	ExpectedTotal Money              `gorm:"not null;default:0" json:"expected_total"`
+	CountedTotal  Money              `gorm:"not null;default:0" json:"counted_total"`
+	Difference    Money              `gorm:"not null;default:0" json:"difference"` // Contado menos esperado; negativo indica falta
+	Notes         string             `gorm:"size:255" json:"notes"`
+	OpenedBy      uuid.UUID          `gorm:"type:uuid;not null" json:"opened_by"`
+	OpenedAt      time.Time          `gorm:"not null" json:"opened_at"`
+	ClosedBy      *uuid.UUID         `gorm:"type:uuid" json:"closed_by"`
+	ClosedAt      *time.Time         `json:"closed_at"`
+	Movements     []CashMovement     `json:"movements,omitempty" gorm:"foreignKey:SessionID"`
+	Counts        []CashSessionCount `json:"counts,omitempty" gorm:"foreignKey:SessionID"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+func (s *CashSession) IsOpen() bool {
+	return s.Status == CashSessionStatusOpen
+}
+
+type CashMovementType string
+
+const (
+	CashMovementIn  CashMovementType = "cash_in"  // Suprimento: dinheiro colocado no caixa
+	CashMovementOut CashMovementType = "cash_out" // Sangria: dinheiro retirado do caixa
+)
+
+// CashMovement é uma entrada ou retirada de dinheiro do caixa que não vem de um pagamento
+type CashMovement struct {
+	ID           uuid.UUID        `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RestaurantID uuid.UUID        `gorm:"type:uuid;not null" json:"restaurant_id"`
+	SessionID    uuid.UUID        `gorm:"type:uuid;not null" json:"session_id"`
+	Type         CashMovementType `gorm:"size:20;not null" json:"type"`
+	Amount       Money            `gorm:"not null" json:"amount"`
+	Reason       string           `gorm:"size:255" json:"reason"`
+	UserID       uuid.UUID        `gorm:"type:uuid;not null" json:"user_id"`
+	CreatedAt    time.Time        `json:"created_at"`
+}
+
+// CashSessionCount é a conferência de uma forma de pagamento no fechamento do caixa
+type CashSessionCount struct {
+	ID         uuid.UUID     `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	SessionID  uuid.UUID     `gorm:"type:uuid;not null" json:"session_id"`
+	Method     PaymentMethod `gorm:"size:30;not null" json:"method"`
+	Expected   Money         `gorm:"not null" json:"expected"`
+	Counted    Money         `gorm:"not null" json:"counted"`
+	Difference Money         `gorm:"not null" json:"difference"`
+}
+
+func (s *CashSession) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+func (m *CashMovement) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+func (c *CashSessionCount) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultAutoLockMinutes é o tempo de inatividade padrão antes do terminal bloquear
+const DefaultAutoLockMinutes = 5
+
+// DeviceKind define o que o terminal é e, com isso, o que a sua credencial pode fazer
+type DeviceKind string
+
+const (
+	DeviceKindPOS     DeviceKind = "pos"     // Terminal do salão, em que os funcionários entram com PIN
+	DeviceKindKDS     DeviceKind = "kds"     // Tela da cozinha
+	DeviceKindPrinter DeviceKind = "printer" // Ponte de impressão das comandas
+)
+
+func (k DeviceKind) Valid() bool {
+	_, ok := deviceScopes[k]
+	return ok
+}
+
+// DeviceScope é uma ação que a credencial do próprio terminal pode executar, sem um usuário conectado
+type DeviceScope string
+
+const (
+	DeviceScopePINLogin    DeviceScope = "pin.login"    // Desbloquear o terminal com o PIN dos funcionários
+	DeviceScopeKitchenRead DeviceScope = "kitchen.read" // Consultar praças e comandas e acompanhar a tela ao vivo
+	DeviceScopeKitchenBump DeviceScope = "kitchen.bump" // Iniciar, finalizar e reabrir comandas
+)
+
+// deviceScopes são as ações liberadas para cada tipo de terminal
+var deviceScopes = map[DeviceKind][]DeviceScope{
+	DeviceKindPOS:     {DeviceScopePINLogin},
+	DeviceKindKDS:     {DeviceScopeKitchenRead, DeviceScopeKitchenBump},
+	DeviceKindPrinter: {DeviceScopeKitchenRead},
+}
+
+// Scopes retorna as ações liberadas para o tipo de terminal
+func (k DeviceKind) Scopes() []DeviceScope {
+	return append([]DeviceScope(nil), deviceScopes[k]...)
+}
+
+type DeviceStatus string
+
+const (
+	DeviceStatusPending DeviceStatus = "pending" // Cadastrado, aguardando o pareamento
+	DeviceStatusActive  DeviceStatus = "active"  // Pareado, com credencial válida
+	DeviceStatusRevoked DeviceStatus = "revoked" // Credencial revogada; pode ser pareado de novo
+)
+
+// Device é um terminal do restaurante: tablet do salão, tela da cozinha ou ponte de impressão.
+// O terminal é cadastrado no painel e pareado com o código exibido no cadastro; no pareamento recebe
+// o token que o identifica dali em diante. Nos terminais do salão os funcionários entram com o PIN,
+// e só um funcionário fica conectado por vez.
+type Device struct {
+	ID               uuid.UUID     `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RestaurantID     uuid.UUID     `gorm:"type:uuid;not null" json:"restaurant_id"`
+	Name             string        `gorm:"size:100;not null" json:"name"`
+	Kind             DeviceKind    `gorm:"size:20;not null;default:'pos'" json:"kind"`
+	Status           DeviceStatus  `gorm:"-" json:"status"`
+	Scopes           []DeviceScope `gorm:"-" json:"scopes"`
+	TokenHash        *string       `gorm:"size:64;uniqueIndex" json:"-"` // Nulo até o pareamento e depois da revogação
+	PairingSelector  *string       `gorm:"size:4;uniqueIndex" json:"-"`  // Primeira metade do código de pareamento
+	PairingCodeHash  *string       `gorm:"size:64" json:"-"`
+	PairingAttempts  int           `gorm:"not null;default:0" json:"-"` // Códigos errados com o seletor do terminal
+	PairingExpiresAt *time.Time    `json:"pairing_expires_at"`
+	PairedAt         *time.Time    `json:"paired_at"`
+	RevokedAt        *time.Time    `json:"revoked_at"`
+	RegisteredByID   *uuid.UUID    `gorm:"type:uuid" json:"registered_by_id"` // Responsável pelas ações do terminal, como finalizar comandas
+	LastSeenAt       *time.Time    `json:"last_seen_at"`
+	LastSeenIP       string        `gorm:"column:last_seen_ip;size:45" json:"last_seen_ip"`
+	AutoLockMinutes  int           `gorm:"not null;default:5" json:"auto_lock_minutes"` // Inatividade que bloqueia o terminal
+	ActiveUserID     *uuid.UUID    `gorm:"type:uuid" json:"active_user_id"`             // Funcionário que desbloqueou o terminal
+	SessionJTI       *uuid.UUID    `gorm:"column:session_jti;type:uuid" json:"-"`       // Token emitido no último desbloqueio
+	LastActivityAt   *time.Time    `json:"last_activity_at"`
+	// Tentativas de PIN erradas seguidas no terminal; ao atingir o limite ele fica bloqueado até LockedUntil
+	FailedAttempts int        `gorm:"not null;default:0" json:"-"`
+	LockedUntil    *time.Time `json:"locked_until"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// Can informa se a credencial do terminal permite a ação
+func (d *Device) Can(scope DeviceScope) bool {
+	for _, s := range deviceScopes[d.Kind] {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Idle informa se o terminal passou do tempo de inatividade e deve ser bloqueado
+func (d *Device) Idle(now time.Time) bool {
+	return d.LastActivityAt == nil || now.After(d.LastActivityAt.Add(time.Duration(d.AutoLockMinutes)*time.Minute))
+}
+
+// Locked informa se o terminal está bloqueado por excesso de tentativas de PIN erradas
+func (d *Device) Locked(now time.Time) bool {
+	return d.LockedUntil != nil && now.Before(*d.LockedUntil)
+}
+
+func (d *Device) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
+
+// AfterFind preenche a situação e as ações liberadas do terminal
+func (d *Device) AfterFind(tx *gorm.DB) error {
+	switch {
+	case d.RevokedAt != nil:
+		d.Status = DeviceStatusRevoked
+	case d.TokenHash == nil:
+		d.Status = DeviceStatusPending
+	default:
+		d.Status = DeviceStatusActive
+	}
+	d.Scopes = d.Kind.Scopes()
+	return nil
+}
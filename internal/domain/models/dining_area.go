@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DiningArea é um ambiente do restaurante (salão, varanda, bar) que agrupa mesas na planta
+type DiningArea struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RestaurantID uuid.UUID `gorm:"type:uuid;not null" json:"restaurant_id"`
+	Name         string    `gorm:"size:100;not null" json:"name"`
+	SortOrder    int       `gorm:"not null;default:0" json:"sort_order"`
+	Active       bool      `gorm:"default:true" json:"active"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (a *DiningArea) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
@@ -30,22 +30,50 @@ const (
 	TransactionCategoryMaintenance TransactionCategory = "maintenance"
 )
 
+func (t TransactionType) Valid() bool {
+	return t == TransactionTypeIncome || t == TransactionTypeExpense
+}
+
+func (c TransactionCategory) Valid() bool {
+	switch c {
+	case TransactionCategorySales, TransactionCategoryOther,
+		TransactionCategoryIngredients, TransactionCategoryUtilities, TransactionCategorySalaries,
+		TransactionCategoryRent, TransactionCategoryEquipment, TransactionCategoryMaintenance:
+		return true
+	}
+	return false
+}
+
+// TransactionSource identifica o registro que gerou uma transação financeira
+type TransactionSource string
+
+const (
+	TransactionSourceManual         TransactionSource = ""                // Lançada diretamente no financeiro
+	TransactionSourcePayment        TransactionSource = "payment"         // Pagamento de um pedido
+	TransactionSourcePurchaseOrder  TransactionSource = "purchase_order"  // Recebimento de um pedido de compra
+	TransactionSourceRecurring      TransactionSource = "recurring"       // Ocorrência de um lançamento recorrente
+	TransactionSourceBillSettlement TransactionSource = "bill_settlement" // Baixa de uma conta a pagar ou a receber
+)
+
 type FinancialTransaction struct {
-	ID            uuid.UUID           `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
-	RestaurantID  uuid.UUID           `json:"restaurant_id" gorm:"type:uuid;not null"`
-	Restaurant    *Restaurant         `json:"restaurant,omitempty" gorm:"foreignKey:RestaurantID"`
-	Type          TransactionType     `gorm:"size:20;not null" json:"type"`
-	Category      TransactionCategory `gorm:"size:30;not null" json:"category"`
-	Amount        float64             `gorm:"not null" json:"amount"`
-	Description   string              `gorm:"size:255" json:"description"`
-	OrderID       *uuid.UUID          `json:"order_id,omitempty" gorm:"type:uuid"`
-	Order         *Order              `json:"order,omitempty" gorm:"foreignKey:OrderID"`
-	UserID        uuid.UUID           `json:"user_id" gorm:"type:uuid;not null"`
-	User          *User               `json:"user,omitempty" gorm:"foreignKey:UserID"`
-	PaymentMethod string              `gorm:"size:30" json:"payment_method"`
-	Date          time.Time           `gorm:"not null" json:"date"`
-	CreatedAt     time.Time           `json:"created_at"`
-	UpdatedAt     time.Time           `json:"updated_at"`
+	ID              uuid.UUID           `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RestaurantID    uuid.UUID           `json:"restaurant_id" gorm:"type:uuid;not null"`
+	Restaurant      *Restaurant         `json:"restaurant,omitempty" gorm:"foreignKey:RestaurantID"`
+	Type            TransactionType     `gorm:"size:20;not null" json:"type"`
+	Category        TransactionCategory `gorm:"size:30;not null" json:"category"`
+	Amount          Money               `gorm:"not null" json:"amount"`
+	Description     string              `gorm:"size:255" json:"description"`
+	OrderID         *uuid.UUID          `json:"order_id,omitempty" gorm:"type:uuid"`
+	Order           *Order              `json:"order,omitempty" gorm:"foreignKey:OrderID"`
+	PurchaseOrderID *uuid.UUID          `json:"purchase_order_id,omitempty" gorm:"type:uuid"` // Pedido de compra cujo recebimento gerou a despesa
+	RecurringID     *uuid.UUID          `json:"recurring_id,omitempty" gorm:"type:uuid"`      // Lançamento recorrente que gerou a transação
+	OccurrenceDate  *time.Time          `json:"occurrence_date,omitempty" gorm:"type:date"`   // Data da ocorrência do lançamento recorrente
+	UserID          uuid.UUID           `json:"user_id" gorm:"type:uuid;not null"`
+	User            *User               `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	PaymentMethod   string              `gorm:"size:30" json:"payment_method"`
+	Date            time.Time           `gorm:"not null" json:"date"`
+	CreatedAt       time.Time           `json:"created_at"`
+	UpdatedAt       time.Time           `json:"updated_at"`
 }
 
 func (ft *FinancialTransaction) BeforeCreate(tx *gorm.DB) error {
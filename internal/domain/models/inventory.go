@@ -0,0 +1,102 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IngredientUnit string
+
+const (
+	IngredientUnitGram       IngredientUnit = "g"
+	IngredientUnitKilogram   IngredientUnit = "kg"
+	IngredientUnitMilliliter IngredientUnit = "ml"
+	IngredientUnitLiter      IngredientUnit = "l"
+	IngredientUnitPiece      IngredientUnit = "un"
+)
+
+func (u IngredientUnit) Valid() bool {
+	switch u {
+	case IngredientUnitGram, IngredientUnitKilogram, IngredientUnitMilliliter, IngredientUnitLiter, IngredientUnitPiece:
+		return true
+	}
+	return false
+}
+
+// Ingredient é um insumo controlado no estoque do restaurante
+type Ingredient struct {
+	ID           uuid.UUID      `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RestaurantID uuid.UUID      `gorm:"type:uuid;not null" json:"restaurant_id"`
+	Name         string         `gorm:"size:100;not null" json:"name"`
+	Unit         IngredientUnit `gorm:"size:10;not null" json:"unit"`
+	OnHand       Quantity       `gorm:"not null;default:0" json:"on_hand"`       // Pode ficar negativo se a cozinha usar mais do que o registrado
+	MinimumStock Quantity       `gorm:"not null;default:0" json:"minimum_stock"` // Nível de alerta para reposição
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+}
+
+// LowStock indica se o insumo atingiu o nível de reposição
+func (i *Ingredient) LowStock() bool {
+	return i.OnHand <= i.MinimumStock
+}
+
+// RecipeItem é a quantidade de um insumo consumida por uma porção do produto
+// ou por uma unidade da opção de complemento. Exatamente um entre ProductID e OptionID é preenchido.
+type RecipeItem struct {
+	ID           uuid.UUID   `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RestaurantID uuid.UUID   `gorm:"type:uuid;not null" json:"restaurant_id"`
+	ProductID    *uuid.UUID  `gorm:"type:uuid" json:"product_id"`
+	OptionID     *uuid.UUID  `gorm:"type:uuid" json:"option_id"`
+	IngredientID uuid.UUID   `gorm:"type:uuid;not null" json:"ingredient_id"`
+	Ingredient   *Ingredient `json:"ingredient,omitempty" gorm:"foreignKey:IngredientID"`
+	Quantity     Quantity    `gorm:"not null" json:"quantity"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+type StockMovementType string
+
+const (
+	StockMovementPurchase    StockMovementType = "purchase"    // Entrada por compra avulsa ou recebimento de pedido de compra
+	StockMovementAdjustment  StockMovementType = "adjustment"  // Correção após contagem
+	StockMovementWaste       StockMovementType = "waste"       // Perda ou descarte
+	StockMovementConsumption StockMovementType = "consumption" // Baixa pelo preparo de um pedido
+	StockMovementReturn      StockMovementType = "return"      // Estorno da baixa de um pedido cancelado
+)
+
+// StockMovement registra cada alteração do estoque de um insumo; Quantity é negativa nas saídas
+type StockMovement struct {
+	ID              uuid.UUID         `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RestaurantID    uuid.UUID         `gorm:"type:uuid;not null" json:"restaurant_id"`
+	IngredientID    uuid.UUID         `gorm:"type:uuid;not null" json:"ingredient_id"`
+	Type            StockMovementType `gorm:"size:20;not null" json:"type"`
+	Quantity        Quantity          `gorm:"not null" json:"quantity"`
+	OrderID         *uuid.UUID        `gorm:"type:uuid" json:"order_id"`
+	PurchaseOrderID *uuid.UUID        `gorm:"type:uuid" json:"purchase_order_id"`
+	UserID          *uuid.UUID        `gorm:"type:uuid" json:"user_id"`
+	Notes           string            `gorm:"size:255" json:"notes"`
+	CreatedAt       time.Time         `json:"created_at"`
+}
+
+func (i *Ingredient) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+func (r *RecipeItem) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+func (m *StockMovement) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
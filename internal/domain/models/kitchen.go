@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// KitchenStation representa uma praça de preparo da cozinha, como chapa, bar ou sobremesas
+type KitchenStation struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RestaurantID uuid.UUID `gorm:"type:uuid;not null" json:"restaurant_id"`
+	Name         string    `gorm:"size:100;not null" json:"name"`
+	Active       bool      `gorm:"default:true" json:"active"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type TicketStatus string
+
+const (
+	TicketStatusQueued     TicketStatus = "queued"
+	TicketStatusInProgress TicketStatus = "in_progress"
+	TicketStatusDone       TicketStatus = "done"
+)
+
+// KitchenTicket é a comanda de preparo de um item do pedido em uma praça.
+// Os dados do pedido e do produto são copiados para que a tela da cozinha não dependa de joins.
+type KitchenTicket struct {
+	ID           uuid.UUID    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RestaurantID uuid.UUID    `gorm:"type:uuid;not null" json:"restaurant_id"`
+	StationID    *uuid.UUID   `gorm:"type:uuid" json:"station_id"` // Nulo quando o produto não tem praça definida
+	OrderID      uuid.UUID    `gorm:"type:uuid;not null;index" json:"order_id"`
+	OrderItemID  uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex" json:"order_item_id"`
+	OrderCode    string       `gorm:"size:20" json:"order_code"`
+	OrderType    OrderType    `gorm:"size:20" json:"order_type"`
+	ProductName  string       `gorm:"size:100;not null" json:"product_name"`
+	Quantity     int          `gorm:"not null" json:"quantity"`
+	Options      string       `gorm:"size:500" json:"options"` // Complementos escolhidos, ex.: "Bacon x2, Sem cebola"
+	Notes        string       `gorm:"size:255" json:"notes"`
+	Status       TicketStatus `gorm:"size:20;not null;default:queued" json:"status"`
+	StartedAt    *time.Time   `json:"started_at"`
+	DoneAt       *time.Time   `json:"done_at"`
+	DoneByID     *uuid.UUID   `gorm:"type:uuid" json:"done_by_id"`     // Usuário que finalizou a comanda
+	DoneDeviceID *uuid.UUID   `gorm:"type:uuid" json:"done_device_id"` // Terminal em que a comanda foi finalizada
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+func (ks *KitchenStation) BeforeCreate(tx *gorm.DB) error {
+	if ks.ID == uuid.Nil {
+		ks.ID = uuid.New()
+	}
+	return nil
+}
+
+func (kt *KitchenTicket) BeforeCreate(tx *gorm.DB) error {
+	if kt.ID == uuid.Nil {
+		kt.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Membership vincula o usuário a um restaurante com o tipo e o papel que ele tem ali.
+// Um usuário pode pertencer a vários restaurantes; cada token de acesso vale para um deles.
+type Membership struct {
+	ID           uuid.UUID   `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	UserID       uuid.UUID   `gorm:"type:uuid;not null" json:"user_id"`
+	RestaurantID uuid.UUID   `gorm:"type:uuid;not null" json:"restaurant_id"`
+	Type         UserType    `gorm:"size:20;not null;default:'staff'" json:"type"` // admin, manager, staff
+	RoleID       *uuid.UUID  `gorm:"type:uuid" json:"role_id"`                     // Sem papel próprio vale o papel padrão do tipo
+	User         *User       `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Restaurant   *Restaurant `json:"restaurant,omitempty" gorm:"foreignKey:RestaurantID"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+func (m *Membership) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money representa um valor monetário em centavos.
+//
+// No banco o valor é gravado como numeric(12,2) e no JSON como número com duas casas
+// decimais. Valores com mais de duas casas são arredondados para o centavo mais próximo,
+// com empates arredondados para longe do zero (0,005 -> 0,01 e -0,005 -> -0,01), a mesma
+// regra usada pelo Postgres ao converter as antigas colunas double precision para numeric.
+type Money int64
+
+var ErrInvalidMoney = errors.New("invalid money value")
+
+// NewMoneyFromCents cria um valor a partir de centavos
+func NewMoneyFromCents(cents int64) Money {
+	return Money(cents)
+}
+
+// ParseMoney converte um texto decimal ("12", "12.5", "-0.015") sem passar por ponto flutuante
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, ErrInvalidMoney
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" {
+		return 0, ErrInvalidMoney
+	}
+	if intPart == "" {
+		intPart = "0"
+	}
+	if !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, ErrInvalidMoney
+	}
+
+	units, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil || units > math.MaxInt64/100-1 {
+		return 0, ErrInvalidMoney
+	}
+
+	// Duas primeiras casas formam os centavos; a terceira decide o arredondamento
+	fracPart += "000"
+	cents := units*100 + int64(fracPart[0]-'0')*10 + int64(fracPart[1]-'0')
+	if fracPart[2] >= '5' {
+		cents++
+	}
+
+	if negative {
+		cents = -cents
+	}
+	return Money(cents), nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Cents retorna o valor em centavos
+func (m Money) Cents() int64 {
+	return int64(m)
+}
+
+func (m Money) Add(other Money) Money {
+	return m + other
+}
+
+func (m Money) Sub(other Money) Money {
+	return m - other
+}
+
+// Mul multiplica o valor por uma quantidade inteira
+func (m Money) Mul(quantity int) Money {
+	return m * Money(quantity)
+}
+
+// MulQuantity calcula o valor de uma quantidade de insumo a partir do preço por unidade,
+// arredondando para o centavo mais próximo
+func (m Money) MulQuantity(quantity Quantity) Money {
+	return Money(int64(m) * int64(quantity)).Div(1000)
+}
+
+// Div divide o valor em n partes, arredondando o resultado para o centavo mais próximo
+func (m Money) Div(n int) Money {
+	if n == 0 {
+		return 0
+	}
+
+	divisor := int64(n)
+	quotient := int64(m) / divisor
+	remainder := int64(m) % divisor
+
+	// Empates são arredondados para longe do zero
+	if 2*abs64(remainder) >= abs64(divisor) {
+		if (int64(m) < 0) != (divisor < 0) {
+			quotient--
+		} else {
+			quotient++
+		}
+	}
+	return Money(quotient)
+}
+
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func (m Money) IsZero() bool {
+	return m == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m < 0
+}
+
+// String formata o valor com duas casas decimais, por exemplo "-12.05"
+func (m Money) String() string {
+	sign := ""
+	cents := int64(m)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// GormDataType define o tipo da coluna usado nas migrações
+func (Money) GormDataType() string {
+	return "numeric(12,2)"
+}
+
+// Value grava o valor como texto decimal para que o Postgres não passe por ponto flutuante
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan lê colunas numeric (texto), inteiras ou double precision ainda não migradas
+func (m *Money) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*m = 0
+		return nil
+	case string:
+		return m.parse(v)
+	case []byte:
+		return m.parse(string(v))
+	case int64:
+		*m = Money(v * 100)
+		return nil
+	case float64:
+		return m.parse(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		return fmt.Errorf("cannot scan %T into Money", value)
+	}
+}
+
+func (m *Money) parse(s string) error {
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// MarshalJSON escreve o valor como número com duas casas decimais
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON aceita números ou textos decimais sem convertê-los para float
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" {
+		return nil
+	}
+	if strings.ContainsAny(s, "eE") {
+		return ErrInvalidMoney
+	}
+	return m.parse(s)
+}
@@ -0,0 +1,194 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Money
+		err   bool
+	}{
+		{input: "12", want: 1200},
+		{input: "12.5", want: 1250},
+		{input: "12.05", want: 1205},
+		{input: "+3.10", want: 310},
+		{input: " 7.00 ", want: 700},
+		{input: ".5", want: 50},
+		{input: "5.", want: 500},
+		{input: "-12.05", want: -1205},
+		// Empates são arredondados para longe do zero
+		{input: "0.005", want: 1},
+		{input: "-0.005", want: -1},
+		{input: "-0.015", want: -2},
+		{input: "0.004", want: 0},
+		{input: "12.999", want: 1300},
+		{input: "0.0049999", want: 0},
+		{input: "", err: true},
+		{input: "-", err: true},
+		{input: ".", err: true},
+		{input: "abc", err: true},
+		{input: "1.2.3", err: true},
+		{input: "1,50", err: true},
+		{input: "--1", err: true},
+		{input: "92233720368547758", err: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.input)
+		if tt.err {
+			if !errors.Is(err, ErrInvalidMoney) {
+				t.Errorf("ParseMoney(%q) error = %v, want ErrInvalidMoney", tt.input, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseMoney(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseMoney(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyDiv(t *testing.T) {
+	tests := []struct {
+		value Money
+		n     int
+		want  Money
+	}{
+		{value: 1000, n: 3, want: 333},
+		{value: 1001, n: 2, want: 501},
+		{value: -1001, n: 2, want: -501},
+		{value: 1000, n: -3, want: -333},
+		{value: 5, n: 10, want: 1},
+		{value: 4, n: 10, want: 0},
+		{value: 1000, n: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.value.Div(tt.n); got != tt.want {
+			t.Errorf("Money(%d).Div(%d) = %d, want %d", tt.value, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyMulQuantity(t *testing.T) {
+	tests := []struct {
+		price    Money
+		quantity Quantity
+		want     Money
+	}{
+		{price: 1000, quantity: 1000, want: 1000},
+		{price: 1000, quantity: 250, want: 250},
+		{price: 999, quantity: 500, want: 500}, // 4,995 arredonda para 5,00
+		{price: 1, quantity: 1, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.price.MulQuantity(tt.quantity); got != tt.want {
+			t.Errorf("Money(%d).MulQuantity(%d) = %d, want %d", tt.price, tt.quantity, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		value Money
+		want  string
+	}{
+		{value: 0, want: "0.00"},
+		{value: 5, want: "0.05"},
+		{value: 1205, want: "12.05"},
+		{value: -1205, want: "-12.05"},
+		{value: -5, want: "-0.05"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.value.String(); got != tt.want {
+			t.Errorf("Money(%d).String() = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyScan(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  Money
+		err   bool
+	}{
+		{name: "nil", value: nil, want: 0},
+		{name: "numeric text", value: "12.34", want: 1234},
+		{name: "numeric bytes", value: []byte("-0.50"), want: -50},
+		{name: "integer", value: int64(7), want: 700},
+		{name: "double precision", value: 0.1 + 0.2, want: 30},
+		{name: "double precision tie", value: 12.345, want: 1235},
+		{name: "invalid text", value: "abc", err: true},
+		{name: "unsupported type", value: true, err: true},
+	}
+
+	for _, tt := range tests {
+		m := Money(99)
+		err := m.Scan(tt.value)
+		if tt.err {
+			if err == nil {
+				t.Errorf("%s: Scan(%v) expected error", tt.name, tt.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: Scan(%v) unexpected error: %v", tt.name, tt.value, err)
+			continue
+		}
+		if m != tt.want {
+			t.Errorf("%s: Scan(%v) = %d, want %d", tt.name, tt.value, m, tt.want)
+		}
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Money
+		err   bool
+	}{
+		{input: `12.5`, want: 1250},
+		{input: `"12.50"`, want: 1250},
+		{input: `-0.015`, want: -2},
+		{input: `1e3`, err: true},
+		{input: `"abc"`, err: true},
+	}
+
+	for _, tt := range tests {
+		var m Money
+		err := json.Unmarshal([]byte(tt.input), &m)
+		if tt.err {
+			if err == nil {
+				t.Errorf("Unmarshal(%s) expected error", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unmarshal(%s) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if m != tt.want {
+			t.Errorf("Unmarshal(%s) = %d, want %d", tt.input, m, tt.want)
+		}
+
+		data, err := json.Marshal(m)
+		if err != nil || string(data) != m.String() {
+			t.Errorf("Marshal(%d) = %s, %v, want %s", m, data, err, m.String())
+		}
+	}
+
+	var m Money = 42
+	if err := json.Unmarshal([]byte(`null`), &m); err != nil || m != 42 {
+		t.Errorf("Unmarshal(null) = %d, %v, want value unchanged", m, err)
+	}
+}
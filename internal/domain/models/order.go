@@ -35,32 +35,58 @@ type Order struct {
 	UserID          uuid.UUID   `json:"user_id" gorm:"type:uuid;not null"`
 	User            *User       `json:"user,omitempty" gorm:"foreignKey:UserID"`
 	Code            string      `gorm:"size:20" json:"code"`
+	CodePeriod      string      `gorm:"size:10" json:"code_period"` // Período da sequência que gerou o código, ex.: "2026-10-17"
 	CustomerName    string      `gorm:"size:100" json:"customer_name"`
 	CustomerPhone   string      `gorm:"size:20" json:"customer_phone"`
 	CustomerEmail   string      `gorm:"size:100" json:"customer_email"`
 	Type            OrderType   `gorm:"size:20;not null;default:'in_house'" json:"type"`
 	Status          OrderStatus `gorm:"size:20;not null;default:'pending'" json:"status"`
 	OrderItems      []OrderItem `json:"order_items,omitempty" gorm:"foreignKey:OrderID"`
-	TotalAmount     float64     `gorm:"not null;default:0" json:"total_amount"`
+	TotalAmount     Money       `gorm:"not null;default:0" json:"total_amount"`
+	AmountPaid      Money       `gorm:"not null;default:0" json:"amount_paid"` // Soma dos pagamentos registrados
 	Notes           string      `gorm:"size:255" json:"notes"`
 	DeliveryAddress string      `gorm:"size:255" json:"delivery_address"`
 	CreatedAt       time.Time   `json:"created_at"`
 	UpdatedAt       time.Time   `json:"updated_at"`
 	PaidAt          *time.Time  `json:"paid_at"`
 	DeliveredAt     *time.Time  `json:"delivered_at"`
+	CancelledAt     *time.Time  `json:"cancelled_at"`
+	StockDeductedAt *time.Time  `json:"stock_deducted_at"` // Quando os insumos dos itens foram baixados do estoque
 }
 
 type OrderItem struct {
-	ID        uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
-	OrderID   uuid.UUID `json:"order_id" gorm:"type:uuid;not null"`
-	Order     *Order    `json:"order,omitempty" gorm:"foreignKey:OrderID"`
-	ProductID uuid.UUID `json:"product_id" gorm:"type:uuid;not null"`
-	Product   *Product  `json:"product,omitempty" gorm:"foreignKey:ProductID"`
-	Quantity  int       `gorm:"not null;default:1" json:"quantity"`
-	Price     float64   `gorm:"not null" json:"price"` // Preço no momento da venda
-	Notes     string    `gorm:"size:255" json:"notes"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uuid.UUID         `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	OrderID   uuid.UUID         `json:"order_id" gorm:"type:uuid;not null"`
+	Order     *Order            `json:"order,omitempty" gorm:"foreignKey:OrderID"`
+	ProductID uuid.UUID         `json:"product_id" gorm:"type:uuid;not null"`
+	Product   *Product          `json:"product,omitempty" gorm:"foreignKey:ProductID"`
+	Quantity  int               `gorm:"not null;default:1" json:"quantity"`
+	Price     Money             `gorm:"not null" json:"price"` // Preço unitário no momento da venda, já com os complementos
+	Notes     string            `gorm:"size:255" json:"notes"`
+	Options   []OrderItemOption `json:"options,omitempty" gorm:"foreignKey:OrderItemID"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// OrderItemOption guarda uma cópia da opção de complemento escolhida no momento da venda,
+// para que alterações posteriores no cardápio não mudem pedidos já feitos
+type OrderItemOption struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	OrderItemID uuid.UUID `json:"order_item_id" gorm:"type:uuid;not null;index"`
+	AddonID     uuid.UUID `json:"addon_id" gorm:"type:uuid;not null"`
+	OptionID    uuid.UUID `json:"option_id" gorm:"type:uuid;not null"`
+	AddonTitle  string    `gorm:"size:100" json:"addon_title"`
+	Name        string    `gorm:"size:100;not null" json:"name"`
+	Price       Money     `gorm:"not null" json:"price"` // Preço unitário da opção no momento da venda
+	Quantity    int       `gorm:"not null;default:1" json:"quantity"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (oio *OrderItemOption) BeforeCreate(tx *gorm.DB) error {
+	if oio.ID == uuid.Nil {
+		oio.ID = uuid.New()
+	}
+	return nil
 }
 
 func (oi *OrderItem) BeforeCreate(tx *gorm.DB) error {
@@ -76,3 +102,8 @@ func (o *Order) BeforeCreate(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+// Balance retorna o valor que ainda falta pagar
+func (o *Order) Balance() Money {
+	return o.TotalAmount.Sub(o.AmountPaid)
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderCodeReset define quando a numeração dos pedidos recomeça
+type OrderCodeReset string
+
+const (
+	OrderCodeResetDaily   OrderCodeReset = "daily"
+	OrderCodeResetMonthly OrderCodeReset = "monthly"
+	OrderCodeResetNever   OrderCodeReset = "never"
+)
+
+// OrderCodeSettings guarda o formato do código dos pedidos de um restaurante
+type OrderCodeSettings struct {
+	RestaurantID uuid.UUID      `gorm:"primaryKey;type:uuid" json:"restaurant_id"`
+	Prefix       string         `gorm:"size:10;not null" json:"prefix"`
+	Reset        OrderCodeReset `gorm:"size:20;not null;default:'daily'" json:"reset"`
+	Padding      int            `gorm:"not null;default:3" json:"padding"`              // Quantidade mínima de dígitos do número
+	Timezone     string         `gorm:"size:50;not null;default:'UTC'" json:"timezone"` // Fuso que define o dia e o mês do código, ex.: "America/Sao_Paulo"
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+}
+
+// DefaultOrderCodeSettings retorna o formato padrão: #DDNNN com reinício diário
+func DefaultOrderCodeSettings(restaurantID uuid.UUID) *OrderCodeSettings {
+	return &OrderCodeSettings{
+		RestaurantID: restaurantID,
+		Prefix:       "#",
+		Reset:        OrderCodeResetDaily,
+		Padding:      3,
+		Timezone:     "UTC",
+	}
+}
+
+// Location retorna o fuso do restaurante; um fuso inválido ou vazio é tratado como UTC
+func (s *OrderCodeSettings) Location() *time.Location {
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// Period retorna a chave do período da sequência para o instante informado, no fuso do restaurante
+func (s *OrderCodeSettings) Period(at time.Time) string {
+	at = at.In(s.Location())
+	switch s.Reset {
+	case OrderCodeResetMonthly:
+		return at.Format("2006-01")
+	case OrderCodeResetNever:
+		return "all"
+	default:
+		return at.Format("2006-01-02")
+	}
+}
+
+// Format monta o código do pedido: prefixo, dia (ou mês) do período e número com zeros à esquerda.
+// O mês leva o marcador "M" e um hífen para que um código mensal, como #M10-001, nunca seja igual a
+// um código diário, como #10001, quando o restaurante troca o reinício no meio do período.
+func (s *OrderCodeSettings) Format(at time.Time, number int64) string {
+	at = at.In(s.Location())
+
+	var code strings.Builder
+	code.WriteString(s.Prefix)
+
+	switch s.Reset {
+	case OrderCodeResetDaily:
+		fmt.Fprintf(&code, "%02d", at.Day())
+	case OrderCodeResetMonthly:
+		fmt.Fprintf(&code, "M%02d-", int(at.Month()))
+	}
+
+	fmt.Fprintf(&code, "%0*d", s.Padding, number)
+	return code.String()
+}
+
+// OrderCodeSequence guarda o último número emitido por restaurante e período
+type OrderCodeSequence struct {
+	RestaurantID uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Period       string    `gorm:"primaryKey;size:10"`
+	LastValue    int64     `gorm:"not null;default:0"`
+	UpdatedAt    time.Time
+}
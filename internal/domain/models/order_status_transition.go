@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OrderStatusTransition registra cada mudança de status de um pedido para auditoria
+type OrderStatusTransition struct {
+	ID           uuid.UUID   `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RestaurantID uuid.UUID   `json:"restaurant_id" gorm:"type:uuid;not null;index"`
+	OrderID      uuid.UUID   `json:"order_id" gorm:"type:uuid;not null;index"`
+	FromStatus   OrderStatus `gorm:"size:20" json:"from_status"` // Vazio na criação do pedido
+	ToStatus     OrderStatus `gorm:"size:20;not null" json:"to_status"`
+	UserID       *uuid.UUID  `json:"user_id" gorm:"type:uuid"` // Nulo quando a mudança veio de um terminal sem usuário conectado
+	User         *User       `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	DeviceID     *uuid.UUID  `json:"device_id" gorm:"type:uuid"` // Terminal em que a mudança foi feita, se houver
+	CreatedAt    time.Time   `json:"created_at"`
+}
+
+func (t *OrderStatusTransition) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PaymentMethod string
+
+const (
+	PaymentMethodCash       PaymentMethod = "cash"
+	PaymentMethodCreditCard PaymentMethod = "credit_card"
+	PaymentMethodDebitCard  PaymentMethod = "debit_card"
+	PaymentMethodPix        PaymentMethod = "pix"
+	PaymentMethodVoucher    PaymentMethod = "voucher"
+	PaymentMethodOther      PaymentMethod = "other"
+)
+
+// Payment representa um pagamento parcial ou total de um pedido
+type Payment struct {
+	ID                     uuid.UUID     `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RestaurantID           uuid.UUID     `json:"restaurant_id" gorm:"type:uuid;not null"`
+	OrderID                uuid.UUID     `json:"order_id" gorm:"type:uuid;not null;index"`
+	Method                 PaymentMethod `gorm:"size:30;not null" json:"method"`
+	Amount                 Money         `gorm:"not null" json:"amount"`       // Valor abatido do saldo do pedido
+	Tendered               Money         `gorm:"not null" json:"tendered"`     // Valor entregue pelo cliente
+	ChangeGiven            Money         `gorm:"not null" json:"change_given"` // Troco devolvido (apenas em dinheiro)
+	PayerLabel             string        `gorm:"size:100" json:"payer_label"`  // Identificação de quem pagou, ex.: "Pessoa 2"
+	UserID                 uuid.UUID     `json:"user_id" gorm:"type:uuid;not null"`
+	FinancialTransactionID *uuid.UUID    `json:"financial_transaction_id" gorm:"type:uuid"`
+	CashSessionID          *uuid.UUID    `json:"cash_session_id" gorm:"type:uuid"` // Turno de caixa que recebeu o pagamento
+	Items                  []PaymentItem `json:"items,omitempty" gorm:"foreignKey:PaymentID"`
+	CreatedAt              time.Time     `json:"created_at"`
+}
+
+// PaymentItem indica quais itens do pedido um pagamento cobre, na divisão por item
+type PaymentItem struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	PaymentID   uuid.UUID `json:"payment_id" gorm:"type:uuid;not null;index"`
+	OrderItemID uuid.UUID `json:"order_item_id" gorm:"type:uuid;not null"`
+	Quantity    int       `gorm:"not null" json:"quantity"`
+	Amount      Money     `gorm:"not null" json:"amount"`
+}
+
+func (p *Payment) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+func (pi *PaymentItem) BeforeCreate(tx *gorm.DB) error {
+	if pi.ID == uuid.Nil {
+		pi.ID = uuid.New()
+	}
+	return nil
+}
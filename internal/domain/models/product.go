@@ -23,12 +23,13 @@ type Product struct {
 	Restaurant   *Restaurant      `json:"restaurant,omitempty" gorm:"foreignKey:RestaurantID"`
 	Name         string           `gorm:"size:100;not null" json:"name"`
 	Description  string           `gorm:"size:255" json:"description"`
-	Price        float64          `gorm:"not null" json:"price"`
+	Price        Money            `gorm:"not null" json:"price"`
 	CategoryID   uuid.UUID        `gorm:"type:uuid;not null" json:"category_id"`
 	Category     *ProductCategory `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
 	Type         ProductType      `gorm:"size:20" json:"type"` // Campo mantido para compatibilidade
 	InStock      bool             `gorm:"default:true" json:"in_stock"`
 	ImageURL     string           `gorm:"size:255" json:"image_url"`
+	StationID    *uuid.UUID       `gorm:"type:uuid" json:"station_id"` // Praça de preparo; quando nulo vale a da categoria
 	CreatedAt    time.Time        `json:"created_at"`
 	UpdatedAt    time.Time        `json:"updated_at"`
 }
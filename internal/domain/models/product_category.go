@@ -15,6 +15,7 @@ type ProductCategory struct {
 	Name         string      `gorm:"size:100;not null" json:"name"`
 	Description  string      `gorm:"size:255" json:"description"`
 	Active       bool        `gorm:"default:true" json:"active"`
+	StationID    *uuid.UUID  `gorm:"type:uuid" json:"station_id"` // Praça de preparo padrão dos produtos da categoria
 	CreatedAt    time.Time   `json:"created_at"`
 	UpdatedAt    time.Time   `json:"updated_at"`
 }
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Supplier é um fornecedor de insumos do restaurante
+type Supplier struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RestaurantID uuid.UUID `gorm:"type:uuid;not null" json:"restaurant_id"`
+	Name         string    `gorm:"size:100;not null" json:"name"`
+	Document     string    `gorm:"size:20" json:"document"` // CNPJ ou CPF
+	ContactName  string    `gorm:"size:100" json:"contact_name"`
+	Phone        string    `gorm:"size:20" json:"phone"`
+	Email        string    `gorm:"size:100" json:"email"`
+	Notes        string    `gorm:"size:255" json:"notes"`
+	Active       bool      `gorm:"default:true" json:"active"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderStatusDraft             PurchaseOrderStatus = "draft"              // Em elaboração; itens podem ser alterados
+	PurchaseOrderStatusOrdered           PurchaseOrderStatus = "ordered"            // Enviado ao fornecedor
+	PurchaseOrderStatusPartiallyReceived PurchaseOrderStatus = "partially_received" // Parte das mercadorias foi recebida
+	PurchaseOrderStatusReceived          PurchaseOrderStatus = "received"           // Todas as mercadorias foram recebidas
+	PurchaseOrderStatusCancelled         PurchaseOrderStatus = "cancelled"
+)
+
+// PurchaseOrder é um pedido de compra a um fornecedor. Cada recebimento de mercadorias
+// aumenta o estoque dos insumos e gera uma despesa ligada ao pedido em Transactions.
+type PurchaseOrder struct {
+	ID            uuid.UUID              `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RestaurantID  uuid.UUID              `gorm:"type:uuid;not null" json:"restaurant_id"`
+	SupplierID    uuid.UUID              `gorm:"type:uuid;not null" json:"supplier_id"`
+	Supplier      *Supplier              `json:"supplier,omitempty" gorm:"foreignKey:SupplierID"`
+	Status        PurchaseOrderStatus    `gorm:"size:20;not null;default:'draft'" json:"status"`
+	Notes         string                 `gorm:"size:255" json:"notes"`
+	Total         Money                  `gorm:"not null;default:0" json:"total"`          // Valor de todos os itens pedidos
+	ReceivedTotal Money                  `gorm:"not null;default:0" json:"received_total"` // Valor já recebido e lançado como despesa
+	ExpectedAt    *time.Time             `json:"expected_at"`
+	Items         []PurchaseOrderItem    `json:"items,omitempty" gorm:"foreignKey:PurchaseOrderID"`
+	Transactions  []FinancialTransaction `json:"transactions,omitempty" gorm:"foreignKey:PurchaseOrderID"`
+	UserID        uuid.UUID              `gorm:"type:uuid;not null" json:"user_id"`
+	OrderedAt     *time.Time             `json:"ordered_at"`
+	ReceivedAt    *time.Time             `json:"received_at"`
+	CancelledAt   *time.Time             `json:"cancelled_at"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+}
+
+// Editable indica se os itens do pedido ainda podem ser alterados
+func (p *PurchaseOrder) Editable() bool {
+	return p.Status == PurchaseOrderStatusDraft
+}
+
+// Receivable indica se o pedido aguarda mercadorias
+func (p *PurchaseOrder) Receivable() bool {
+	return p.Status == PurchaseOrderStatusOrdered || p.Status == PurchaseOrderStatusPartiallyReceived
+}
+
+// PurchaseOrderItem é uma linha do pedido de compra; UnitCost é o preço por unidade do insumo
+type PurchaseOrderItem struct {
+	ID               uuid.UUID   `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	PurchaseOrderID  uuid.UUID   `gorm:"type:uuid;not null" json:"purchase_order_id"`
+	IngredientID     uuid.UUID   `gorm:"type:uuid;not null" json:"ingredient_id"`
+	Ingredient       *Ingredient `json:"ingredient,omitempty" gorm:"foreignKey:IngredientID"`
+	Quantity         Quantity    `gorm:"not null" json:"quantity"`
+	QuantityReceived Quantity    `gorm:"not null;default:0" json:"quantity_received"`
+	UnitCost         Money       `gorm:"not null" json:"unit_cost"`
+	CreatedAt        time.Time   `json:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at"`
+}
+
+// Remaining retorna a quantidade que ainda falta receber
+func (i *PurchaseOrderItem) Remaining() Quantity {
+	if i.QuantityReceived >= i.Quantity {
+		return 0
+	}
+	return i.Quantity.Sub(i.QuantityReceived)
+}
+
+func (s *Supplier) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+func (p *PurchaseOrder) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+func (i *PurchaseOrderItem) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
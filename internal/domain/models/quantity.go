@@ -0,0 +1,153 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Quantity representa uma quantidade de estoque em milésimos da unidade do insumo
+// (miligramas para g, mililitros para l, milésimos para un).
+//
+// Assim como Money, é gravada como numeric(14,3) e trafega no JSON como número decimal,
+// sem passar por ponto flutuante. Valores com mais de três casas são arredondados para
+// o milésimo mais próximo, com empates arredondados para longe do zero.
+type Quantity int64
+
+var ErrInvalidQuantity = errors.New("invalid quantity value")
+
+// ParseQuantity converte um texto decimal ("2", "0.25", "-1.5") para Quantity
+func ParseQuantity(s string) (Quantity, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, ErrInvalidQuantity
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" {
+		return 0, ErrInvalidQuantity
+	}
+	if intPart == "" {
+		intPart = "0"
+	}
+	if !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, ErrInvalidQuantity
+	}
+
+	units, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil || units > math.MaxInt64/1000-1 {
+		return 0, ErrInvalidQuantity
+	}
+
+	// Três primeiras casas formam os milésimos; a quarta decide o arredondamento
+	fracPart += "0000"
+	thousandths := units*1000 + int64(fracPart[0]-'0')*100 + int64(fracPart[1]-'0')*10 + int64(fracPart[2]-'0')
+	if fracPart[3] >= '5' {
+		thousandths++
+	}
+
+	if negative {
+		thousandths = -thousandths
+	}
+	return Quantity(thousandths), nil
+}
+
+func (q Quantity) Add(other Quantity) Quantity {
+	return q + other
+}
+
+func (q Quantity) Sub(other Quantity) Quantity {
+	return q - other
+}
+
+// Mul multiplica a quantidade por um número inteiro de porções
+func (q Quantity) Mul(n int) Quantity {
+	return q * Quantity(n)
+}
+
+func (q Quantity) IsZero() bool {
+	return q == 0
+}
+
+func (q Quantity) IsNegative() bool {
+	return q < 0
+}
+
+// String formata a quantidade com três casas decimais, por exemplo "-0.250"
+func (q Quantity) String() string {
+	sign := ""
+	value := int64(q)
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	return fmt.Sprintf("%s%d.%03d", sign, value/1000, value%1000)
+}
+
+// GormDataType define o tipo da coluna usado nas migrações
+func (Quantity) GormDataType() string {
+	return "numeric(14,3)"
+}
+
+// Value grava a quantidade como texto decimal
+func (q Quantity) Value() (driver.Value, error) {
+	return q.String(), nil
+}
+
+// Scan lê colunas numeric (texto) ou inteiras
+func (q *Quantity) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*q = 0
+		return nil
+	case string:
+		return q.parse(v)
+	case []byte:
+		return q.parse(string(v))
+	case int64:
+		*q = Quantity(v * 1000)
+		return nil
+	case float64:
+		return q.parse(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		return fmt.Errorf("cannot scan %T into Quantity", value)
+	}
+}
+
+func (q *Quantity) parse(s string) error {
+	parsed, err := ParseQuantity(s)
+	if err != nil {
+		return err
+	}
+	*q = parsed
+	return nil
+}
+
+// MarshalJSON escreve a quantidade como número com três casas decimais
+func (q Quantity) MarshalJSON() ([]byte, error) {
+	return []byte(q.String()), nil
+}
+
+// UnmarshalJSON aceita números ou textos decimais sem convertê-los para float
+func (q *Quantity) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" {
+		return nil
+	}
+	if strings.ContainsAny(s, "eE") {
+		return ErrInvalidQuantity
+	}
+	return q.parse(s)
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RecurrenceFrequency string
+
+const (
+	RecurrenceMonthly RecurrenceFrequency = "monthly" // A cada Interval meses, no dia DayOfMonth
+	RecurrenceWeekly  RecurrenceFrequency = "weekly"  // A cada Interval semanas, no dia da semana Weekday
+	RecurrenceCustom  RecurrenceFrequency = "custom"  // A cada Interval dias, a partir de StartDate
+)
+
+func (f RecurrenceFrequency) Valid() bool {
+	return f == RecurrenceMonthly || f == RecurrenceWeekly || f == RecurrenceCustom
+}
+
+// RecurringTransaction é o modelo de um lançamento que se repete, como aluguel e salários.
+// As datas são dias do calendário, guardados à meia-noite UTC como as datas dos lançamentos manuais.
+type RecurringTransaction struct {
+	ID             uuid.UUID           `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RestaurantID   uuid.UUID           `gorm:"type:uuid;not null" json:"restaurant_id"`
+	Type           TransactionType     `gorm:"size:20;not null" json:"type"`
+	Category       TransactionCategory `gorm:"size:30;not null" json:"category"`
+	Amount         Money               `gorm:"not null" json:"amount"`
+	Description    string              `gorm:"size:255" json:"description"`
+	PaymentMethod  string              `gorm:"size:30" json:"payment_method"`
+	Frequency      RecurrenceFrequency `gorm:"size:20;not null" json:"frequency"`
+	Interval       int                 `gorm:"not null;default:1" json:"interval"`
+	DayOfMonth     int                 `json:"day_of_month"` // 1 a 31; em meses mais curtos usa o último dia
+	Weekday        int                 `json:"weekday"`      // 0 (domingo) a 6 (sábado)
+	StartDate      time.Time           `gorm:"type:date;not null" json:"start_date"`
+	EndDate        *time.Time          `gorm:"type:date" json:"end_date"`
+	NextDate       *time.Time          `gorm:"type:date" json:"next_date"` // Próxima ocorrência a lançar; nula quando encerrado
+	LastOccurrence *time.Time          `gorm:"type:date" json:"last_occurrence"`
+	Active         bool                `gorm:"not null;default:true" json:"active"`
+	UserID         uuid.UUID           `gorm:"type:uuid;not null" json:"user_id"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
+
+func (rt *RecurringTransaction) BeforeCreate(tx *gorm.DB) error {
+	if rt.ID == uuid.Nil {
+		rt.ID = uuid.New()
+	}
+	return nil
+}
+
+// Day normaliza um instante para o dia do calendário à meia-noite UTC
+func Day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// NextOccurrence retorna a primeira ocorrência no dia from ou depois dele,
+// ou nil se ela cair depois da data final
+func (rt *RecurringTransaction) NextOccurrence(from time.Time) *time.Time {
+	start := Day(rt.StartDate)
+	from = Day(from)
+	if from.Before(start) {
+		from = start
+	}
+	interval := rt.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	var next time.Time
+	switch rt.Frequency {
+	case RecurrenceMonthly:
+		// Cada ocorrência é calculada a partir do mês inicial, para que o dia 31 não vire 28 após fevereiro
+		months := (from.Year()-start.Year())*12 + int(from.Month()-start.Month())
+		k := months / interval
+		if k > 0 {
+			k--
+		}
+		for {
+			next = monthDay(start.Year(), start.Month()+time.Month(k*interval), rt.DayOfMonth)
+			if !next.Before(from) {
+				break
+			}
+			k++
+		}
+	case RecurrenceWeekly:
+		first := start.AddDate(0, 0, (rt.Weekday-int(start.Weekday())+7)%7)
+		next = stepDays(first, from, 7*interval)
+	default:
+		next = stepDays(start, from, interval)
+	}
+
+	if rt.EndDate != nil && next.After(Day(*rt.EndDate)) {
+		return nil
+	}
+	return &next
+}
+
+// monthDay retorna o dia do mês, limitado ao último dia do mês
+func monthDay(year int, month time.Month, day int) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	if day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// stepDays retorna a primeira data first + k*step que não é anterior a from
+func stepDays(first, from time.Time, step int) time.Time {
+	if !from.After(first) {
+		return first
+	}
+	days := int(from.Sub(first).Hours() / 24)
+	k := (days + step - 1) / step
+	return first.AddDate(0, 0, k*step)
+}
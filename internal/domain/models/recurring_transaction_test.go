@@ -0,0 +1,167 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestRecurringTransactionNextOccurrence(t *testing.T) {
+	tests := []struct {
+		name     string
+		template RecurringTransaction
+		from     time.Time
+		want     string // Vazio quando não há próxima ocorrência
+	}{
+		// Mensal
+		{
+			name:     "day 31 falls back to the last day of february",
+			template: RecurringTransaction{Frequency: RecurrenceMonthly, Interval: 1, DayOfMonth: 31, StartDate: date("2026-01-31")},
+			from:     date("2026-02-01"),
+			want:     "2026-02-28",
+		},
+		{
+			name:     "day 31 returns to 31 after february",
+			template: RecurringTransaction{Frequency: RecurrenceMonthly, Interval: 1, DayOfMonth: 31, StartDate: date("2026-01-31")},
+			from:     date("2026-03-01"),
+			want:     "2026-03-31",
+		},
+		{
+			name:     "day 31 in a thirty day month",
+			template: RecurringTransaction{Frequency: RecurrenceMonthly, Interval: 1, DayOfMonth: 31, StartDate: date("2026-01-31")},
+			from:     date("2026-04-01"),
+			want:     "2026-04-30",
+		},
+		{
+			name:     "leap year february",
+			template: RecurringTransaction{Frequency: RecurrenceMonthly, Interval: 1, DayOfMonth: 30, StartDate: date("2027-12-30")},
+			from:     date("2028-02-01"),
+			want:     "2028-02-29",
+		},
+		{
+			name:     "from is inclusive",
+			template: RecurringTransaction{Frequency: RecurrenceMonthly, Interval: 1, DayOfMonth: 31, StartDate: date("2026-01-31")},
+			from:     date("2026-02-28"),
+			want:     "2026-02-28",
+		},
+		{
+			name:     "every three months",
+			template: RecurringTransaction{Frequency: RecurrenceMonthly, Interval: 3, DayOfMonth: 15, StartDate: date("2026-01-15")},
+			from:     date("2026-02-01"),
+			want:     "2026-04-15",
+		},
+		{
+			name:     "every three months after the occurrence",
+			template: RecurringTransaction{Frequency: RecurrenceMonthly, Interval: 3, DayOfMonth: 15, StartDate: date("2026-01-15")},
+			from:     date("2026-04-16"),
+			want:     "2026-07-15",
+		},
+		{
+			name:     "day before the start day moves to the next month",
+			template: RecurringTransaction{Frequency: RecurrenceMonthly, Interval: 1, DayOfMonth: 10, StartDate: date("2026-01-20")},
+			from:     date("2026-01-01"),
+			want:     "2026-02-10",
+		},
+		{
+			name:     "crosses the year",
+			template: RecurringTransaction{Frequency: RecurrenceMonthly, Interval: 1, DayOfMonth: 30, StartDate: date("2026-11-30")},
+			from:     date("2027-01-31"),
+			want:     "2027-02-28",
+		},
+
+		// Semanal
+		{
+			name:     "first weekday after the start",
+			template: RecurringTransaction{Frequency: RecurrenceWeekly, Interval: 2, Weekday: int(time.Monday), StartDate: date("2026-10-14")},
+			from:     date("2026-10-14"),
+			want:     "2026-10-19",
+		},
+		{
+			name:     "every two weeks skips the off week",
+			template: RecurringTransaction{Frequency: RecurrenceWeekly, Interval: 2, Weekday: int(time.Monday), StartDate: date("2026-10-14")},
+			from:     date("2026-10-20"),
+			want:     "2026-11-02",
+		},
+		{
+			name:     "weekday on the occurrence",
+			template: RecurringTransaction{Frequency: RecurrenceWeekly, Interval: 2, Weekday: int(time.Monday), StartDate: date("2026-10-14")},
+			from:     date("2026-11-02"),
+			want:     "2026-11-02",
+		},
+		{
+			name:     "start on the weekday",
+			template: RecurringTransaction{Frequency: RecurrenceWeekly, Interval: 1, Weekday: int(time.Wednesday), StartDate: date("2026-10-14")},
+			from:     date("2026-10-01"),
+			want:     "2026-10-14",
+		},
+		{
+			name:     "saturday",
+			template: RecurringTransaction{Frequency: RecurrenceWeekly, Interval: 1, Weekday: int(time.Saturday), StartDate: date("2026-10-14")},
+			from:     date("2026-10-14"),
+			want:     "2026-10-17",
+		},
+
+		// Personalizado
+		{
+			name:     "every ten days on the occurrence",
+			template: RecurringTransaction{Frequency: RecurrenceCustom, Interval: 10, StartDate: date("2026-01-01")},
+			from:     date("2026-01-11"),
+			want:     "2026-01-11",
+		},
+		{
+			name:     "every ten days after the occurrence",
+			template: RecurringTransaction{Frequency: RecurrenceCustom, Interval: 10, StartDate: date("2026-01-01")},
+			from:     date("2026-01-12"),
+			want:     "2026-01-21",
+		},
+		{
+			name:     "interval zero is daily",
+			template: RecurringTransaction{Frequency: RecurrenceCustom, Interval: 0, StartDate: date("2026-01-01")},
+			from:     date("2026-03-05"),
+			want:     "2026-03-05",
+		},
+		{
+			name:     "time of day and zone are ignored",
+			template: RecurringTransaction{Frequency: RecurrenceCustom, Interval: 1, StartDate: date("2026-01-01")},
+			from:     time.Date(2026, 2, 1, 23, 30, 0, 0, time.FixedZone("BRT", -3*60*60)),
+			want:     "2026-02-01",
+		},
+
+		// Data final
+		{
+			name:     "occurrence on the end date",
+			template: RecurringTransaction{Frequency: RecurrenceMonthly, Interval: 1, DayOfMonth: 5, StartDate: date("2026-01-05"), EndDate: timePtr(date("2026-03-05"))},
+			from:     date("2026-02-06"),
+			want:     "2026-03-05",
+		},
+		{
+			name:     "occurrence after the end date",
+			template: RecurringTransaction{Frequency: RecurrenceMonthly, Interval: 1, DayOfMonth: 5, StartDate: date("2026-01-05"), EndDate: timePtr(date("2026-03-04"))},
+			from:     date("2026-02-06"),
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		got := tt.template.NextOccurrence(tt.from)
+		switch {
+		case tt.want == "" && got != nil:
+			t.Errorf("%s: NextOccurrence(%s) = %s, want nil", tt.name, tt.from.Format("2006-01-02"), got.Format("2006-01-02"))
+		case tt.want != "" && got == nil:
+			t.Errorf("%s: NextOccurrence(%s) = nil, want %s", tt.name, tt.from.Format("2006-01-02"), tt.want)
+		case tt.want != "" && !got.Equal(date(tt.want)):
+			t.Errorf("%s: NextOccurrence(%s) = %s, want %s", tt.name, tt.from.Format("2006-01-02"), got.Format("2006-01-02"), tt.want)
+		}
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
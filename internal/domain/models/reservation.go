@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ReservationStatus string
+
+const (
+	ReservationStatusBooked    ReservationStatus = "booked"    // Aguardando o cliente
+	ReservationStatusSeated    ReservationStatus = "seated"    // Cliente chegou e foi acomodado
+	ReservationStatusCompleted ReservationStatus = "completed" // Horário encerrado após o cliente ser acomodado
+	ReservationStatusCancelled ReservationStatus = "cancelled"
+	ReservationStatusNoShow    ReservationStatus = "no_show" // Cliente não apareceu dentro da tolerância
+)
+
+// Reservation representa uma reserva de uma ou mais mesas para um horário
+type Reservation struct {
+	ID              uuid.UUID         `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RestaurantID    uuid.UUID         `gorm:"type:uuid;not null" json:"restaurant_id"`
+	CustomerName    string            `gorm:"size:100;not null" json:"customer_name"`
+	CustomerPhone   string            `gorm:"size:20;not null" json:"customer_phone"`
+	PartySize       int               `gorm:"not null" json:"party_size"`
+	StartsAt        time.Time         `gorm:"not null" json:"starts_at"`
+	DurationMinutes int               `gorm:"not null" json:"duration_minutes"`
+	EndsAt          time.Time         `gorm:"not null" json:"ends_at"` // Calculado a partir de StartsAt e DurationMinutes
+	Status          ReservationStatus `gorm:"size:20;not null;default:'booked'" json:"status"`
+	Notes           string            `gorm:"size:255" json:"notes"`
+	Tables          []Table           `gorm:"many2many:reservation_tables" json:"tables"`
+	UserID          uuid.UUID         `gorm:"type:uuid;not null" json:"user_id"` // Quem registrou a reserva
+	SeatedAt        *time.Time        `json:"seated_at"`
+	CancelledAt     *time.Time        `json:"cancelled_at"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// Active indica se a reserva ainda ocupa as mesas no horário marcado
+func (r *Reservation) Active() bool {
+	return r.Status == ReservationStatusBooked || r.Status == ReservationStatusSeated
+}
+
+// TableIDs retorna os IDs das mesas da reserva
+func (r *Reservation) TableIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(r.Tables))
+	for _, table := range r.Tables {
+		ids = append(ids, table.ID)
+	}
+	return ids
+}
+
+func (r *Reservation) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
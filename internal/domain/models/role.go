@@ -0,0 +1,142 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Permission é uma ação que pode ser concedida por um papel, no formato recurso.ação
+type Permission string
+
+const (
+	PermissionUsersWrite        Permission = "users.write"        // Cadastrar usuários, definir papéis e encerrar sessões
+	PermissionRolesWrite        Permission = "roles.write"        // Criar e alterar papéis
+	PermissionOrdersCancel      Permission = "orders.cancel"      // Cancelar pedidos
+	PermissionPaymentsVoid      Permission = "payments.void"      // Estornar pagamentos
+	PermissionCashManage        Permission = "cash.manage"        // Abrir e fechar o caixa e lançar suprimentos e sangrias
+	PermissionTablesWrite       Permission = "tables.write"       // Cadastrar e alterar mesas
+	PermissionTablesDelete      Permission = "tables.delete"      // Excluir mesas
+	PermissionFloorPlanWrite    Permission = "floor_plan.write"   // Alterar a planta do salão e os ambientes
+	PermissionReservationsWrite Permission = "reservations.write" // Gerir as reservas e a fila de espera
+	PermissionProductsWrite     Permission = "products.write"     // Cadastrar produtos, complementos e estoque de produtos
+	PermissionProductsDelete    Permission = "products.delete"    // Excluir produtos
+	PermissionKitchenWrite      Permission = "kitchen.write"      // Configurar praças e roteamento da cozinha
+	PermissionKitchenBump       Permission = "kitchen.bump"       // Iniciar, finalizar e reabrir comandas
+	PermissionInventoryWrite    Permission = "inventory.write"    // Cadastrar insumos, ajustar estoque e fichas técnicas
+	PermissionPurchasingRead    Permission = "purchasing.read"    // Consultar fornecedores e pedidos de compra
+	PermissionPurchasingWrite   Permission = "purchasing.write"   // Gerir fornecedores e pedidos de compra
+	PermissionFinanceRead       Permission = "finance.read"       // Consultar lançamentos, contas e relatórios
+	PermissionFinanceWrite      Permission = "finance.write"      // Lançar transações, recorrências e contas
+	PermissionFinanceDelete     Permission = "finance.delete"     // Excluir transações
+	PermissionSettingsWrite     Permission = "settings.write"     // Alterar configurações do restaurante
+	PermissionDevicesWrite      Permission = "devices.write"      // Cadastrar e configurar os terminais compartilhados
+)
+
+// AllPermissions é o catálogo das permissões, na ordem em que são exibidas
+var AllPermissions = []Permission{
+	PermissionUsersWrite,
+	PermissionRolesWrite,
+	PermissionOrdersCancel,
+	PermissionPaymentsVoid,
+	PermissionCashManage,
+	PermissionTablesWrite,
+	PermissionTablesDelete,
+	PermissionFloorPlanWrite,
+	PermissionReservationsWrite,
+	PermissionProductsWrite,
+	PermissionProductsDelete,
+	PermissionKitchenWrite,
+	PermissionKitchenBump,
+	PermissionInventoryWrite,
+	PermissionPurchasingRead,
+	PermissionPurchasingWrite,
+	PermissionFinanceRead,
+	PermissionFinanceWrite,
+	PermissionFinanceDelete,
+	PermissionSettingsWrite,
+	PermissionDevicesWrite,
+}
+
+func (p Permission) Valid() bool {
+	for _, permission := range AllPermissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultPermissions são as permissões dos papéis padrão, usadas enquanto o restaurante
+// não personalizar o papel do tipo de usuário
+func DefaultPermissions(userType UserType) []Permission {
+	switch userType {
+	case UserTypeSuperAdmin, UserTypeAdmin:
+		return append([]Permission(nil), AllPermissions...)
+	case UserTypeManager:
+		var permissions []Permission
+		for _, permission := range AllPermissions {
+			switch permission {
+			case PermissionRolesWrite, PermissionTablesDelete, PermissionProductsDelete, PermissionFinanceDelete:
+				continue
+			}
+			permissions = append(permissions, permission)
+		}
+		return permissions
+	case UserTypeStaff:
+		return []Permission{PermissionOrdersCancel, PermissionReservationsWrite, PermissionKitchenBump}
+	}
+	return nil
+}
+
+// Role agrupa permissões e é atribuído aos usuários do restaurante. Os papéis padrão têm
+// UserType preenchido e valem para os usuários desse tipo sem papel próprio.
+type Role struct {
+	ID           uuid.UUID        `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RestaurantID uuid.UUID        `gorm:"type:uuid;not null" json:"restaurant_id"`
+	Name         string           `gorm:"size:50;not null" json:"name"`
+	Description  string           `gorm:"size:255" json:"description"`
+	UserType     *UserType        `gorm:"size:20" json:"user_type"`
+	Permissions  []Permission     `gorm:"-" json:"permissions"`
+	Grants       []RolePermission `gorm:"foreignKey:RoleID" json:"-"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+}
+
+// RolePermission é uma permissão concedida por um papel
+type RolePermission struct {
+	RoleID     uuid.UUID  `gorm:"primaryKey;type:uuid" json:"role_id"`
+	Permission Permission `gorm:"primaryKey;size:50" json:"permission"`
+}
+
+// BuiltIn indica se é um dos papéis padrão, que não podem ser excluídos
+func (r *Role) BuiltIn() bool {
+	return r.UserType != nil
+}
+
+// Has informa se o papel concede a permissão
+func (r *Role) Has(permission Permission) bool {
+	for _, p := range r.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Role) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// AfterFind expõe as permissões carregadas em Grants
+func (r *Role) AfterFind(tx *gorm.DB) error {
+	r.Permissions = make([]Permission, 0, len(r.Grants))
+	for _, grant := range r.Grants {
+		r.Permissions = append(r.Permissions, grant.Permission)
+	}
+	return nil
+}
@@ -16,6 +16,23 @@ const (
 	TableStatusReserved TableStatus = "reserved"
 )
 
+// TableShape define como a mesa é desenhada na planta do salão
+type TableShape string
+
+const (
+	TableShapeSquare    TableShape = "square"
+	TableShapeRound     TableShape = "round"
+	TableShapeRectangle TableShape = "rectangle"
+)
+
+func (s TableShape) Valid() bool {
+	switch s {
+	case TableShapeSquare, TableShapeRound, TableShapeRectangle:
+		return true
+	}
+	return false
+}
+
 type Table struct {
 	ID             uuid.UUID   `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
 	RestaurantID   uuid.UUID   `json:"restaurant_id" gorm:"type:uuid;not null"`
@@ -24,8 +41,36 @@ type Table struct {
 	Capacity       int         `gorm:"not null" json:"capacity"`
 	Status         TableStatus `gorm:"size:20;not null;default:'free'" json:"status"`
 	CurrentOrderID *uuid.UUID  `json:"current_order_id" gorm:"type:uuid"`
-	CreatedAt      time.Time   `json:"created_at"`
-	UpdatedAt      time.Time   `json:"updated_at"`
+	AreaID         *uuid.UUID  `json:"area_id" gorm:"type:uuid"`
+	// Posição e formato na planta do salão, nas unidades definidas pela interface
+	Shape    TableShape `gorm:"size:20;not null;default:'square'" json:"shape"`
+	PosX     float64    `gorm:"not null;default:0" json:"pos_x"`
+	PosY     float64    `gorm:"not null;default:0" json:"pos_y"`
+	Width    float64    `gorm:"not null;default:1" json:"width"`
+	Height   float64    `gorm:"not null;default:1" json:"height"`
+	Rotation float64    `gorm:"not null;default:0" json:"rotation"` // Em graus
+	// MergedIntoID aponta para a mesa principal quando esta mesa foi juntada a outra.
+	// As mesas do grupo compartilham status e pedido atual com a principal.
+	MergedIntoID *uuid.UUID `json:"merged_into_id" gorm:"type:uuid"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// GroupID retorna a mesa principal do grupo de mesas juntadas, ou a própria mesa
+func (t *Table) GroupID() uuid.UUID {
+	if t.MergedIntoID != nil {
+		return *t.MergedIntoID
+	}
+	return t.ID
+}
+
+// GroupCapacities soma a capacidade das mesas de cada grupo de mesas juntadas, indexada pela mesa principal
+func GroupCapacities(tables []Table) map[uuid.UUID]int {
+	capacities := make(map[uuid.UUID]int, len(tables))
+	for _, table := range tables {
+		capacities[table.GroupID()] += table.Capacity
+	}
+	return capacities
 }
 
 // Chave composta para garantir que números de mesa são únicos por restaurante
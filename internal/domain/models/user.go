@@ -17,37 +17,97 @@ const (
 	UserTypeStaff      UserType = "staff"      // Funcionário de um restaurante
 )
 
+func (t UserType) Valid() bool {
+	return t == UserTypeSuperAdmin || t == UserTypeAdmin || t == UserTypeManager || t == UserTypeStaff
+}
+
+// CanManage informa se um usuário deste tipo pode cadastrar ou alterar usuários do tipo informado:
+// o superadmin gerencia todos, o admin gerencia gerentes e funcionários e o gerente só funcionários
+func (t UserType) CanManage(other UserType) bool {
+	switch t {
+	case UserTypeSuperAdmin:
+		return other.Valid()
+	case UserTypeAdmin:
+		return other == UserTypeManager || other == UserTypeStaff
+	case UserTypeManager:
+		return other == UserTypeStaff
+	}
+	return false
+}
+
 type User struct {
-	ID           uuid.UUID   `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
-	Name         string      `gorm:"size:100;not null" json:"name"`
-	Email        string      `gorm:"size:100;uniqueIndex;not null" json:"email"`
-	Password     string      `gorm:"size:100;not null" json:"-"`
-	Type         UserType    `gorm:"size:20;not null;default:'staff'" json:"type"` // superadmin, admin, manager, staff
-	RestaurantID *uuid.UUID  `json:"restaurant_id" gorm:"type:uuid"`
-	Restaurant   *Restaurant `json:"restaurant,omitempty" gorm:"foreignKey:RestaurantID"`
-	CreatedAt    time.Time   `json:"created_at"`
-	UpdatedAt    time.Time   `json:"updated_at"`
+	ID           uuid.UUID    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	Name         string       `gorm:"size:100;not null" json:"name"`
+	Email        string       `gorm:"size:100;uniqueIndex;not null" json:"email"`
+	Password     string       `gorm:"size:100;not null" json:"-"`
+	PIN          string       `gorm:"column:pin;size:100" json:"-"`                 // PIN de 4 a 6 dígitos para entrar nos terminais do salão
+	Type         UserType     `gorm:"size:20;not null;default:'staff'" json:"type"` // superadmin, admin, manager, staff
+	RestaurantID *uuid.UUID   `json:"restaurant_id" gorm:"type:uuid"`               // Restaurante padrão, aberto no login
+	Restaurant   *Restaurant  `json:"restaurant,omitempty" gorm:"foreignKey:RestaurantID"`
+	Memberships  []Membership `json:"-" gorm:"foreignKey:UserID"`
+	// Tentativas de PIN erradas seguidas; ao atingir o limite o PIN fica bloqueado até PINLockedUntil
+	PINFailedAttempts int        `gorm:"column:pin_failed_attempts;not null;default:0" json:"-"`
+	PINLockedUntil    *time.Time `gorm:"column:pin_locked_until" json:"-"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
 }
 
-// BeforeSave - Hook para hashear a senha antes de salvar
+// BeforeSave - Hook para hashear a senha e o PIN antes de salvar
 func (u *User) BeforeSave(tx *gorm.DB) error {
-	if u.Password == "" {
-		return nil
+	hashedPassword, err := hashSecret(u.Password)
+	if err != nil {
+		return err
 	}
+	u.Password = hashedPassword
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(u.Password), bcrypt.DefaultCost)
+	hashedPIN, err := hashSecret(u.PIN)
 	if err != nil {
 		return err
 	}
-	u.Password = string(hashedPassword)
+	u.PIN = hashedPIN
 	return nil
 }
 
+// hashSecret gera o hash bcrypt do valor. Valores que já são hash são mantidos, para que salvar
+// um usuário carregado do banco não gere o hash do hash.
+func hashSecret(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if _, err := bcrypt.Cost([]byte(value)); err == nil {
+		return value, nil
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(value), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
+
 // CheckPassword verifica se a senha corresponde ao hash
 func (u *User) CheckPassword(password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
 }
 
+// HasPIN informa se o usuário cadastrou um PIN
+func (u *User) HasPIN() bool {
+	return u.PIN != ""
+}
+
+// CheckPIN verifica se o PIN corresponde ao hash
+func (u *User) CheckPIN(pin string) error {
+	if u.PIN == "" {
+		return bcrypt.ErrMismatchedHashAndPassword
+	}
+	return bcrypt.CompareHashAndPassword([]byte(u.PIN), []byte(pin))
+}
+
+// PINLocked informa se o PIN está bloqueado por excesso de tentativas erradas
+func (u *User) PINLocked(now time.Time) bool {
+	return u.PINLockedUntil != nil && now.Before(*u.PINLockedUntil)
+}
+
 // IsSuperAdmin verifica se o usuário é superadmin
 func (u *User) IsSuperAdmin() bool {
 	return u.Type == UserTypeSuperAdmin
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WaitlistStatus string
+
+const (
+	WaitlistStatusWaiting  WaitlistStatus = "waiting"
+	WaitlistStatusNotified WaitlistStatus = "notified" // Cliente avisado de que a mesa está pronta
+	WaitlistStatusSeated   WaitlistStatus = "seated"
+	WaitlistStatusDropped  WaitlistStatus = "dropped" // Cliente desistiu ou não respondeu ao aviso
+)
+
+// WaitlistEntry é um grupo sem reserva aguardando mesa
+type WaitlistEntry struct {
+	ID                uuid.UUID      `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RestaurantID      uuid.UUID      `gorm:"type:uuid;not null" json:"restaurant_id"`
+	CustomerName      string         `gorm:"size:100;not null" json:"customer_name"`
+	CustomerPhone     string         `gorm:"size:20;not null" json:"customer_phone"`
+	PartySize         int            `gorm:"not null" json:"party_size"`
+	QuotedWaitMinutes int            `gorm:"not null;default:0" json:"quoted_wait_minutes"` // Espera informada ao cliente na chegada
+	Status            WaitlistStatus `gorm:"size:20;not null;default:'waiting'" json:"status"`
+	Notes             string         `gorm:"size:255" json:"notes"`
+	TableID           *uuid.UUID     `gorm:"type:uuid" json:"table_id"` // Mesa em que o grupo foi acomodado
+	OrderID           *uuid.UUID     `gorm:"type:uuid" json:"order_id"` // Pedido aberto ao acomodar, se houver
+	UserID            uuid.UUID      `gorm:"type:uuid;not null" json:"user_id"`
+	NotifiedAt        *time.Time     `json:"notified_at"`
+	SeatedAt          *time.Time     `json:"seated_at"`
+	DroppedAt         *time.Time     `json:"dropped_at"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+}
+
+// Active indica se o grupo ainda está na fila
+func (w *WaitlistEntry) Active() bool {
+	return w.Status == WaitlistStatusWaiting || w.Status == WaitlistStatusNotified
+}
+
+func (w *WaitlistEntry) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return nil
+}
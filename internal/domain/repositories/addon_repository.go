@@ -15,9 +15,10 @@ type AddonRepository interface {
 	DeleteAddon(restaurant_id, id uuid.UUID) error
 
 	// Option operations
+	// As opções são sempre acessadas através do complemento, que já foi validado contra o restaurante
 	CreateOption(option *models.Option) error
-	GetOptionByID(id uuid.UUID) (*models.Option, error)
+	GetOptionByID(addonID, id uuid.UUID) (*models.Option, error)
 	GetOptionsByAddonID(addonID uuid.UUID) ([]models.Option, error)
 	UpdateOption(option *models.Option) error
-	DeleteOption(id uuid.UUID) error
+	DeleteOption(addonID, id uuid.UUID) error
 }
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+type AuthRepository interface {
+	// WithTx retorna uma cópia do repositório que opera dentro da transação informada
+	WithTx(tx Transaction) AuthRepository
+
+	CreateRefreshToken(token *models.RefreshToken) error
+	// FindRefreshTokenForUpdate localiza o token pelo hash e o bloqueia até o fim da transação
+	FindRefreshTokenForUpdate(hash string) (*models.RefreshToken, error)
+	UpdateRefreshToken(token *models.RefreshToken) error
+	// RevokeFamily revoga os tokens ainda não revogados da família e os retorna
+	RevokeFamily(familyID uuid.UUID, at time.Time) ([]models.RefreshToken, error)
+	// RevokeUser revoga os tokens ainda não revogados de todas as sessões do usuário e os retorna
+	RevokeUser(userID uuid.UUID, at time.Time) ([]models.RefreshToken, error)
+	// RevokeUserInRestaurant revoga os tokens ainda não revogados das sessões do usuário abertas no restaurante e os retorna
+	RevokeUserInRestaurant(userID, restaurantID uuid.UUID, at time.Time) ([]models.RefreshToken, error)
+	// DenyTokens inclui os tokens de acesso na lista de revogação; tokens já incluídos são ignorados
+	DenyTokens(tokens []models.RevokedToken) error
+	IsTokenDenied(jti uuid.UUID) (bool, error)
+	// DeleteExpired remove os refresh tokens e as revogações que expiraram antes do instante informado
+	DeleteExpired(before time.Time) error
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// BillFilter restringe a listagem das contas; campos vazios não filtram.
+// O status overdue seleciona as contas em aberto vencidas antes de hoje.
+type BillFilter struct {
+	Kind       models.BillKind
+	Status     models.BillStatus
+	Unpaid     bool // Apenas contas que ainda aceitam baixas, vencidas ou não
+	SupplierID *uuid.UUID
+	DueFrom    *time.Time
+	DueTo      *time.Time
+}
+
+type BillRepository interface {
+	// WithTx retorna uma cópia do repositório que opera dentro da transação informada
+	WithTx(tx Transaction) BillRepository
+
+	Create(bill *models.Bill) error
+	// FindByID carrega a conta com suas baixas e anexos
+	FindByID(restaurantID, id uuid.UUID) (*models.Bill, error)
+	// FindByIDForUpdate bloqueia a conta até o fim da transação
+	FindByIDForUpdate(restaurantID, id uuid.UUID) (*models.Bill, error)
+	// List ordena pelo vencimento
+	List(restaurantID uuid.UUID, filter BillFilter) ([]models.Bill, error)
+	// Update grava apenas os dados da conta
+	Update(bill *models.Bill) error
+	CreateSettlement(settlement *models.BillSettlement) error
+	CreateAttachment(attachment *models.BillAttachment) error
+	DeleteAttachment(billID, id uuid.UUID) error
+}
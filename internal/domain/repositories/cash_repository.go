@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// CashSessionFilter restringe a listagem dos turnos de caixa; campos vazios não filtram
+type CashSessionFilter struct {
+	Register string
+	Status   models.CashSessionStatus
+	From     *time.Time
+	To       *time.Time
+}
+
+// CashTotals resume o que passou pelo caixa durante o turno
+type CashTotals struct {
+	Payments map[models.PaymentMethod]models.Money // Soma dos pagamentos por forma de pagamento
+	CashIn   models.Money
+	CashOut  models.Money
+}
+
+type CashRepository interface {
+	// WithTx retorna uma cópia do repositório que opera dentro da transação informada
+	WithTx(tx Transaction) CashRepository
+
+	CreateSession(session *models.CashSession) error
+	// FindSessionByID carrega o turno com suas movimentações e conferências
+	FindSessionByID(restaurantID, id uuid.UUID) (*models.CashSession, error)
+	// FindSessionByIDForUpdate bloqueia o turno até o fim da transação
+	FindSessionByIDForUpdate(restaurantID, id uuid.UUID) (*models.CashSession, error)
+	// FindOpenSessions retorna os turnos abertos do restaurante
+	FindOpenSessions(restaurantID uuid.UUID) ([]models.CashSession, error)
+	ListSessions(restaurantID uuid.UUID, filter CashSessionFilter) ([]models.CashSession, error)
+	// UpdateSession grava apenas os dados do turno
+	UpdateSession(session *models.CashSession) error
+
+	CreateMovement(movement *models.CashMovement) error
+	CreateCounts(counts []models.CashSessionCount) error
+	// Totals soma os pagamentos e as movimentações atribuídos ao turno
+	Totals(sessionID uuid.UUID) (*CashTotals, error)
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+type DeviceRepository interface {
+	// WithTx retorna uma cópia do repositório que opera dentro da transação informada
+	WithTx(tx Transaction) DeviceRepository
+
+	Create(device *models.Device) error
+	FindByID(restaurantID, id uuid.UUID) (*models.Device, error)
+	// FindByIDForUpdate localiza o terminal e o bloqueia até o fim da transação
+	FindByIDForUpdate(restaurantID, id uuid.UUID) (*models.Device, error)
+	// FindByToken localiza o terminal pareado pelo hash do token
+	FindByToken(hash string) (*models.Device, error)
+	// FindByPairingSelector localiza o terminal pelo seletor do código de pareamento
+	FindByPairingSelector(selector string) (*models.Device, error)
+	// FindByPairingSelectorForUpdate faz o mesmo que FindByPairingSelector bloqueando o terminal até o fim da transação
+	FindByPairingSelectorForUpdate(selector string) (*models.Device, error)
+	List(restaurantID uuid.UUID) ([]models.Device, error)
+	Update(device *models.Device) error
+	// Seen registra o último acesso do terminal com a sua credencial
+	Seen(id uuid.UUID, at time.Time, ip string) error
+	// Touch registra a última atividade do funcionário conectado ao terminal
+	Touch(id uuid.UUID, at time.Time) error
+	// EndSession bloqueia o terminal se o último desbloqueio ainda for o do token informado
+	EndSession(id, jti uuid.UUID) error
+	// EndUserSessions bloqueia os terminais em que o usuário está conectado
+	EndUserSessions(userID uuid.UUID) error
+	// EndUserSessionsInRestaurant bloqueia os terminais do restaurante em que o usuário está conectado
+	EndUserSessionsInRestaurant(userID, restaurantID uuid.UUID) error
+	Delete(restaurantID, id uuid.UUID) error
+}
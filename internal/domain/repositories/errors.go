@@ -0,0 +1,48 @@
+package repositories
+
+import "errors"
+
+// Erros retornados quando o registro não existe ou não pertence ao restaurante. Compare-os com
+// errors.Is; o texto das mensagens pode mudar.
+var (
+	ErrProductNotFound = errors.New("product not found")
+	ErrAddonNotFound   = errors.New("addon not found")
+	ErrOptionNotFound  = errors.New("option not found")
+
+	ErrTransactionNotFound = errors.New("transaction not found")
+
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrStationNotFound  = errors.New("station not found")
+	ErrTicketNotFound   = errors.New("ticket not found")
+
+	ErrReservationNotFound = errors.New("reservation not found")
+	ErrTableNotFound       = errors.New("table not found")
+
+	ErrWaitlistEntryNotFound = errors.New("waitlist entry not found")
+
+	ErrAreaNotFound = errors.New("area not found")
+
+	ErrOrderNotFound     = errors.New("order not found")
+	ErrOrderItemNotFound = errors.New("item not found")
+
+	ErrIngredientNotFound = errors.New("ingredient not found")
+
+	ErrSupplierNotFound      = errors.New("supplier not found")
+	ErrPurchaseOrderNotFound = errors.New("purchase order not found")
+
+	ErrCashSessionNotFound = errors.New("cash session not found")
+	ErrPaymentNotFound     = errors.New("payment not found")
+
+	ErrRecurringTransactionNotFound = errors.New("recurring transaction not found")
+
+	ErrBillNotFound           = errors.New("bill not found")
+	ErrBillAttachmentNotFound = errors.New("bill attachment not found")
+
+	ErrUserNotFound         = errors.New("user not found")
+	ErrMembershipNotFound   = errors.New("membership not found")
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+
+	ErrRoleNotFound = errors.New("role not found")
+
+	ErrDeviceNotFound = errors.New("device not found")
+)
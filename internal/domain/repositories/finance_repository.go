@@ -9,14 +9,19 @@ import (
 )
 
 type FinanceRepository interface {
+	// WithTx retorna uma cópia do repositório que opera dentro da transação informada
+	WithTx(tx Transaction) FinanceRepository
+
 	Create(transaction *models.FinancialTransaction) error
 	FindByID(restaurantID, id uuid.UUID) (*models.FinancialTransaction, error)
 	Update(transaction *models.FinancialTransaction) error
 	Delete(restaurantID, id uuid.UUID) error
+	// FindSource informa qual registro gerou a transação; TransactionSourceManual quando nenhum
+	FindSource(restaurantID, id uuid.UUID) (models.TransactionSource, error)
 	List(restaurantID uuid.UUID) ([]models.FinancialTransaction, error)
 	FindByType(restaurantID uuid.UUID, transactionType models.TransactionType) ([]models.FinancialTransaction, error)
 	FindByDateRange(restaurantID uuid.UUID, startDate, endDate time.Time) ([]models.FinancialTransaction, error)
 	FindByOrder(restaurantID, orderID uuid.UUID) ([]models.FinancialTransaction, error)
-	GetDailySummary(restaurantID uuid.UUID, date time.Time) (float64, float64, error) // Retorna (receitas, despesas)
-	GetMonthlySummary(restaurantID uuid.UUID, year int, month int) (float64, float64, error)
+	GetDailySummary(restaurantID uuid.UUID, date time.Time) (models.Money, models.Money, error) // Retorna (receitas, despesas)
+	GetMonthlySummary(restaurantID uuid.UUID, year int, month int) (models.Money, models.Money, error)
 }
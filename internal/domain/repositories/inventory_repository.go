@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// StockMovementFilter restringe a listagem das movimentações; campos vazios não filtram
+type StockMovementFilter struct {
+	IngredientID *uuid.UUID
+	OrderID      *uuid.UUID
+	From         *time.Time
+	To           *time.Time
+}
+
+type InventoryRepository interface {
+	// WithTx retorna uma cópia do repositório que opera dentro da transação informada
+	WithTx(tx Transaction) InventoryRepository
+
+	CreateIngredient(ingredient *models.Ingredient) error
+	FindIngredientByID(restaurantID, id uuid.UUID) (*models.Ingredient, error)
+	// ListIngredients retorna os insumos por nome; lowStock limita aos que atingiram o nível de reposição
+	ListIngredients(restaurantID uuid.UUID, lowStock bool) ([]models.Ingredient, error)
+	UpdateIngredient(ingredient *models.Ingredient) error
+	DeleteIngredient(restaurantID, id uuid.UUID) error
+	// IngredientInUse indica se o insumo aparece em alguma ficha técnica
+	IngredientInUse(restaurantID, id uuid.UUID) (bool, error)
+
+	FindProductRecipe(restaurantID, productID uuid.UUID) ([]models.RecipeItem, error)
+	FindOptionRecipe(restaurantID, optionID uuid.UUID) ([]models.RecipeItem, error)
+	// SetProductRecipe substitui a ficha técnica do produto
+	SetProductRecipe(restaurantID, productID uuid.UUID, items []models.RecipeItem) error
+	// SetOptionRecipe substitui a ficha técnica da opção de complemento
+	SetOptionRecipe(restaurantID, optionID uuid.UUID, items []models.RecipeItem) error
+	// FindRecipes retorna de uma vez as fichas técnicas dos produtos e opções informados
+	FindRecipes(restaurantID uuid.UUID, productIDs, optionIDs []uuid.UUID) ([]models.RecipeItem, error)
+
+	// AdjustStock soma as variações ao estoque dos insumos, bloqueando-os em ordem para evitar deadlocks,
+	// e retorna os insumos atualizados
+	AdjustStock(restaurantID uuid.UUID, deltas map[uuid.UUID]models.Quantity) ([]models.Ingredient, error)
+	CreateMovements(movements []models.StockMovement) error
+	ListMovements(restaurantID uuid.UUID, filter StockMovementFilter) ([]models.StockMovement, error)
+
+	// RefreshProductStock recalcula a disponibilidade dos produtos que usam os insumos informados:
+	// o produto fica indisponível quando algum insumo da ficha técnica não cobre uma porção
+	RefreshProductStock(restaurantID uuid.UUID, ingredientIDs []uuid.UUID) error
+	// RefreshProductAvailability faz o mesmo cálculo para os produtos informados
+	RefreshProductAvailability(restaurantID uuid.UUID, productIDs []uuid.UUID) error
+}
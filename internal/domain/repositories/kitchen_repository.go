@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"api-jet-manager/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// KitchenRoute indica para qual praça um produto é enviado
+type KitchenRoute struct {
+	ProductID   uuid.UUID
+	ProductName string
+	StationID   *uuid.UUID
+}
+
+// KitchenTicketFilter restringe a listagem de comandas; campos vazios não filtram
+type KitchenTicketFilter struct {
+	StationID *uuid.UUID
+	OrderID   *uuid.UUID
+	Statuses  []models.TicketStatus
+}
+
+type KitchenRepository interface {
+	// WithTx retorna uma cópia do repositório que opera dentro da transação informada
+	WithTx(tx Transaction) KitchenRepository
+
+	CreateStation(station *models.KitchenStation) error
+	FindStationByID(restaurantID, id uuid.UUID) (*models.KitchenStation, error)
+	ListStations(restaurantID uuid.UUID) ([]models.KitchenStation, error)
+	UpdateStation(station *models.KitchenStation) error
+	// DeleteStation remove a praça, desvinculando produtos, categorias e comandas
+	DeleteStation(restaurantID, id uuid.UUID) error
+
+	SetProductStation(restaurantID, productID uuid.UUID, stationID *uuid.UUID) error
+	SetCategoryStation(restaurantID, categoryID uuid.UUID, stationID *uuid.UUID) error
+	// ResolveRoutes retorna a praça de cada produto: a do produto ou, se vazia, a da categoria
+	ResolveRoutes(restaurantID uuid.UUID, productIDs []uuid.UUID) (map[uuid.UUID]KitchenRoute, error)
+
+	CreateTickets(tickets []models.KitchenTicket) error
+	FindTicketByID(restaurantID, id uuid.UUID) (*models.KitchenTicket, error)
+	FindTicketByIDForUpdate(restaurantID, id uuid.UUID) (*models.KitchenTicket, error)
+	FindTickets(restaurantID uuid.UUID, filter KitchenTicketFilter) ([]models.KitchenTicket, error)
+	UpdateTicket(ticket *models.KitchenTicket) error
+	// DeleteOpenTickets remove as comandas do pedido que ainda não ficaram prontas e as retorna
+	DeleteOpenTickets(restaurantID, orderID uuid.UUID) ([]models.KitchenTicket, error)
+	// DeleteItemTickets remove todas as comandas de um item do pedido e as retorna
+	DeleteItemTickets(restaurantID, itemID uuid.UUID) ([]models.KitchenTicket, error)
+	// MoveItemTickets passa as comandas de um item para o pedido informado e as retorna
+	MoveItemTickets(restaurantID, itemID uuid.UUID, order *models.Order) ([]models.KitchenTicket, error)
+}
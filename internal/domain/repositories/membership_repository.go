@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"api-jet-manager/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+type MembershipRepository interface {
+	// WithTx retorna uma cópia do repositório que opera dentro da transação informada
+	WithTx(tx Transaction) MembershipRepository
+
+	Create(membership *models.Membership) error
+	Find(restaurantID, userID uuid.UUID) (*models.Membership, error)
+	// ListByUser retorna os vínculos do usuário com os dados de cada restaurante
+	ListByUser(userID uuid.UUID) ([]models.Membership, error)
+	// ListByRestaurant retorna os vínculos do restaurante com os dados de cada usuário
+	ListByRestaurant(restaurantID uuid.UUID) ([]models.Membership, error)
+	Update(membership *models.Membership) error
+	Delete(restaurantID, userID uuid.UUID) error
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"api-jet-manager/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+type OrderCodeRepository interface {
+	// FindSettings retorna o formato configurado ou o padrão quando o restaurante não tem configuração
+	FindSettings(restaurantID uuid.UUID) (*models.OrderCodeSettings, error)
+	SaveSettings(settings *models.OrderCodeSettings) error
+	// NextValue incrementa atomicamente a sequência do período e retorna o novo valor
+	NextValue(restaurantID uuid.UUID, period string) (int64, error)
+}
@@ -8,8 +8,13 @@ import (
 )
 
 type OrderRepository interface {
+	// WithTx retorna uma cópia do repositório que opera dentro da transação informada
+	WithTx(tx Transaction) OrderRepository
+
 	Create(order *models.Order) error
 	FindByID(restaurantID, id uuid.UUID) (*models.Order, error)
+	// FindByIDForUpdate bloqueia a linha do pedido até o fim da transação
+	FindByIDForUpdate(restaurantID, id uuid.UUID) (*models.Order, error)
 	Update(order *models.Order) error
 	Delete(restaurantID, id uuid.UUID) error
 	List(restaurantID uuid.UUID) ([]models.Order, error)
@@ -19,9 +24,20 @@ type OrderRepository interface {
 	UpdateStatus(restaurantID, id uuid.UUID, status models.OrderStatus) error
 	AddItem(item *models.OrderItem) error
 	RemoveItem(restaurantID, orderID, itemID uuid.UUID) error
+	// MoveItem transfere o item, com suas opções, para outro pedido do mesmo restaurante
+	MoveItem(restaurantID, itemID, toOrderID uuid.UUID) error
 	UpdateItem(item *models.OrderItem) error
 	FindItems(restaurantID, orderID uuid.UUID) ([]models.OrderItem, error)
+	// RecalculateTotal recalcula o total do pedido a partir dos itens gravados e retorna o novo valor
+	RecalculateTotal(restaurantID, orderID uuid.UUID) (models.Money, error)
 	FindOrdersByDateAndType(restaurantID uuid.UUID, date time.Time, orderType models.OrderType) ([]models.Order, error)
 	FindDeliveryOrdersByDate(restaurantID uuid.UUID, date time.Time) ([]models.Order, error)
 	FindOrdersByDateRangeAndType(restaurantID uuid.UUID, startDate, endDate time.Time, orderType models.OrderType) ([]models.Order, error)
+	// AverageSeatDuration retorna o tempo médio entre a abertura e o pagamento dos pedidos de mesa
+	// pagos desde since, e quantos pedidos entraram na média
+	AverageSeatDuration(restaurantID uuid.UUID, since time.Time) (time.Duration, int64, error)
+
+	// Histórico de status
+	AddStatusTransition(transition *models.OrderStatusTransition) error
+	FindStatusTransitions(restaurantID, orderID uuid.UUID) ([]models.OrderStatusTransition, error)
 }
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"api-jet-manager/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+type PaymentRepository interface {
+	// WithTx retorna uma cópia do repositório que opera dentro da transação informada
+	WithTx(tx Transaction) PaymentRepository
+
+	Create(payment *models.Payment) error
+	FindByID(restaurantID, id uuid.UUID) (*models.Payment, error)
+	FindByOrder(restaurantID, orderID uuid.UUID) ([]models.Payment, error)
+	Delete(restaurantID, id uuid.UUID) error
+	// PaidQuantities retorna, por item do pedido, a quantidade já coberta por pagamentos
+	PaidQuantities(orderID uuid.UUID) (map[uuid.UUID]int, error)
+}
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"api-jet-manager/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// PurchaseOrderFilter restringe a listagem dos pedidos de compra; campos vazios não filtram
+type PurchaseOrderFilter struct {
+	SupplierID *uuid.UUID
+	Status     models.PurchaseOrderStatus
+}
+
+type PurchaseRepository interface {
+	// WithTx retorna uma cópia do repositório que opera dentro da transação informada
+	WithTx(tx Transaction) PurchaseRepository
+
+	CreateSupplier(supplier *models.Supplier) error
+	FindSupplierByID(restaurantID, id uuid.UUID) (*models.Supplier, error)
+	ListSuppliers(restaurantID uuid.UUID, activeOnly bool) ([]models.Supplier, error)
+	UpdateSupplier(supplier *models.Supplier) error
+	DeleteSupplier(restaurantID, id uuid.UUID) error
+	// SupplierInUse indica se o fornecedor tem pedidos de compra
+	SupplierInUse(restaurantID, id uuid.UUID) (bool, error)
+
+	// CreatePurchaseOrder grava o pedido junto com seus itens
+	CreatePurchaseOrder(order *models.PurchaseOrder) error
+	// FindPurchaseOrderByID carrega o pedido com fornecedor, itens e despesas geradas
+	FindPurchaseOrderByID(restaurantID, id uuid.UUID) (*models.PurchaseOrder, error)
+	// FindPurchaseOrderByIDForUpdate bloqueia o pedido até o fim da transação e carrega seus itens
+	FindPurchaseOrderByIDForUpdate(restaurantID, id uuid.UUID) (*models.PurchaseOrder, error)
+	ListPurchaseOrders(restaurantID uuid.UUID, filter PurchaseOrderFilter) ([]models.PurchaseOrder, error)
+	// UpdatePurchaseOrder grava apenas o cabeçalho do pedido
+	UpdatePurchaseOrder(order *models.PurchaseOrder) error
+	// ReplacePurchaseOrderItems substitui todos os itens do pedido
+	ReplacePurchaseOrderItems(order *models.PurchaseOrder) error
+	UpdatePurchaseOrderItem(item *models.PurchaseOrderItem) error
+}
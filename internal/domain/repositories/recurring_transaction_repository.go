@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+type RecurringTransactionRepository interface {
+	// WithTx retorna uma cópia do repositório que opera dentro da transação informada
+	WithTx(tx Transaction) RecurringTransactionRepository
+
+	Create(recurring *models.RecurringTransaction) error
+	FindByID(restaurantID, id uuid.UUID) (*models.RecurringTransaction, error)
+	// FindByIDForUpdate bloqueia o lançamento recorrente até o fim da transação
+	FindByIDForUpdate(restaurantID, id uuid.UUID) (*models.RecurringTransaction, error)
+	List(restaurantID uuid.UUID) ([]models.RecurringTransaction, error)
+	Update(recurring *models.RecurringTransaction) error
+	Delete(restaurantID, id uuid.UUID) error
+	// FindDue retorna os lançamentos ativos de todos os restaurantes com ocorrência até o dia informado,
+	// pulando os que outra instância já está processando
+	FindDue(day time.Time) ([]models.RecurringTransaction, error)
+	// CreateOccurrence grava a transação gerada por uma ocorrência; retorna false se ela já existia
+	CreateOccurrence(transaction *models.FinancialTransaction) (bool, error)
+}
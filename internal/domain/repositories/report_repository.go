@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// CategoryTotal é a soma dos lançamentos de uma categoria no período
+type CategoryTotal struct {
+	Type     models.TransactionType
+	Category models.TransactionCategory
+	Total    models.Money
+	Count    int64
+}
+
+// DailyTotal é a soma das receitas e despesas de um dia
+type DailyTotal struct {
+	Day     time.Time
+	Income  models.Money
+	Expense models.Money
+}
+
+// PaymentMethodTotal é a soma dos lançamentos de uma forma de pagamento no período
+type PaymentMethodTotal struct {
+	Method  string
+	Income  models.Money
+	Expense models.Money
+	Count   int64
+}
+
+// ReportRepository agrega os lançamentos financeiros no banco, sem carregar as linhas.
+// Todos os períodos são semiabertos: [from, to).
+type ReportRepository interface {
+	// WithTx retorna uma cópia do repositório que opera dentro da transação informada
+	WithTx(tx Transaction) ReportRepository
+
+	CategoryTotals(restaurantID uuid.UUID, from, to time.Time) ([]CategoryTotal, error)
+	// DailyTotals retorna um registro por dia do período, inclusive os dias sem lançamentos.
+	// Os dias são contados no fuso de from, que precisa ter um nome IANA.
+	DailyTotals(restaurantID uuid.UUID, from, to time.Time) ([]DailyTotal, error)
+	PaymentMethodTotals(restaurantID uuid.UUID, from, to time.Time) ([]PaymentMethodTotal, error)
+}
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ReservationFilter restringe a listagem de reservas; campos vazios não filtram
+type ReservationFilter struct {
+	From   *time.Time
+	To     *time.Time
+	Status models.ReservationStatus
+}
+
+type ReservationRepository interface {
+	// WithTx retorna uma cópia do repositório que opera dentro da transação informada
+	WithTx(tx Transaction) ReservationRepository
+
+	// Create grava a reserva e o vínculo com suas mesas, sem alterar as mesas
+	Create(reservation *models.Reservation) error
+	Update(reservation *models.Reservation) error
+	// ReplaceTables troca as mesas vinculadas à reserva
+	ReplaceTables(reservation *models.Reservation, tables []models.Table) error
+	FindByID(restaurantID, id uuid.UUID) (*models.Reservation, error)
+	FindByIDForUpdate(restaurantID, id uuid.UUID) (*models.Reservation, error)
+	List(restaurantID uuid.UUID, filter ReservationFilter) ([]models.Reservation, error)
+	// FindOverlapping retorna as reservas ativas que usam alguma das mesas em um horário que cruza [start, end)
+	FindOverlapping(restaurantID uuid.UUID, tableIDs []uuid.UUID, start, end time.Time, excludeID uuid.UUID) ([]models.Reservation, error)
+
+	// Consultas do agendador, de todos os restaurantes. As linhas são bloqueadas com SKIP LOCKED
+	// para que várias instâncias possam rodar o agendador sem processar a mesma reserva.
+	// FindBookedBetween retorna as reservas aguardando o cliente com início em [from, to]
+	FindBookedBetween(from, to time.Time) ([]models.Reservation, error)
+	// FindBookedBefore retorna as reservas aguardando o cliente com início antes de before
+	FindBookedBefore(before time.Time) ([]models.Reservation, error)
+	// FindSeatedEndedBefore retorna as reservas com cliente acomodado cujo horário terminou antes de before
+	FindSeatedEndedBefore(before time.Time) ([]models.Reservation, error)
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"api-jet-manager/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+type RoleRepository interface {
+	// WithTx retorna uma cópia do repositório que opera dentro da transação informada
+	WithTx(tx Transaction) RoleRepository
+
+	// Create grava o papel e as permissões; deve ser chamado dentro de uma transação
+	Create(role *models.Role) error
+	// CreateDefault grava um papel padrão caso o restaurante ainda não tenha o papel do tipo;
+	// retorna false se ele já existia
+	CreateDefault(role *models.Role) (bool, error)
+	FindByID(restaurantID, id uuid.UUID) (*models.Role, error)
+	FindByUserType(restaurantID uuid.UUID, userType models.UserType) (*models.Role, error)
+	List(restaurantID uuid.UUID) ([]models.Role, error)
+	// Update grava o papel e substitui as permissões; deve ser chamado dentro de uma transação
+	Update(role *models.Role) error
+	// Delete exclui o papel; os usuários que o tinham voltam ao papel padrão do seu tipo
+	Delete(restaurantID, id uuid.UUID) error
+}
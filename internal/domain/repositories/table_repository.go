@@ -7,12 +7,43 @@ import (
 )
 
 type TableRepository interface {
+	// WithTx retorna uma cópia do repositório que opera dentro da transação informada
+	WithTx(tx Transaction) TableRepository
+
 	Create(table *models.Table) error
 	FindByID(restauranteID, id uuid.UUID) (*models.Table, error)
+	// FindByIDForUpdate bloqueia a linha da mesa até o fim da transação
+	FindByIDForUpdate(restauranteID, id uuid.UUID) (*models.Table, error)
 	FindByNumber(restauranteID uuid.UUID, number int) (*models.Table, error)
 	Update(table *models.Table) error
 	Delete(restauranteID, id uuid.UUID) error
 	List(restauranteID uuid.UUID) ([]models.Table, error)
 	UpdateStatus(restauranteID, id uuid.UUID, status models.TableStatus) error
 	SetCurrentOrder(restauranteID, id uuid.UUID, orderID *uuid.UUID) error
+	// UpdateLayout grava apenas o ambiente, a posição e o formato da mesa
+	UpdateLayout(table *models.Table) error
+	// Occupy marca a mesa (e as mesas juntadas a ela) como ocupada e a vincula ao pedido em uma única operação
+	Occupy(restauranteID, id uuid.UUID, orderID *uuid.UUID) error
+	// Release libera a mesa (e as mesas juntadas a ela) e remove o vínculo com o pedido atual
+	Release(restauranteID, id uuid.UUID) error
+	// Reserve marca como reservadas as mesas livres informadas e retorna as que mudaram
+	Reserve(restauranteID uuid.UUID, ids []uuid.UUID) ([]models.Table, error)
+	// Unreserve libera as mesas reservadas sem pedido vinculado e retorna as que mudaram
+	Unreserve(restauranteID uuid.UUID, ids []uuid.UUID) ([]models.Table, error)
+
+	// FindGroup retorna as mesas do grupo da mesa informada, com a principal primeiro
+	FindGroup(restauranteID, id uuid.UUID) ([]models.Table, error)
+	// FindGroupForUpdate faz o mesmo que FindGroup bloqueando as mesas até o fim da transação
+	FindGroupForUpdate(restauranteID, id uuid.UUID) ([]models.Table, error)
+	// Merge junta as mesas à principal, copiando o status e o pedido atual dela
+	Merge(restauranteID, primaryID uuid.UUID, memberIDs []uuid.UUID) error
+	// Split separa as mesas do grupo e as libera; o pedido compartilhado fica com a mesa principal
+	Split(restauranteID, primaryID uuid.UUID, memberIDs []uuid.UUID) error
+
+	CreateArea(area *models.DiningArea) error
+	FindAreaByID(restauranteID, id uuid.UUID) (*models.DiningArea, error)
+	ListAreas(restauranteID uuid.UUID) ([]models.DiningArea, error)
+	UpdateArea(area *models.DiningArea) error
+	// DeleteArea remove o ambiente, deixando suas mesas sem ambiente
+	DeleteArea(restauranteID, id uuid.UUID) error
 }
@@ -0,0 +1,16 @@
+package repositories
+
+// Transaction representa uma transação de banco de dados em andamento.
+// É opaca para a camada de domínio: apenas as implementações dos repositórios sabem usá-la.
+type Transaction interface {
+	// AfterCommit registra uma função a ser executada somente depois que a transação for confirmada,
+	// útil para notificar efeitos que não podem ser desfeitos, como eventos em tempo real
+	AfterCommit(fn func())
+}
+
+// UnitOfWork executa um conjunto de operações em repositórios de forma atômica
+type UnitOfWork interface {
+	// Do executa fn dentro de uma transação. Se fn retornar erro ou entrar em pânico,
+	// todas as operações feitas através de repositórios ligados a tx são desfeitas.
+	Do(fn func(tx Transaction) error) error
+}
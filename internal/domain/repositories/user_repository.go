@@ -1,17 +1,28 @@
 package repositories
 
 import (
+	"time"
+
 	"api-jet-manager/internal/domain/models"
 
 	"github.com/google/uuid"
 )
 
+// UserRepository filtra por restaurante através dos vínculos (memberships) do usuário
 type UserRepository interface {
+	// WithTx retorna uma cópia do repositório que opera dentro da transação informada
+	WithTx(tx Transaction) UserRepository
+
 	Create(user *models.User) error
 	FindByID(restaurantID, id uuid.UUID) (*models.User, error)
 	FindByEmail(restaurantID uuid.UUID, email string) (*models.User, error)
 	FindByEmailGlobal(email string) (*models.User, error)
+	FindByIDGlobal(id uuid.UUID) (*models.User, error)
+	// FindByIDForUpdate localiza o usuário e o bloqueia até o fim da transação
+	FindByIDForUpdate(id uuid.UUID) (*models.User, error)
 	Update(user *models.User) error
+	// UpdatePINAttempts grava as tentativas de PIN erradas e o bloqueio, sem passar pelos hooks do usuário
+	UpdatePINAttempts(id uuid.UUID, failedAttempts int, lockedUntil *time.Time) error
 	Delete(restaurantID, id uuid.UUID) error
 	List(restaurantID uuid.UUID) ([]models.User, error)
 	FindByType(restaurantID uuid.UUID, userType models.UserType) ([]models.User, error)
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+type WaitlistRepository interface {
+	// WithTx retorna uma cópia do repositório que opera dentro da transação informada
+	WithTx(tx Transaction) WaitlistRepository
+
+	Create(entry *models.WaitlistEntry) error
+	Update(entry *models.WaitlistEntry) error
+	FindByID(restaurantID, id uuid.UUID) (*models.WaitlistEntry, error)
+	FindByIDForUpdate(restaurantID, id uuid.UUID) (*models.WaitlistEntry, error)
+	// ListActive retorna os grupos ainda na fila, na ordem de chegada
+	ListActive(restaurantID uuid.UUID) ([]models.WaitlistEntry, error)
+	// ListSince retorna todos os grupos que entraram na fila a partir de since, na ordem de chegada
+	ListSince(restaurantID uuid.UUID, since time.Time) ([]models.WaitlistEntry, error)
+}
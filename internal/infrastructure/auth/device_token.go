@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"crypto/rand"
+	"strings"
+)
+
+const (
+	// pairingCodeAlphabet evita caracteres que se confundem na tela, como 0 e O ou 1 e I
+	pairingCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	pairingCodeLength   = 8
+)
+
+// NewDeviceToken gera o token que identifica um terminal e o hash que é guardado no banco.
+// Tem o mesmo formato dos refresh tokens e também só é conhecido pelo terminal.
+func NewDeviceToken() (token, hash string, err error) {
+	return NewRefreshToken()
+}
+
+// HashDeviceToken calcula o hash usado para localizar o terminal no banco
+func HashDeviceToken(token string) string {
+	return HashRefreshToken(token)
+}
+
+// NewPairingCode gera o código digitado no terminal para pareá-lo, no formato XXXX-XXXX. A primeira
+// metade é o seletor, guardado como está para localizar o terminal e contar as tentativas erradas;
+// do código completo é guardado apenas o hash.
+func NewPairingCode() (code, selector, hash string, err error) {
+	buf := make([]byte, pairingCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", "", err
+	}
+
+	chars := make([]byte, pairingCodeLength)
+	for i, b := range buf {
+		chars[i] = pairingCodeAlphabet[int(b)%len(pairingCodeAlphabet)]
+	}
+	code = string(chars[:pairingCodeLength/2]) + "-" + string(chars[pairingCodeLength/2:])
+	return code, PairingSelector(code), HashPairingCode(code), nil
+}
+
+// normalizePairingCode ignora maiúsculas, hífens e espaços
+func normalizePairingCode(code string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+// PairingSelector retorna a primeira metade do código, que localiza o terminal
+func PairingSelector(code string) string {
+	normalized := normalizePairingCode(code)
+	if len(normalized) < pairingCodeLength/2 {
+		return normalized
+	}
+	return normalized[:pairingCodeLength/2]
+}
+
+// HashPairingCode calcula o hash do código completo
+func HashPairingCode(code string) string {
+	return HashRefreshToken(normalizePairingCode(code))
+}
@@ -20,6 +20,8 @@ type JWTClaims struct {
 	Email        string          `json:"email"`
 	UserType     models.UserType `json:"user_type"`
 	RestaurantID *uuid.UUID      `json:"restaurant_id,omitempty"`
+	SessionID    string          `json:"sid,omitempty"` // Família de refresh tokens que emitiu o token
+	DeviceID     string          `json:"did,omitempty"` // Terminal em que o funcionário entrou com o PIN
 	jwt.RegisteredClaims
 }
 
@@ -30,20 +32,41 @@ func NewJWTService(secretKey string, tokenDuration time.Duration) *JWTService {
 	}
 }
 
-func (j *JWTService) GenerateToken(user *models.User) (string, error) {
-	claims := JWTClaims{
-		UserID:       user.ID.String(),
-		Email:        user.Email,
-		UserType:     user.Type,
-		RestaurantID: user.RestaurantID,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.tokenDuration)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+// TokenDuration é a validade dos tokens de acesso
+func (j *JWTService) TokenDuration() time.Duration {
+	return j.tokenDuration
+}
+
+// GenerateToken emite um token de acesso de curta duração ligado à sessão informada.
+// O jti (claims.ID) identifica o token na lista de revogação.
+func (j *JWTService) GenerateToken(user *models.User, sessionID uuid.UUID) (string, *JWTClaims, error) {
+	return j.generate(user, &JWTClaims{SessionID: sessionID.String()}, j.tokenDuration)
+}
+
+// GenerateDeviceToken emite o token de um funcionário que entrou no terminal com o PIN. O token não
+// tem refresh token e só vale enquanto for o último desbloqueio do terminal.
+func (j *JWTService) GenerateDeviceToken(user *models.User, deviceID uuid.UUID, duration time.Duration) (string, *JWTClaims, error) {
+	return j.generate(user, &JWTClaims{DeviceID: deviceID.String()}, duration)
+}
+
+// generate completa as claims com os dados do usuário e assina o token
+func (j *JWTService) generate(user *models.User, claims *JWTClaims, duration time.Duration) (string, *JWTClaims, error) {
+	now := time.Now()
+	claims.UserID = user.ID.String()
+	claims.Email = user.Email
+	claims.UserType = user.Type
+	claims.RestaurantID = user.RestaurantID
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        uuid.NewString(),
+		ExpiresAt: jwt.NewNumericDate(now.Add(duration)),
+		IssuedAt:  jwt.NewNumericDate(now),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(j.secretKey))
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(j.secretKey))
+	if err != nil {
+		return "", nil, err
+	}
+	return token, claims, nil
 }
 
 func (j *JWTService) ValidateToken(tokenString string) (*JWTClaims, error) {
@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// refreshTokenBytes é a quantidade de bytes aleatórios de cada refresh token
+const refreshTokenBytes = 32
+
+// NewRefreshToken gera um refresh token opaco e o hash que é guardado no banco.
+// O token em si só é conhecido pelo cliente.
+func NewRefreshToken() (token, hash string, err error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashRefreshToken(token), nil
+}
+
+// HashRefreshToken calcula o hash usado para localizar o refresh token no banco
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,266 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// migrationLockKey identifica o advisory lock usado para serializar as migrações entre réplicas
+const migrationLockKey int64 = 7_343_209_117
+
+// Migration é uma versão do esquema com os scripts de aplicação e de reversão
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus indica se uma migração já foi aplicada e quando
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// NewMigrator carrega as migrações de source, que deve conter arquivos NN_nome.up.sql e NN_nome.down.sql
+func NewMigrator(db *PostgresDB, source fs.FS) (*Migrator, error) {
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		return nil, fmt.Errorf("falha ao obter conexão SQL: %w", err)
+	}
+
+	migrations, err := loadMigrations(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: sqlDB, migrations: migrations}, nil
+}
+
+func loadMigrations(source fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(source, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, file := range files {
+		name := strings.TrimSuffix(file, ".sql")
+
+		var direction string
+		switch {
+		case strings.HasSuffix(name, ".up"):
+			direction = "up"
+		case strings.HasSuffix(name, ".down"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("arquivo de migração sem direção: %s", file)
+		}
+		name = strings.TrimSuffix(name, "."+direction)
+
+		prefix, title, found := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if !found || err != nil {
+			return nil, fmt.Errorf("nome de migração inválido: %s", file)
+		}
+
+		content, err := fs.ReadFile(source, file)
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: title}
+			byVersion[version] = migration
+		} else if migration.Name != title {
+			return nil, fmt.Errorf("versão %d duplicada: %s e %s", version, migration.Name, title)
+		}
+
+		if direction == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migração %d (%s) sem arquivo up", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// withLock executa fn em uma conexão dedicada que mantém o advisory lock das migrações.
+// Réplicas que sobem ao mesmo tempo esperam aqui até que a primeira termine.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		return fmt.Errorf("falha ao obter lock das migrações: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockKey); err != nil {
+			log.Printf("Falha ao liberar lock das migrações: %v", err)
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    BIGINT PRIMARY KEY,
+			name       VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`); err != nil {
+		return fmt.Errorf("falha ao criar tabela schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// run executa o script e atualiza schema_migrations na mesma transação
+func run(ctx context.Context, conn *sql.Conn, script string, record func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := record(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Up aplica todas as migrações pendentes em ordem e retorna quantas foram aplicadas
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	count := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			log.Printf("Aplicando migração %02d_%s...", migration.Version, migration.Name)
+			err := run(ctx, conn, migration.Up, func(tx *sql.Tx) error {
+				_, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", migration.Version, migration.Name)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("falha na migração %02d_%s: %w", migration.Version, migration.Name, err)
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Down reverte as últimas steps migrações aplicadas e retorna quantas foram revertidas
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	count := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+
+			if migration.Down == "" {
+				return fmt.Errorf("migração %02d_%s não pode ser revertida", migration.Version, migration.Name)
+			}
+
+			log.Printf("Revertendo migração %02d_%s...", migration.Version, migration.Name)
+			err := run(ctx, conn, migration.Down, func(tx *sql.Tx) error {
+				_, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("falha ao reverter %02d_%s: %w", migration.Version, migration.Name, err)
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Status lista todas as migrações conhecidas com a data em que foram aplicadas
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+			if appliedAt, ok := applied[migration.Version]; ok {
+				status.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	return statuses, err
+}
@@ -2,12 +2,13 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
 	"api-jet-manager/internal/config"
-	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/migrations"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -19,8 +20,7 @@ type PostgresDB struct {
 }
 
 func NewPostgresConnection(cfg *config.Config) (*PostgresDB, error) {
-	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		cfg.BLUEPRINT_DB_HOST, cfg.BLUEPRINT_DB_PORT, cfg.BLUEPRINT_DB_USERNAME, cfg.BLUEPRINT_DB_PASSWORD, cfg.BLUEPRINT_DB_DATABASE, cfg.DBSSLMode)
+	dsn := cfg.DatabaseDSN()
 
 	// Tenta conectar com retry
 	var db *gorm.DB
@@ -69,17 +69,20 @@ func (p *PostgresDB) Close() error {
 	return sqlDB.Close()
 }
 
+// RunMigrations aplica as migrações SQL pendentes embutidas no binário
 func RunMigrations(db *PostgresDB) error {
 	log.Println("Running database migrations...")
 
-	// Auto-migrate tabelas
-	return db.AutoMigrate(
-		&models.User{},
-		&models.Table{},
-		&models.Product{},
-		&models.Order{},
-		&models.OrderItem{},
-		&models.Restaurant{},
-		&models.FinancialTransaction{},
-	)
+	migrator, err := NewMigrator(db, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	applied, err := migrator.Up(context.Background())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("%d migration(s) applied", applied)
+	return nil
 }
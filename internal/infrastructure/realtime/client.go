@@ -0,0 +1,194 @@
+package realtime
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// Tempo máximo para escrever uma mensagem no cliente
+	writeWait = 10 * time.Second
+	// Tempo máximo sem receber pong antes de considerar a conexão perdida
+	pongWait = 60 * time.Second
+	// Intervalo dos pings; precisa ser menor que pongWait
+	pingPeriod = (pongWait * 9) / 10
+	// Tamanho máximo das mensagens enviadas pelo cliente (apenas comandos de assinatura)
+	maxMessageSize = 4096
+	// Mensagens pendentes por cliente antes de ele ser desconectado
+	sendBuffer = 256
+)
+
+// ClientMessage é o comando enviado pelo cliente para alterar suas assinaturas
+type ClientMessage struct {
+	Action string  `json:"action"` // subscribe ou unsubscribe
+	Topics []Topic `json:"topics"`
+}
+
+// Client é uma conexão WebSocket autenticada de um usuário de um restaurante
+type Client struct {
+	hub          *Hub
+	conn         *websocket.Conn
+	restaurantID uuid.UUID
+	userID       uuid.UUID
+	expiresAt    time.Time
+
+	send      chan []byte
+	closeOnce sync.Once
+	done      chan struct{}
+
+	mu     sync.RWMutex
+	topics map[Topic]bool
+}
+
+// Serve registra a conexão no hub e inicia as rotinas de leitura e escrita do cliente.
+// A conexão é encerrada quando o token usado para abri-la expira.
+func (h *Hub) Serve(conn *websocket.Conn, restaurantID, userID uuid.UUID, expiresAt time.Time, topics []Topic) {
+	client := &Client{
+		hub:          h,
+		conn:         conn,
+		restaurantID: restaurantID,
+		userID:       userID,
+		expiresAt:    expiresAt,
+		send:         make(chan []byte, sendBuffer),
+		done:         make(chan struct{}),
+		topics:       make(map[Topic]bool),
+	}
+	client.setTopics(true, topics)
+
+	h.register(client)
+
+	go client.writePump()
+	go client.readPump()
+}
+
+func (c *Client) subscribed(topic Topic) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.topics[topic]
+}
+
+func (c *Client) setTopics(subscribe bool, topics []Topic) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, topic := range topics {
+		if !topic.Valid() {
+			continue
+		}
+		if subscribe {
+			c.topics[topic] = true
+		} else {
+			delete(c.topics, topic)
+		}
+	}
+}
+
+func (c *Client) subscriptions() []Topic {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	topics := make([]Topic, 0, len(c.topics))
+	for _, topic := range Topics {
+		if c.topics[topic] {
+			topics = append(topics, topic)
+		}
+	}
+	return topics
+}
+
+// enqueue nunca bloqueia; retorna false se o buffer do cliente estiver cheio
+func (c *Client) enqueue(message []byte) bool {
+	select {
+	case <-c.done:
+		return true
+	case c.send <- message:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *Client) close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		c.hub.unregister(c)
+		c.conn.Close()
+	})
+}
+
+// readPump processa os comandos de assinatura e os pongs do cliente
+func (c *Client) readPump() {
+	defer c.close()
+
+	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		var message ClientMessage
+		if err := c.conn.ReadJSON(&message); err != nil {
+			return
+		}
+
+		switch message.Action {
+		case "subscribe":
+			c.setTopics(true, message.Topics)
+		case "unsubscribe":
+			c.setTopics(false, message.Topics)
+		default:
+			continue
+		}
+
+		c.acknowledge()
+	}
+}
+
+// acknowledge informa ao cliente as assinaturas vigentes
+func (c *Client) acknowledge() {
+	ack, err := json.Marshal(map[string]interface{}{
+		"type":   "subscriptions",
+		"topics": c.subscriptions(),
+	})
+	if err == nil && !c.enqueue(ack) {
+		c.close()
+	}
+}
+
+// writePump é a única rotina que escreve na conexão, como exige o gorilla/websocket
+func (c *Client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	expiry := time.NewTimer(time.Until(c.expiresAt))
+	defer func() {
+		ticker.Stop()
+		expiry.Stop()
+		c.close()
+	}()
+
+	c.acknowledge()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case message := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-expiry.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			c.conn.WriteMessage(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "token expired"))
+			return
+		}
+	}
+}
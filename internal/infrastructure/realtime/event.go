@@ -0,0 +1,63 @@
+package realtime
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Topic agrupa os eventos que uma tela pode assinar
+type Topic string
+
+const (
+	TopicOrders   Topic = "orders"
+	TopicTables   Topic = "tables"
+	TopicKitchen  Topic = "kitchen"
+	TopicDelivery Topic = "delivery"
+)
+
+// Topics lista todos os tópicos disponíveis
+var Topics = []Topic{TopicOrders, TopicTables, TopicKitchen, TopicDelivery}
+
+func (t Topic) Valid() bool {
+	for _, topic := range Topics {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
+
+// Event é o envelope enviado aos clientes. Data carrega o payload já serializado,
+// o que permite repassar eventos vindos de outras instâncias sem decodificá-los.
+type Event struct {
+	ID           uuid.UUID       `json:"id"`
+	Topic        Topic           `json:"topic"`
+	Type         string          `json:"type"`
+	RestaurantID uuid.UUID       `json:"restaurant_id"`
+	Data         json.RawMessage `json:"data"`
+	At           time.Time       `json:"at"`
+}
+
+// NewEvent cria o envelope serializando o payload
+func NewEvent(restaurantID uuid.UUID, topic Topic, eventType string, data interface{}) (Event, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		ID:           uuid.New(),
+		Topic:        topic,
+		Type:         eventType,
+		RestaurantID: restaurantID,
+		Data:         payload,
+		At:           time.Now(),
+	}, nil
+}
+
+// Publisher publica eventos para os clientes conectados do restaurante
+type Publisher interface {
+	Publish(restaurantID uuid.UUID, topic Topic, eventType string, data interface{})
+}
@@ -0,0 +1,104 @@
+package realtime
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Hub mantém os clientes conectados agrupados por restaurante e entrega cada evento
+// apenas aos clientes do mesmo restaurante que assinaram o tópico.
+type Hub struct {
+	mu        sync.RWMutex
+	clients   map[uuid.UUID]map[*Client]struct{}
+	observers map[Topic][]func(Event)
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		clients:   make(map[uuid.UUID]map[*Client]struct{}),
+		observers: make(map[Topic][]func(Event)),
+	}
+}
+
+// Observe registra uma função que recebe todos os eventos do tópico, de qualquer restaurante
+// e de qualquer instância. Serve para alimentar outros transportes, como o SSE da cozinha.
+// O observador não pode bloquear.
+func (h *Hub) Observe(topic Topic, observer func(Event)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.observers[topic] = append(h.observers[topic], observer)
+}
+
+func (h *Hub) register(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	clients, ok := h.clients[client.restaurantID]
+	if !ok {
+		clients = make(map[*Client]struct{})
+		h.clients[client.restaurantID] = clients
+	}
+	clients[client] = struct{}{}
+}
+
+func (h *Hub) unregister(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	clients, ok := h.clients[client.restaurantID]
+	if !ok {
+		return
+	}
+	delete(clients, client)
+	if len(clients) == 0 {
+		delete(h.clients, client.restaurantID)
+	}
+}
+
+// Publish serializa o payload e entrega o evento localmente
+func (h *Hub) Publish(restaurantID uuid.UUID, topic Topic, eventType string, data interface{}) {
+	event, err := NewEvent(restaurantID, topic, eventType, data)
+	if err != nil {
+		log.Printf("Falha ao serializar evento %s: %v", eventType, err)
+		return
+	}
+	h.Deliver(event)
+}
+
+// Deliver entrega um envelope pronto aos clientes interessados.
+// Clientes que não acompanham o volume de eventos são desconectados em vez de bloquear o hub.
+func (h *Hub) Deliver(event Event) {
+	message, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Falha ao serializar evento %s: %v", event.Type, err)
+		return
+	}
+
+	var slow []*Client
+
+	h.mu.RLock()
+	for _, observer := range h.observers[event.Topic] {
+		observer(event)
+	}
+	for client := range h.clients[event.RestaurantID] {
+		if client.subscribed(event.Topic) && !client.enqueue(message) {
+			slow = append(slow, client)
+		}
+	}
+	h.mu.RUnlock()
+
+	// Fechar fora do lock, pois close remove o cliente do hub
+	for _, client := range slow {
+		client.close()
+	}
+}
+
+// ConnectedClients retorna quantos clientes estão conectados ao restaurante
+func (h *Hub) ConnectedClients(restaurantID uuid.UUID) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.clients[restaurantID])
+}
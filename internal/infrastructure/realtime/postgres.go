@@ -0,0 +1,201 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"api-jet-manager/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+)
+
+const (
+	// notifyChannel é o canal do LISTEN/NOTIFY compartilhado por todas as instâncias
+	notifyChannel = "realtime_events"
+	// maxNotifyPayload fica abaixo do limite de 8000 bytes do NOTIFY; eventos maiores passam pelo outbox
+	maxNotifyPayload = 7900
+	// outboxRetention é o tempo que um evento grande fica disponível para as outras instâncias
+	outboxRetention = time.Hour
+	// Intervalos de reconexão do listener
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// notification é o payload do NOTIFY: o evento completo ou a referência a ele no outbox
+type notification struct {
+	Origin   string     `json:"origin"`
+	Event    *Event     `json:"event,omitempty"`
+	OutboxID *uuid.UUID `json:"outbox_id,omitempty"`
+}
+
+// outboxEvent guarda eventos grandes demais para o payload do NOTIFY
+type outboxEvent struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Payload   string    `gorm:"type:jsonb;not null"`
+	CreatedAt time.Time
+}
+
+func (outboxEvent) TableName() string {
+	return "realtime_outbox"
+}
+
+// PostgresPublisher entrega cada evento aos clientes desta instância e o repassa às demais
+// via NOTIFY. Cada instância escuta o canal com Listen e entrega ao seu hub os eventos
+// publicados pelas outras, de modo que réplicas atrás de um balanceador vejam os mesmos eventos.
+type PostgresPublisher struct {
+	db         *gorm.DB
+	hub        *Hub
+	instanceID string
+}
+
+func NewPostgresPublisher(db *database.PostgresDB, hub *Hub) *PostgresPublisher {
+	return &PostgresPublisher{
+		db:         db.DB,
+		hub:        hub,
+		instanceID: uuid.NewString(),
+	}
+}
+
+// Publish entrega o evento localmente e o envia às outras instâncias.
+// Falhas no envio são registradas em log: a operação de negócio já foi confirmada.
+func (p *PostgresPublisher) Publish(restaurantID uuid.UUID, topic Topic, eventType string, data interface{}) {
+	event, err := NewEvent(restaurantID, topic, eventType, data)
+	if err != nil {
+		log.Printf("Falha ao serializar evento %s: %v", eventType, err)
+		return
+	}
+
+	p.hub.Deliver(event)
+
+	if err := p.notify(event); err != nil {
+		log.Printf("Falha ao repassar evento %s às outras instâncias: %v", eventType, err)
+	}
+}
+
+func (p *PostgresPublisher) notify(event Event) error {
+	payload, err := json.Marshal(notification{Origin: p.instanceID, Event: &event})
+	if err != nil {
+		return err
+	}
+
+	if len(payload) > maxNotifyPayload {
+		eventPayload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		outbox := outboxEvent{ID: uuid.New(), Payload: string(eventPayload)}
+		if err := p.db.Create(&outbox).Error; err != nil {
+			return err
+		}
+
+		payload, err = json.Marshal(notification{Origin: p.instanceID, OutboxID: &outbox.ID})
+		if err != nil {
+			return err
+		}
+	}
+
+	return p.db.Exec("SELECT pg_notify(?, ?)", notifyChannel, string(payload)).Error
+}
+
+// Listen mantém uma conexão dedicada escutando o canal e repassa ao hub local os eventos das
+// outras instâncias. Reconecta automaticamente até que ctx seja cancelado. Eventos publicados
+// enquanto a conexão estiver caída são perdidos; os clientes recarregam o estado ao reconectar.
+func (p *PostgresPublisher) Listen(ctx context.Context, dsn string) {
+	go p.pruneOutbox(ctx)
+
+	delay := minReconnectDelay
+	for ctx.Err() == nil {
+		err := p.listen(ctx, dsn, func() {
+			delay = minReconnectDelay
+		})
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Printf("Conexão de eventos em tempo real perdida: %v. Reconectando em %v...", err, delay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+func (p *PostgresPublisher) listen(ctx context.Context, dsn string, connected func()) error {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+notifyChannel); err != nil {
+		return err
+	}
+	connected()
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		p.relay(n.Payload)
+	}
+}
+
+// relay entrega ao hub local um evento publicado por outra instância
+func (p *PostgresPublisher) relay(payload string) {
+	var message notification
+	if err := json.Unmarshal([]byte(payload), &message); err != nil {
+		log.Printf("Notificação de evento inválida: %v", err)
+		return
+	}
+
+	if message.Origin == p.instanceID {
+		return
+	}
+
+	if message.OutboxID != nil {
+		var outbox outboxEvent
+		if err := p.db.Where("id = ?", *message.OutboxID).First(&outbox).Error; err != nil {
+			log.Printf("Falha ao carregar evento %s do outbox: %v", *message.OutboxID, err)
+			return
+		}
+
+		message.Event = &Event{}
+		if err := json.Unmarshal([]byte(outbox.Payload), message.Event); err != nil {
+			log.Printf("Evento %s do outbox inválido: %v", *message.OutboxID, err)
+			return
+		}
+	}
+
+	if message.Event != nil {
+		p.hub.Deliver(*message.Event)
+	}
+}
+
+// pruneOutbox remove periodicamente os eventos grandes que todas as instâncias já receberam
+func (p *PostgresPublisher) pruneOutbox(ctx context.Context) {
+	ticker := time.NewTicker(outboxRetention / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.db.Where("created_at < ?", time.Now().Add(-outboxRetention)).Delete(&outboxEvent{}).Error; err != nil {
+				log.Printf("Falha ao limpar o outbox de eventos: %v", err)
+			}
+		}
+	}
+}
@@ -1,7 +1,10 @@
 package repositories
 
 import (
+	"errors"
+
 	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
 	"api-jet-manager/internal/infrastructure/database"
 
 	"github.com/google/uuid"
@@ -18,6 +21,14 @@ func NewPostgresAddonRepository(db *database.PostgresDB) *PostgresAddonRepositor
 	}
 }
 
+// scopedAddons limita a consulta aos complementos de produtos do restaurante,
+// já que a tabela addons não possui restaurant_id
+func (r *PostgresAddonRepository) scopedAddons(restaurantID uuid.UUID) *gorm.DB {
+	return r.DB.Model(&models.Addon{}).
+		Joins("JOIN products ON products.id = addons.product_id").
+		Where("products.restaurant_id = ?", restaurantID)
+}
+
 func (r *PostgresAddonRepository) CreateAddon(addon *models.Addon) error {
 	if err := r.DB.Create(addon).Error; err != nil {
 		return err
@@ -27,8 +38,79 @@ func (r *PostgresAddonRepository) CreateAddon(addon *models.Addon) error {
 
 func (r *PostgresAddonRepository) GetAddonByID(restaurantID, id uuid.UUID) (*models.Addon, error) {
 	var addon models.Addon
-	if err := r.DB.Where("restaurant_id = ? AND id = ?", restaurantID, id).First(&addon).Preload("Options").Error; err != nil {
+	if err := r.scopedAddons(restaurantID).
+		Preload("Options", func(db *gorm.DB) *gorm.DB { return db.Order("options.created_at asc") }).
+		Where("addons.id = ?", id).
+		First(&addon).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrAddonNotFound
+		}
 		return nil, err
 	}
 	return &addon, nil
 }
+
+func (r *PostgresAddonRepository) GetAddonsByProductID(restaurantID, productID uuid.UUID) ([]models.Addon, error) {
+	var addons []models.Addon
+	if err := r.scopedAddons(restaurantID).
+		Preload("Options", func(db *gorm.DB) *gorm.DB { return db.Order("options.created_at asc") }).
+		Where("addons.product_id = ?", productID).
+		Order("addons.created_at asc").
+		Find(&addons).Error; err != nil {
+		return nil, err
+	}
+	return addons, nil
+}
+
+func (r *PostgresAddonRepository) UpdateAddon(restaurantID uuid.UUID, addon *models.Addon) error {
+	// Garante que o complemento pertence a um produto do restaurante antes de salvar
+	if _, err := r.GetAddonByID(restaurantID, addon.ID); err != nil {
+		return err
+	}
+	return r.DB.Omit("Options", "Product").Save(addon).Error
+}
+
+func (r *PostgresAddonRepository) DeleteAddon(restaurantID, id uuid.UUID) error {
+	addon, err := r.GetAddonByID(restaurantID, id)
+	if err != nil {
+		return err
+	}
+
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("addon_id = ?", addon.ID).Delete(&models.Option{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Addon{}, "id = ?", addon.ID).Error
+	})
+}
+
+func (r *PostgresAddonRepository) CreateOption(option *models.Option) error {
+	return r.DB.Create(option).Error
+}
+
+func (r *PostgresAddonRepository) GetOptionByID(addonID, id uuid.UUID) (*models.Option, error) {
+	var option models.Option
+	if err := r.DB.Where("addon_id = ? AND id = ?", addonID, id).First(&option).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrOptionNotFound
+		}
+		return nil, err
+	}
+	return &option, nil
+}
+
+func (r *PostgresAddonRepository) GetOptionsByAddonID(addonID uuid.UUID) ([]models.Option, error) {
+	var options []models.Option
+	if err := r.DB.Where("addon_id = ?", addonID).Order("created_at asc").Find(&options).Error; err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+func (r *PostgresAddonRepository) UpdateOption(option *models.Option) error {
+	return r.DB.Omit("Addon").Save(option).Error
+}
+
+func (r *PostgresAddonRepository) DeleteOption(addonID, id uuid.UUID) error {
+	return r.DB.Where("addon_id = ? AND id = ?", addonID, id).Delete(&models.Option{}).Error
+}
@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresAuthRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresAuthRepository(db *database.PostgresDB) *PostgresAuthRepository {
+	return &PostgresAuthRepository{
+		DB: db.DB,
+	}
+}
+
+func (r *PostgresAuthRepository) WithTx(tx repositories.Transaction) repositories.AuthRepository {
+	return &PostgresAuthRepository{DB: txDB(tx, r.DB)}
+}
+
+func (r *PostgresAuthRepository) CreateRefreshToken(token *models.RefreshToken) error {
+	return r.DB.Create(token).Error
+}
+
+func (r *PostgresAuthRepository) FindRefreshTokenForUpdate(hash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	if err := r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", hash).
+		First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrRefreshTokenNotFound
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *PostgresAuthRepository) UpdateRefreshToken(token *models.RefreshToken) error {
+	return r.DB.Save(token).Error
+}
+
+func (r *PostgresAuthRepository) revoke(query *gorm.DB, at time.Time) ([]models.RefreshToken, error) {
+	var tokens []models.RefreshToken
+	if err := query.Model(&tokens).
+		Clauses(clause.Returning{}).
+		Where("revoked_at IS NULL").
+		Update("revoked_at", at).Error; err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (r *PostgresAuthRepository) RevokeFamily(familyID uuid.UUID, at time.Time) ([]models.RefreshToken, error) {
+	return r.revoke(r.DB.Where("family_id = ?", familyID), at)
+}
+
+func (r *PostgresAuthRepository) RevokeUser(userID uuid.UUID, at time.Time) ([]models.RefreshToken, error) {
+	return r.revoke(r.DB.Where("user_id = ?", userID), at)
+}
+
+func (r *PostgresAuthRepository) RevokeUserInRestaurant(userID, restaurantID uuid.UUID, at time.Time) ([]models.RefreshToken, error) {
+	return r.revoke(r.DB.Where("user_id = ? AND restaurant_id = ?", userID, restaurantID), at)
+}
+
+func (r *PostgresAuthRepository) DenyTokens(tokens []models.RevokedToken) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	return r.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&tokens).Error
+}
+
+func (r *PostgresAuthRepository) IsTokenDenied(jti uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.DB.Model(&models.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *PostgresAuthRepository) DeleteExpired(before time.Time) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expires_at < ?", before).Delete(&models.RevokedToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("expires_at < ?", before).Delete(&models.RefreshToken{}).Error
+	})
+}
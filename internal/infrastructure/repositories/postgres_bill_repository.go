@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresBillRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresBillRepository(db *database.PostgresDB) *PostgresBillRepository {
+	return &PostgresBillRepository{
+		DB: db.DB,
+	}
+}
+
+func (r *PostgresBillRepository) WithTx(tx repositories.Transaction) repositories.BillRepository {
+	return &PostgresBillRepository{DB: txDB(tx, r.DB)}
+}
+
+func (r *PostgresBillRepository) Create(bill *models.Bill) error {
+	return r.DB.Omit(clause.Associations).Create(bill).Error
+}
+
+func (r *PostgresBillRepository) find(query *gorm.DB, restaurantID, id uuid.UUID) (*models.Bill, error) {
+	var bill models.Bill
+	if err := query.Where("restaurant_id = ? AND id = ?", restaurantID, id).First(&bill).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrBillNotFound
+		}
+		return nil, err
+	}
+	return &bill, nil
+}
+
+func (r *PostgresBillRepository) FindByID(restaurantID, id uuid.UUID) (*models.Bill, error) {
+	return r.find(r.DB.
+		Preload("Settlements", func(db *gorm.DB) *gorm.DB { return db.Order("paid_at ASC") }).
+		Preload("Attachments", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }),
+		restaurantID, id)
+}
+
+func (r *PostgresBillRepository) FindByIDForUpdate(restaurantID, id uuid.UUID) (*models.Bill, error) {
+	return r.find(r.DB.Clauses(clause.Locking{Strength: "UPDATE"}), restaurantID, id)
+}
+
+func (r *PostgresBillRepository) List(restaurantID uuid.UUID, filter repositories.BillFilter) ([]models.Bill, error) {
+	query := r.DB.Where("restaurant_id = ?", restaurantID)
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	if filter.SupplierID != nil {
+		query = query.Where("supplier_id = ?", *filter.SupplierID)
+	}
+	if filter.DueFrom != nil {
+		query = query.Where("due_date >= ?", *filter.DueFrom)
+	}
+	if filter.DueTo != nil {
+		query = query.Where("due_date <= ?", *filter.DueTo)
+	}
+
+	// O status gravado não considera o vencimento, que é comparado com o dia de hoje
+	today := models.Day(time.Now())
+	unpaid := []models.BillStatus{models.BillStatusOpen, models.BillStatusPartiallyPaid}
+	if filter.Unpaid {
+		query = query.Where("status IN ?", unpaid)
+	}
+	switch filter.Status {
+	case "":
+	case models.BillStatusOverdue:
+		query = query.Where("status IN ? AND due_date < ?", unpaid, today)
+	case models.BillStatusOpen, models.BillStatusPartiallyPaid:
+		query = query.Where("status = ? AND due_date >= ?", filter.Status, today)
+	default:
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var bills []models.Bill
+	if err := query.Order("due_date ASC, created_at ASC").Find(&bills).Error; err != nil {
+		return nil, err
+	}
+	return bills, nil
+}
+
+func (r *PostgresBillRepository) Update(bill *models.Bill) error {
+	return r.DB.Omit(clause.Associations).Save(bill).Error
+}
+
+func (r *PostgresBillRepository) CreateSettlement(settlement *models.BillSettlement) error {
+	return r.DB.Create(settlement).Error
+}
+
+func (r *PostgresBillRepository) CreateAttachment(attachment *models.BillAttachment) error {
+	return r.DB.Create(attachment).Error
+}
+
+func (r *PostgresBillRepository) DeleteAttachment(billID, id uuid.UUID) error {
+	result := r.DB.Where("bill_id = ? AND id = ?", billID, id).Delete(&models.BillAttachment{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrBillAttachmentNotFound
+	}
+	return nil
+}
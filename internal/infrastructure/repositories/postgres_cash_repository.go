@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"errors"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresCashRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresCashRepository(db *database.PostgresDB) *PostgresCashRepository {
+	return &PostgresCashRepository{
+		DB: db.DB,
+	}
+}
+
+func (r *PostgresCashRepository) WithTx(tx repositories.Transaction) repositories.CashRepository {
+	return &PostgresCashRepository{DB: txDB(tx, r.DB)}
+}
+
+func (r *PostgresCashRepository) CreateSession(session *models.CashSession) error {
+	return r.DB.Omit(clause.Associations).Create(session).Error
+}
+
+func (r *PostgresCashRepository) find(query *gorm.DB, restaurantID, id uuid.UUID) (*models.CashSession, error) {
+	var session models.CashSession
+	if err := query.Where("restaurant_id = ? AND id = ?", restaurantID, id).First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrCashSessionNotFound
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *PostgresCashRepository) FindSessionByID(restaurantID, id uuid.UUID) (*models.CashSession, error) {
+	return r.find(r.DB.
+		Preload("Movements", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Preload("Counts", func(db *gorm.DB) *gorm.DB { return db.Order("method ASC") }),
+		restaurantID, id)
+}
+
+func (r *PostgresCashRepository) FindSessionByIDForUpdate(restaurantID, id uuid.UUID) (*models.CashSession, error) {
+	return r.find(r.DB.Clauses(clause.Locking{Strength: "UPDATE"}), restaurantID, id)
+}
+
+func (r *PostgresCashRepository) FindOpenSessions(restaurantID uuid.UUID) ([]models.CashSession, error) {
+	var sessions []models.CashSession
+	if err := r.DB.Where("restaurant_id = ? AND status = ?", restaurantID, models.CashSessionStatusOpen).
+		Order("opened_at ASC").Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (r *PostgresCashRepository) ListSessions(restaurantID uuid.UUID, filter repositories.CashSessionFilter) ([]models.CashSession, error) {
+	query := r.DB.Where("restaurant_id = ?", restaurantID)
+	if filter.Register != "" {
+		query = query.Where("register = ?", filter.Register)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.From != nil {
+		query = query.Where("opened_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("opened_at < ?", *filter.To)
+	}
+
+	var sessions []models.CashSession
+	if err := query.Order("opened_at DESC").Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (r *PostgresCashRepository) UpdateSession(session *models.CashSession) error {
+	return r.DB.Omit(clause.Associations).Save(session).Error
+}
+
+func (r *PostgresCashRepository) CreateMovement(movement *models.CashMovement) error {
+	return r.DB.Create(movement).Error
+}
+
+func (r *PostgresCashRepository) CreateCounts(counts []models.CashSessionCount) error {
+	if len(counts) == 0 {
+		return nil
+	}
+	return r.DB.Create(&counts).Error
+}
+
+func (r *PostgresCashRepository) Totals(sessionID uuid.UUID) (*repositories.CashTotals, error) {
+	var payments []struct {
+		Method models.PaymentMethod
+		Total  models.Money
+	}
+	if err := r.DB.Model(&models.Payment{}).
+		Select("method, COALESCE(SUM(amount), 0) AS total").
+		Where("cash_session_id = ?", sessionID).
+		Group("method").
+		Scan(&payments).Error; err != nil {
+		return nil, err
+	}
+
+	var movements []struct {
+		Type  models.CashMovementType
+		Total models.Money
+	}
+	if err := r.DB.Model(&models.CashMovement{}).
+		Select("type, COALESCE(SUM(amount), 0) AS total").
+		Where("session_id = ?", sessionID).
+		Group("type").
+		Scan(&movements).Error; err != nil {
+		return nil, err
+	}
+
+	totals := &repositories.CashTotals{Payments: make(map[models.PaymentMethod]models.Money, len(payments))}
+	for _, row := range payments {
+		totals.Payments[row.Method] = row.Total
+	}
+	for _, row := range movements {
+		switch row.Type {
+		case models.CashMovementIn:
+			totals.CashIn = row.Total
+		case models.CashMovementOut:
+			totals.CashOut = row.Total
+		}
+	}
+	return totals, nil
+}
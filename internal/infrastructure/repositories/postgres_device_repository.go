@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresDeviceRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresDeviceRepository(db *database.PostgresDB) *PostgresDeviceRepository {
+	return &PostgresDeviceRepository{
+		DB: db.DB,
+	}
+}
+
+func (r *PostgresDeviceRepository) WithTx(tx repositories.Transaction) repositories.DeviceRepository {
+	return &PostgresDeviceRepository{DB: txDB(tx, r.DB)}
+}
+
+func (r *PostgresDeviceRepository) Create(device *models.Device) error {
+	return r.DB.Create(device).Error
+}
+
+// first busca o primeiro terminal da consulta, traduzindo a ausência para "device not found"
+func (r *PostgresDeviceRepository) first(query *gorm.DB) (*models.Device, error) {
+	var device models.Device
+	if err := query.First(&device).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrDeviceNotFound
+		}
+		return nil, err
+	}
+	return &device, nil
+}
+
+func (r *PostgresDeviceRepository) FindByID(restaurantID, id uuid.UUID) (*models.Device, error) {
+	return r.first(r.DB.Where("restaurant_id = ? AND id = ?", restaurantID, id))
+}
+
+func (r *PostgresDeviceRepository) FindByIDForUpdate(restaurantID, id uuid.UUID) (*models.Device, error) {
+	return r.first(r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("restaurant_id = ? AND id = ?", restaurantID, id))
+}
+
+func (r *PostgresDeviceRepository) FindByToken(hash string) (*models.Device, error) {
+	return r.first(r.DB.Where("token_hash = ?", hash))
+}
+
+func (r *PostgresDeviceRepository) FindByPairingSelector(selector string) (*models.Device, error) {
+	return r.first(r.DB.Where("pairing_selector = ?", selector))
+}
+
+func (r *PostgresDeviceRepository) FindByPairingSelectorForUpdate(selector string) (*models.Device, error) {
+	return r.first(r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("pairing_selector = ?", selector))
+}
+
+func (r *PostgresDeviceRepository) List(restaurantID uuid.UUID) ([]models.Device, error) {
+	var devices []models.Device
+	if err := r.DB.Where("restaurant_id = ?", restaurantID).Order("name ASC").Find(&devices).Error; err != nil {
+		return nil, err
+	}
+	return devices, nil
+}
+
+func (r *PostgresDeviceRepository) Update(device *models.Device) error {
+	return r.DB.Save(device).Error
+}
+
+func (r *PostgresDeviceRepository) Seen(id uuid.UUID, at time.Time, ip string) error {
+	return r.DB.Model(&models.Device{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"last_seen_at": at,
+		"last_seen_ip": ip,
+	}).Error
+}
+
+func (r *PostgresDeviceRepository) Touch(id uuid.UUID, at time.Time) error {
+	return r.DB.Model(&models.Device{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"last_activity_at": at,
+		"last_seen_at":     at,
+	}).Error
+}
+
+func (r *PostgresDeviceRepository) EndSession(id, jti uuid.UUID) error {
+	return r.DB.Model(&models.Device{}).
+		Where("id = ? AND session_jti = ?", id, jti).
+		UpdateColumns(map[string]interface{}{"active_user_id": nil, "session_jti": nil}).Error
+}
+
+func (r *PostgresDeviceRepository) EndUserSessions(userID uuid.UUID) error {
+	return r.DB.Model(&models.Device{}).
+		Where("active_user_id = ?", userID).
+		UpdateColumns(map[string]interface{}{"active_user_id": nil, "session_jti": nil}).Error
+}
+
+func (r *PostgresDeviceRepository) EndUserSessionsInRestaurant(userID, restaurantID uuid.UUID) error {
+	return r.DB.Model(&models.Device{}).
+		Where("active_user_id = ? AND restaurant_id = ?", userID, restaurantID).
+		UpdateColumns(map[string]interface{}{"active_user_id": nil, "session_jti": nil}).Error
+}
+
+func (r *PostgresDeviceRepository) Delete(restaurantID, id uuid.UUID) error {
+	return r.DB.Where("restaurant_id = ? AND id = ?", restaurantID, id).Delete(&models.Device{}).Error
+}
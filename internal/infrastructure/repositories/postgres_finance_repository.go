@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
 	"api-jet-manager/internal/infrastructure/database"
 
 	"github.com/google/uuid"
@@ -22,6 +23,10 @@ func NewPostgresFinanceRepository(db *database.PostgresDB) *PostgresFinanceRepos
 	}
 }
 
+func (r *PostgresFinanceRepository) WithTx(tx repositories.Transaction) repositories.FinanceRepository {
+	return &PostgresFinanceRepository{DB: txDB(tx, r.DB)}
+}
+
 func (r *PostgresFinanceRepository) FindByDateRange(restaurantID uuid.UUID, startDate, endDate time.Time) ([]models.FinancialTransaction, error) {
 	var transactions []models.FinancialTransaction
 	if err := r.DB.Where("restaurant_id = ? AND date BETWEEN ? AND ?", restaurantID, startDate, endDate).Find(&transactions).Error; err != nil {
@@ -38,13 +43,13 @@ func (r *PostgresFinanceRepository) FindByOrder(restaurantID, orderID uuid.UUID)
 	return transactions, nil
 }
 
-func (r *PostgresFinanceRepository) GetDailySummary(restaurantID uuid.UUID, date time.Time) (float64, float64, error) {
+func (r *PostgresFinanceRepository) GetDailySummary(restaurantID uuid.UUID, date time.Time) (models.Money, models.Money, error) {
 	// Define o início e o fim do dia
 	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
 	endOfDay := startOfDay.Add(24 * time.Hour)
 
 	// Calcula o total de receitas
-	var income float64
+	var income models.Money
 	if err := r.DB.Model(&models.FinancialTransaction{}).
 		Where("restaurant_id = ? AND type = ? AND date BETWEEN ? AND ?", restaurantID, models.TransactionTypeIncome, startOfDay, endOfDay).
 		Select("COALESCE(SUM(amount), 0)").
@@ -53,7 +58,7 @@ func (r *PostgresFinanceRepository) GetDailySummary(restaurantID uuid.UUID, date
 	}
 
 	// Calcula o total de despesas
-	var expense float64
+	var expense models.Money
 	if err := r.DB.Model(&models.FinancialTransaction{}).
 		Where("restaurant_id = ? AND type = ? AND date BETWEEN ? AND ?", restaurantID, models.TransactionTypeExpense, startOfDay, endOfDay).
 		Select("COALESCE(SUM(amount), 0)").
@@ -64,7 +69,7 @@ func (r *PostgresFinanceRepository) GetDailySummary(restaurantID uuid.UUID, date
 	return income, expense, nil
 }
 
-func (r *PostgresFinanceRepository) GetMonthlySummary(restaurantID uuid.UUID, year int, month int) (float64, float64, error) {
+func (r *PostgresFinanceRepository) GetMonthlySummary(restaurantID uuid.UUID, year int, month int) (models.Money, models.Money, error) {
 	// Define o início e o fim do mês
 	loc := time.Now().Location()
 	startOfMonth := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, loc)
@@ -77,7 +82,7 @@ func (r *PostgresFinanceRepository) GetMonthlySummary(restaurantID uuid.UUID, ye
 	}
 
 	// Calcula o total de receitas
-	var income float64
+	var income models.Money
 	if err := r.DB.Model(&models.FinancialTransaction{}).
 		Where("restaurant_id = ? AND type = ? AND date BETWEEN ? AND ?", restaurantID, models.TransactionTypeIncome, startOfMonth, endOfMonth).
 		Select("COALESCE(SUM(amount), 0)").
@@ -86,7 +91,7 @@ func (r *PostgresFinanceRepository) GetMonthlySummary(restaurantID uuid.UUID, ye
 	}
 
 	// Calcula o total de despesas
-	var expense float64
+	var expense models.Money
 	if err := r.DB.Model(&models.FinancialTransaction{}).
 		Where("restaurant_id = ? AND type = ? AND date BETWEEN ? AND ?", restaurantID, models.TransactionTypeExpense, startOfMonth, endOfMonth).
 		Select("COALESCE(SUM(amount), 0)").
@@ -105,7 +110,7 @@ func (r *PostgresFinanceRepository) FindByID(restaurantID, id uuid.UUID) (*model
 	var transaction models.FinancialTransaction
 	if err := r.DB.Where("restaurant_id = ? AND id = ?", restaurantID, id).First(&transaction).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("transaction not found")
+			return nil, repositories.ErrTransactionNotFound
 		}
 		return nil, err
 	}
@@ -120,6 +125,42 @@ func (r *PostgresFinanceRepository) Delete(restaurantID, id uuid.UUID) error {
 	return r.DB.Where("restaurant_id = ?", restaurantID).Delete(&models.FinancialTransaction{}, id).Error
 }
 
+func (r *PostgresFinanceRepository) FindSource(restaurantID, id uuid.UUID) (models.TransactionSource, error) {
+	transaction, err := r.FindByID(restaurantID, id)
+	if err != nil {
+		return models.TransactionSourceManual, err
+	}
+
+	switch {
+	case transaction.PurchaseOrderID != nil:
+		return models.TransactionSourcePurchaseOrder, nil
+	case transaction.RecurringID != nil:
+		return models.TransactionSourceRecurring, nil
+	}
+
+	// Pagamentos e baixas de contas apontam para a transação que geraram
+	references := []struct {
+		model  interface{}
+		source models.TransactionSource
+	}{
+		{&models.Payment{}, models.TransactionSourcePayment},
+		{&models.BillSettlement{}, models.TransactionSourceBillSettlement},
+	}
+	for _, reference := range references {
+		var count int64
+		if err := r.DB.Model(reference.model).
+			Where("restaurant_id = ? AND financial_transaction_id = ?", restaurantID, id).
+			Count(&count).Error; err != nil {
+			return models.TransactionSourceManual, err
+		}
+		if count > 0 {
+			return reference.source, nil
+		}
+	}
+
+	return models.TransactionSourceManual, nil
+}
+
 func (r *PostgresFinanceRepository) List(restaurantID uuid.UUID) ([]models.FinancialTransaction, error) {
 	var transactions []models.FinancialTransaction
 	if err := r.DB.Where("restaurant_id = ?", restaurantID).Find(&transactions).Error; err != nil {
@@ -0,0 +1,274 @@
+package repositories
+
+import (
+	"errors"
+	"sort"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PostgresInventoryRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresInventoryRepository(db *database.PostgresDB) *PostgresInventoryRepository {
+	return &PostgresInventoryRepository{
+		DB: db.DB,
+	}
+}
+
+func (r *PostgresInventoryRepository) WithTx(tx repositories.Transaction) repositories.InventoryRepository {
+	return &PostgresInventoryRepository{DB: txDB(tx, r.DB)}
+}
+
+func (r *PostgresInventoryRepository) CreateIngredient(ingredient *models.Ingredient) error {
+	return r.DB.Create(ingredient).Error
+}
+
+func (r *PostgresInventoryRepository) FindIngredientByID(restaurantID, id uuid.UUID) (*models.Ingredient, error) {
+	var ingredient models.Ingredient
+	if err := r.DB.Where("restaurant_id = ? AND id = ?", restaurantID, id).First(&ingredient).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrIngredientNotFound
+		}
+		return nil, err
+	}
+	return &ingredient, nil
+}
+
+func (r *PostgresInventoryRepository) ListIngredients(restaurantID uuid.UUID, lowStock bool) ([]models.Ingredient, error) {
+	query := r.DB.Where("restaurant_id = ?", restaurantID)
+	if lowStock {
+		query = query.Where("on_hand <= minimum_stock")
+	}
+
+	var ingredients []models.Ingredient
+	if err := query.Order("name ASC").Find(&ingredients).Error; err != nil {
+		return nil, err
+	}
+	return ingredients, nil
+}
+
+func (r *PostgresInventoryRepository) UpdateIngredient(ingredient *models.Ingredient) error {
+	// O estoque só muda através de AdjustStock, que registra a movimentação
+	return r.DB.Model(ingredient).Select("name", "unit", "minimum_stock", "updated_at").Updates(ingredient).Error
+}
+
+func (r *PostgresInventoryRepository) DeleteIngredient(restaurantID, id uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("restaurant_id = ? AND ingredient_id = ?", restaurantID, id).Delete(&models.StockMovement{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("restaurant_id = ? AND id = ?", restaurantID, id).Delete(&models.Ingredient{}).Error
+	})
+}
+
+func (r *PostgresInventoryRepository) IngredientInUse(restaurantID, id uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.DB.Model(&models.RecipeItem{}).
+		Where("restaurant_id = ? AND ingredient_id = ?", restaurantID, id).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *PostgresInventoryRepository) FindProductRecipe(restaurantID, productID uuid.UUID) ([]models.RecipeItem, error) {
+	var items []models.RecipeItem
+	if err := r.DB.Preload("Ingredient").
+		Where("restaurant_id = ? AND product_id = ?", restaurantID, productID).
+		Order("created_at ASC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *PostgresInventoryRepository) FindOptionRecipe(restaurantID, optionID uuid.UUID) ([]models.RecipeItem, error) {
+	var items []models.RecipeItem
+	if err := r.DB.Preload("Ingredient").
+		Where("restaurant_id = ? AND option_id = ?", restaurantID, optionID).
+		Order("created_at ASC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *PostgresInventoryRepository) SetProductRecipe(restaurantID, productID uuid.UUID, items []models.RecipeItem) error {
+	var count int64
+	if err := r.DB.Model(&models.Product{}).Where("restaurant_id = ? AND id = ?", restaurantID, productID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return repositories.ErrProductNotFound
+	}
+
+	for i := range items {
+		items[i].RestaurantID = restaurantID
+		items[i].ProductID = &productID
+		items[i].OptionID = nil
+	}
+	return r.replaceRecipe(restaurantID, "product_id", productID, items)
+}
+
+func (r *PostgresInventoryRepository) SetOptionRecipe(restaurantID, optionID uuid.UUID, items []models.RecipeItem) error {
+	// A opção pertence ao restaurante através do complemento e do produto
+	var count int64
+	if err := r.DB.Table("options").
+		Joins("JOIN addons ON addons.id = options.addon_id").
+		Joins("JOIN products ON products.id = addons.product_id").
+		Where("products.restaurant_id = ? AND options.id = ?", restaurantID, optionID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return repositories.ErrOptionNotFound
+	}
+
+	for i := range items {
+		items[i].RestaurantID = restaurantID
+		items[i].ProductID = nil
+		items[i].OptionID = &optionID
+	}
+	return r.replaceRecipe(restaurantID, "option_id", optionID, items)
+}
+
+// replaceRecipe apaga a ficha técnica atual do dono (produto ou opção) e grava a nova, conferindo os insumos
+func (r *PostgresInventoryRepository) replaceRecipe(restaurantID uuid.UUID, ownerColumn string, ownerID uuid.UUID, items []models.RecipeItem) error {
+	ingredientIDs := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		ingredientIDs = append(ingredientIDs, item.IngredientID)
+	}
+
+	if len(ingredientIDs) > 0 {
+		var count int64
+		if err := r.DB.Model(&models.Ingredient{}).
+			Where("restaurant_id = ? AND id IN ?", restaurantID, ingredientIDs).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count != int64(len(ingredientIDs)) {
+			return repositories.ErrIngredientNotFound
+		}
+	}
+
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("restaurant_id = ? AND "+ownerColumn+" = ?", restaurantID, ownerID).Delete(&models.RecipeItem{}).Error; err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		return tx.Omit("Ingredient").Create(&items).Error
+	})
+}
+
+func (r *PostgresInventoryRepository) FindRecipes(restaurantID uuid.UUID, productIDs, optionIDs []uuid.UUID) ([]models.RecipeItem, error) {
+	if len(productIDs) == 0 && len(optionIDs) == 0 {
+		return nil, nil
+	}
+
+	query := r.DB.Where("restaurant_id = ?", restaurantID)
+	switch {
+	case len(productIDs) > 0 && len(optionIDs) > 0:
+		query = query.Where("product_id IN ? OR option_id IN ?", productIDs, optionIDs)
+	case len(productIDs) > 0:
+		query = query.Where("product_id IN ?", productIDs)
+	default:
+		query = query.Where("option_id IN ?", optionIDs)
+	}
+
+	var items []models.RecipeItem
+	if err := query.Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *PostgresInventoryRepository) AdjustStock(restaurantID uuid.UUID, deltas map[uuid.UUID]models.Quantity) ([]models.Ingredient, error) {
+	ids := make([]uuid.UUID, 0, len(deltas))
+	for id := range deltas {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+
+	ingredients := make([]models.Ingredient, 0, len(ids))
+	for _, id := range ids {
+		var ingredient models.Ingredient
+		result := r.DB.Raw(`
+			UPDATE ingredients
+			SET on_hand = on_hand + ?, updated_at = NOW()
+			WHERE restaurant_id = ? AND id = ?
+			RETURNING *`, deltas[id], restaurantID, id).Scan(&ingredient)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil, repositories.ErrIngredientNotFound
+		}
+		ingredients = append(ingredients, ingredient)
+	}
+	return ingredients, nil
+}
+
+func (r *PostgresInventoryRepository) CreateMovements(movements []models.StockMovement) error {
+	if len(movements) == 0 {
+		return nil
+	}
+	return r.DB.Create(&movements).Error
+}
+
+func (r *PostgresInventoryRepository) ListMovements(restaurantID uuid.UUID, filter repositories.StockMovementFilter) ([]models.StockMovement, error) {
+	query := r.DB.Where("restaurant_id = ?", restaurantID)
+	if filter.IngredientID != nil {
+		query = query.Where("ingredient_id = ?", *filter.IngredientID)
+	}
+	if filter.OrderID != nil {
+		query = query.Where("order_id = ?", *filter.OrderID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var movements []models.StockMovement
+	if err := query.Order("created_at DESC").Find(&movements).Error; err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+// productAvailability é verdadeira quando todos os insumos da ficha técnica cobrem uma porção
+const productAvailability = `NOT EXISTS (
+	SELECT 1 FROM recipe_items ri
+	JOIN ingredients i ON i.id = ri.ingredient_id
+	WHERE ri.product_id = products.id AND i.on_hand < ri.quantity)`
+
+func (r *PostgresInventoryRepository) RefreshProductStock(restaurantID uuid.UUID, ingredientIDs []uuid.UUID) error {
+	if len(ingredientIDs) == 0 {
+		return nil
+	}
+	return r.DB.Exec(`
+		UPDATE products SET in_stock = `+productAvailability+`, updated_at = NOW()
+		WHERE restaurant_id = ? AND id IN (
+			SELECT product_id FROM recipe_items WHERE restaurant_id = ? AND ingredient_id IN ?)`,
+		restaurantID, restaurantID, ingredientIDs).Error
+}
+
+func (r *PostgresInventoryRepository) RefreshProductAvailability(restaurantID uuid.UUID, productIDs []uuid.UUID) error {
+	if len(productIDs) == 0 {
+		return nil
+	}
+	return r.DB.Exec(`
+		UPDATE products SET in_stock = `+productAvailability+`, updated_at = NOW()
+		WHERE restaurant_id = ? AND id IN ?`,
+		restaurantID, productIDs).Error
+}
@@ -0,0 +1,207 @@
+package repositories
+
+import (
+	"errors"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresKitchenRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresKitchenRepository(db *database.PostgresDB) *PostgresKitchenRepository {
+	return &PostgresKitchenRepository{
+		DB: db.DB,
+	}
+}
+
+func (r *PostgresKitchenRepository) WithTx(tx repositories.Transaction) repositories.KitchenRepository {
+	return &PostgresKitchenRepository{DB: txDB(tx, r.DB)}
+}
+
+func (r *PostgresKitchenRepository) CreateStation(station *models.KitchenStation) error {
+	return r.DB.Create(station).Error
+}
+
+func (r *PostgresKitchenRepository) FindStationByID(restaurantID, id uuid.UUID) (*models.KitchenStation, error) {
+	var station models.KitchenStation
+	if err := r.DB.Where("restaurant_id = ? AND id = ?", restaurantID, id).First(&station).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrStationNotFound
+		}
+		return nil, err
+	}
+	return &station, nil
+}
+
+func (r *PostgresKitchenRepository) ListStations(restaurantID uuid.UUID) ([]models.KitchenStation, error) {
+	var stations []models.KitchenStation
+	if err := r.DB.Where("restaurant_id = ?", restaurantID).Order("name ASC").Find(&stations).Error; err != nil {
+		return nil, err
+	}
+	return stations, nil
+}
+
+func (r *PostgresKitchenRepository) UpdateStation(station *models.KitchenStation) error {
+	return r.DB.Save(station).Error
+}
+
+func (r *PostgresKitchenRepository) DeleteStation(restaurantID, id uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Product{}).Where("restaurant_id = ? AND station_id = ?", restaurantID, id).
+			Update("station_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.ProductCategory{}).Where("restaurant_id = ? AND station_id = ?", restaurantID, id).
+			Update("station_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.KitchenTicket{}).Where("restaurant_id = ? AND station_id = ?", restaurantID, id).
+			Update("station_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Where("restaurant_id = ? AND id = ?", restaurantID, id).Delete(&models.KitchenStation{}).Error
+	})
+}
+
+func (r *PostgresKitchenRepository) SetProductStation(restaurantID, productID uuid.UUID, stationID *uuid.UUID) error {
+	result := r.DB.Model(&models.Product{}).Where("restaurant_id = ? AND id = ?", restaurantID, productID).Update("station_id", stationID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrProductNotFound
+	}
+	return nil
+}
+
+func (r *PostgresKitchenRepository) SetCategoryStation(restaurantID, categoryID uuid.UUID, stationID *uuid.UUID) error {
+	result := r.DB.Model(&models.ProductCategory{}).Where("restaurant_id = ? AND id = ?", restaurantID, categoryID).Update("station_id", stationID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrCategoryNotFound
+	}
+	return nil
+}
+
+// ResolveRoutes ignora praças inativas, de modo que o produto cai na praça da categoria ou fica sem praça
+func (r *PostgresKitchenRepository) ResolveRoutes(restaurantID uuid.UUID, productIDs []uuid.UUID) (map[uuid.UUID]repositories.KitchenRoute, error) {
+	routes := make(map[uuid.UUID]repositories.KitchenRoute, len(productIDs))
+	if len(productIDs) == 0 {
+		return routes, nil
+	}
+
+	var rows []repositories.KitchenRoute
+	if err := r.DB.Raw(`
+		SELECT p.id AS product_id, p.name AS product_name, COALESCE(ps.id, cs.id) AS station_id
+		FROM products p
+		LEFT JOIN product_categories c ON c.id = p.category_id
+		LEFT JOIN kitchen_stations ps ON ps.id = p.station_id AND ps.active
+		LEFT JOIN kitchen_stations cs ON cs.id = c.station_id AND cs.active
+		WHERE p.restaurant_id = ? AND p.id IN ?`, restaurantID, productIDs).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		routes[row.ProductID] = row
+	}
+	return routes, nil
+}
+
+func (r *PostgresKitchenRepository) CreateTickets(tickets []models.KitchenTicket) error {
+	if len(tickets) == 0 {
+		return nil
+	}
+	// A restrição única em order_item_id torna a criação idempotente
+	return r.DB.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "order_item_id"}}, DoNothing: true}).Create(&tickets).Error
+}
+
+func (r *PostgresKitchenRepository) FindTicketByID(restaurantID, id uuid.UUID) (*models.KitchenTicket, error) {
+	var ticket models.KitchenTicket
+	if err := r.DB.Where("restaurant_id = ? AND id = ?", restaurantID, id).First(&ticket).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrTicketNotFound
+		}
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+func (r *PostgresKitchenRepository) FindTicketByIDForUpdate(restaurantID, id uuid.UUID) (*models.KitchenTicket, error) {
+	var ticket models.KitchenTicket
+	if err := r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("restaurant_id = ? AND id = ?", restaurantID, id).
+		First(&ticket).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrTicketNotFound
+		}
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+func (r *PostgresKitchenRepository) FindTickets(restaurantID uuid.UUID, filter repositories.KitchenTicketFilter) ([]models.KitchenTicket, error) {
+	query := r.DB.Where("restaurant_id = ?", restaurantID)
+	if filter.StationID != nil {
+		query = query.Where("station_id = ?", *filter.StationID)
+	}
+	if filter.OrderID != nil {
+		query = query.Where("order_id = ?", *filter.OrderID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+
+	var tickets []models.KitchenTicket
+	if err := query.Order("created_at ASC").Find(&tickets).Error; err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+func (r *PostgresKitchenRepository) UpdateTicket(ticket *models.KitchenTicket) error {
+	return r.DB.Save(ticket).Error
+}
+
+func (r *PostgresKitchenRepository) DeleteOpenTickets(restaurantID, orderID uuid.UUID) ([]models.KitchenTicket, error) {
+	var tickets []models.KitchenTicket
+	if err := r.DB.Clauses(clause.Returning{}).
+		Where("restaurant_id = ? AND order_id = ? AND status <> ?", restaurantID, orderID, models.TicketStatusDone).
+		Delete(&tickets).Error; err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+func (r *PostgresKitchenRepository) MoveItemTickets(restaurantID, itemID uuid.UUID, order *models.Order) ([]models.KitchenTicket, error) {
+	var tickets []models.KitchenTicket
+	if err := r.DB.Model(&tickets).Clauses(clause.Returning{}).
+		Where("restaurant_id = ? AND order_item_id = ?", restaurantID, itemID).
+		Updates(map[string]interface{}{
+			"order_id":   order.ID,
+			"order_code": order.Code,
+			"order_type": order.Type,
+		}).Error; err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+func (r *PostgresKitchenRepository) DeleteItemTickets(restaurantID, itemID uuid.UUID) ([]models.KitchenTicket, error) {
+	var tickets []models.KitchenTicket
+	if err := r.DB.Clauses(clause.Returning{}).
+		Where("restaurant_id = ? AND order_item_id = ?", restaurantID, itemID).
+		Delete(&tickets).Error; err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
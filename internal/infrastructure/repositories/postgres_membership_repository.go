@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"errors"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PostgresMembershipRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresMembershipRepository(db *database.PostgresDB) *PostgresMembershipRepository {
+	return &PostgresMembershipRepository{
+		DB: db.DB,
+	}
+}
+
+func (r *PostgresMembershipRepository) WithTx(tx repositories.Transaction) repositories.MembershipRepository {
+	return &PostgresMembershipRepository{DB: txDB(tx, r.DB)}
+}
+
+func (r *PostgresMembershipRepository) Create(membership *models.Membership) error {
+	return r.DB.Omit("User", "Restaurant").Create(membership).Error
+}
+
+func (r *PostgresMembershipRepository) Find(restaurantID, userID uuid.UUID) (*models.Membership, error) {
+	var membership models.Membership
+	if err := r.DB.Where("restaurant_id = ? AND user_id = ?", restaurantID, userID).First(&membership).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrMembershipNotFound
+		}
+		return nil, err
+	}
+	return &membership, nil
+}
+
+func (r *PostgresMembershipRepository) ListByUser(userID uuid.UUID) ([]models.Membership, error) {
+	var memberships []models.Membership
+	if err := r.DB.Preload("Restaurant").
+		Joins("JOIN restaurants ON restaurants.id = memberships.restaurant_id").
+		Where("memberships.user_id = ?", userID).
+		Order("restaurants.name ASC").
+		Find(&memberships).Error; err != nil {
+		return nil, err
+	}
+	return memberships, nil
+}
+
+func (r *PostgresMembershipRepository) ListByRestaurant(restaurantID uuid.UUID) ([]models.Membership, error) {
+	var memberships []models.Membership
+	if err := r.DB.Preload("User").
+		Joins("JOIN users ON users.id = memberships.user_id").
+		Where("memberships.restaurant_id = ?", restaurantID).
+		Order("users.name ASC").
+		Find(&memberships).Error; err != nil {
+		return nil, err
+	}
+	return memberships, nil
+}
+
+func (r *PostgresMembershipRepository) Update(membership *models.Membership) error {
+	return r.DB.Omit("User", "Restaurant").Save(membership).Error
+}
+
+func (r *PostgresMembershipRepository) Delete(restaurantID, userID uuid.UUID) error {
+	return r.DB.Where("restaurant_id = ? AND user_id = ?", restaurantID, userID).Delete(&models.Membership{}).Error
+}
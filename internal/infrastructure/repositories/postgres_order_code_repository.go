@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"errors"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PostgresOrderCodeRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresOrderCodeRepository(db *database.PostgresDB) *PostgresOrderCodeRepository {
+	return &PostgresOrderCodeRepository{
+		DB: db.DB,
+	}
+}
+
+func (r *PostgresOrderCodeRepository) FindSettings(restaurantID uuid.UUID) (*models.OrderCodeSettings, error) {
+	var settings models.OrderCodeSettings
+	if err := r.DB.Where("restaurant_id = ?", restaurantID).First(&settings).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.DefaultOrderCodeSettings(restaurantID), nil
+		}
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (r *PostgresOrderCodeRepository) SaveSettings(settings *models.OrderCodeSettings) error {
+	return r.DB.Save(settings).Error
+}
+
+// NextValue usa um upsert para que instâncias concorrentes nunca recebam o mesmo número.
+// O incremento não participa da transação do pedido: um pedido que falha deixa um buraco
+// na numeração, mas não bloqueia a sequência enquanto a transação estiver aberta.
+func (r *PostgresOrderCodeRepository) NextValue(restaurantID uuid.UUID, period string) (int64, error) {
+	var value int64
+	err := r.DB.Raw(`
+		INSERT INTO order_code_sequences (restaurant_id, period, last_value, updated_at)
+		VALUES (?, ?, 1, NOW())
+		ON CONFLICT (restaurant_id, period)
+		DO UPDATE SET last_value = order_code_sequences.last_value + 1, updated_at = NOW()
+		RETURNING last_value`, restaurantID, period).Scan(&value).Error
+	if err != nil {
+		return 0, err
+	}
+	return value, nil
+}
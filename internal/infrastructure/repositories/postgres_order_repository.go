@@ -7,10 +7,12 @@ import (
 	"time"
 
 	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
 	"api-jet-manager/internal/infrastructure/database"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PostgresOrderRepository struct {
@@ -23,6 +25,10 @@ func NewPostgresOrderRepository(db *database.PostgresDB) *PostgresOrderRepositor
 	}
 }
 
+func (r *PostgresOrderRepository) WithTx(tx repositories.Transaction) repositories.OrderRepository {
+	return &PostgresOrderRepository{DB: txDB(tx, r.DB)}
+}
+
 func (r *PostgresOrderRepository) Create(order *models.Order) error {
 	// O restaurant_id já deve estar definido no objeto order antes de chamar este método
 	return r.DB.Create(order).Error
@@ -32,7 +38,19 @@ func (r *PostgresOrderRepository) FindByID(restaurantID, id uuid.UUID) (*models.
 	var order models.Order
 	if err := r.DB.Where("restaurant_id = ? AND id = ?", restaurantID, id).First(&order).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("order not found")
+			return nil, repositories.ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (r *PostgresOrderRepository) FindByIDForUpdate(restaurantID, id uuid.UUID) (*models.Order, error) {
+	var order models.Order
+	if err := r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("restaurant_id = ? AND id = ?", restaurantID, id).First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrOrderNotFound
 		}
 		return nil, err
 	}
@@ -66,8 +84,12 @@ func (r *PostgresOrderRepository) FindByTable(restaurantID, tableID uuid.UUID) (
 
 func (r *PostgresOrderRepository) FindActiveByTable(restaurantID, tableID uuid.UUID) (*models.Order, error) {
 	var order models.Order
-	if err := r.DB.Where("restaurant_id = ? AND table_id = ? AND status NOT IN (?, ?)",
-		restaurantID, tableID, models.OrderStatusPaid, models.OrderStatusCancelled).First(&order).Error; err != nil {
+	// Mesas juntadas compartilham o pedido atual, então qualquer mesa do grupo o encontra
+	currentOrder := r.DB.Model(&models.Table{}).Select("current_order_id").Where("restaurant_id = ? AND id = ?", restaurantID, tableID)
+	if err := r.DB.Where("restaurant_id = ? AND status NOT IN (?, ?)", restaurantID, models.OrderStatusPaid, models.OrderStatusCancelled).
+		Where("id = (?) OR table_id = ?", currentOrder, tableID).
+		Order("created_at DESC").
+		First(&order).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("no active order for this table")
 		}
@@ -104,10 +126,26 @@ func (r *PostgresOrderRepository) RemoveItem(restaurantID, orderID, itemID uuid.
 		return err
 	}
 
-	// Se o pedido pertence ao restaurante, então podemos remover o item
+	// Se o pedido pertence ao restaurante, então podemos remover o item e as opções escolhidas
+	if err := r.DB.Where("order_item_id = ?", itemID).Delete(&models.OrderItemOption{}).Error; err != nil {
+		return err
+	}
 	return r.DB.Where("order_id = ? AND id = ?", orderID, itemID).Delete(&models.OrderItem{}).Error
 }
 
+func (r *PostgresOrderRepository) MoveItem(restaurantID, itemID, toOrderID uuid.UUID) error {
+	result := r.DB.Model(&models.OrderItem{}).
+		Where("id = ? AND order_id IN (?)", itemID, r.DB.Model(&models.Order{}).Select("id").Where("restaurant_id = ?", restaurantID)).
+		Update("order_id", toOrderID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrOrderItemNotFound
+	}
+	return nil
+}
+
 func (r *PostgresOrderRepository) UpdateItem(item *models.OrderItem) error {
 	// Assumindo que garantimos no service layer que este item pertence a um pedido do restaurante correto
 	return r.DB.Save(item).Error
@@ -125,12 +163,41 @@ func (r *PostgresOrderRepository) FindItems(restaurantID, orderID uuid.UUID) ([]
 
 	// Se o pedido pertence ao restaurante, então podemos buscar os itens
 	var items []models.OrderItem
-	if err := r.DB.Where("order_id = ?", orderID).Find(&items).Error; err != nil {
+	if err := r.DB.Preload("Options").Where("order_id = ?", orderID).Find(&items).Error; err != nil {
 		return nil, err
 	}
 	return items, nil
 }
 
+func (r *PostgresOrderRepository) RecalculateTotal(restaurantID, orderID uuid.UUID) (models.Money, error) {
+	var total models.Money
+	if err := r.DB.Raw(`
+		UPDATE orders
+		SET total_amount = (
+			SELECT COALESCE(SUM(price * quantity), 0) FROM order_items WHERE order_id = orders.id
+		), updated_at = NOW()
+		WHERE restaurant_id = ? AND id = ?
+		RETURNING total_amount`, restaurantID, orderID).Scan(&total).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *PostgresOrderRepository) AverageSeatDuration(restaurantID uuid.UUID, since time.Time) (time.Duration, int64, error) {
+	var result struct {
+		Seconds float64
+		Count   int64
+	}
+	if err := r.DB.Raw(`
+		SELECT COALESCE(AVG(EXTRACT(EPOCH FROM paid_at - created_at)), 0) AS seconds, COUNT(*) AS count
+		FROM orders
+		WHERE restaurant_id = ? AND table_id IS NOT NULL AND type = ? AND status = ? AND paid_at >= ?`,
+		restaurantID, models.OrderTypeInHouse, models.OrderStatusPaid, since).Scan(&result).Error; err != nil {
+		return 0, 0, err
+	}
+	return time.Duration(result.Seconds * float64(time.Second)), result.Count, nil
+}
+
 // FindOrdersByDateAndType busca pedidos por data e tipo específico
 // Este método já estava implementado corretamente para multitenancy
 func (r *PostgresOrderRepository) FindOrdersByDateAndType(restaurantID uuid.UUID, date time.Time, orderType models.OrderType) ([]models.Order, error) {
@@ -144,6 +211,7 @@ func (r *PostgresOrderRepository) FindOrdersByDateAndType(restaurantID uuid.UUID
 	result := r.DB.
 		Preload("OrderItems").
 		Preload("OrderItems.Product").
+		Preload("OrderItems.Options").
 		Preload("Restaurant").
 		Preload("User").
 		Where("restaurant_id = ? AND type = ? AND created_at >= ? AND created_at < ?",
@@ -176,6 +244,7 @@ func (r *PostgresOrderRepository) FindOrdersByDateRangeAndType(restaurantID uuid
 	result := r.DB.
 		Preload("OrderItems").
 		Preload("OrderItems.Product").
+		Preload("OrderItems.Options").
 		Preload("Restaurant").
 		Preload("User").
 		Where("restaurant_id = ? AND type = ? AND created_at >= ? AND created_at <= ?",
@@ -188,3 +257,18 @@ func (r *PostgresOrderRepository) FindOrdersByDateRangeAndType(restaurantID uuid
 
 	return orders, nil
 }
+
+func (r *PostgresOrderRepository) AddStatusTransition(transition *models.OrderStatusTransition) error {
+	return r.DB.Create(transition).Error
+}
+
+func (r *PostgresOrderRepository) FindStatusTransitions(restaurantID, orderID uuid.UUID) ([]models.OrderStatusTransition, error) {
+	var transitions []models.OrderStatusTransition
+	if err := r.DB.Preload("User").
+		Where("restaurant_id = ? AND order_id = ?", restaurantID, orderID).
+		Order("created_at asc").
+		Find(&transitions).Error; err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"errors"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PostgresPaymentRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresPaymentRepository(db *database.PostgresDB) *PostgresPaymentRepository {
+	return &PostgresPaymentRepository{
+		DB: db.DB,
+	}
+}
+
+func (r *PostgresPaymentRepository) WithTx(tx repositories.Transaction) repositories.PaymentRepository {
+	return &PostgresPaymentRepository{DB: txDB(tx, r.DB)}
+}
+
+func (r *PostgresPaymentRepository) Create(payment *models.Payment) error {
+	return r.DB.Create(payment).Error
+}
+
+func (r *PostgresPaymentRepository) FindByID(restaurantID, id uuid.UUID) (*models.Payment, error) {
+	var payment models.Payment
+	if err := r.DB.Preload("Items").Where("restaurant_id = ? AND id = ?", restaurantID, id).First(&payment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrPaymentNotFound
+		}
+		return nil, err
+	}
+	return &payment, nil
+}
+
+func (r *PostgresPaymentRepository) FindByOrder(restaurantID, orderID uuid.UUID) ([]models.Payment, error) {
+	var payments []models.Payment
+	if err := r.DB.Preload("Items").
+		Where("restaurant_id = ? AND order_id = ?", restaurantID, orderID).
+		Order("created_at ASC").
+		Find(&payments).Error; err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+func (r *PostgresPaymentRepository) Delete(restaurantID, id uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("payment_id IN (?)",
+			tx.Model(&models.Payment{}).Select("id").Where("restaurant_id = ? AND id = ?", restaurantID, id),
+		).Delete(&models.PaymentItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("restaurant_id = ? AND id = ?", restaurantID, id).Delete(&models.Payment{}).Error
+	})
+}
+
+func (r *PostgresPaymentRepository) PaidQuantities(orderID uuid.UUID) (map[uuid.UUID]int, error) {
+	var rows []struct {
+		OrderItemID uuid.UUID
+		Quantity    int
+	}
+	if err := r.DB.Model(&models.PaymentItem{}).
+		Select("payment_items.order_item_id, SUM(payment_items.quantity) AS quantity").
+		Joins("JOIN payments ON payments.id = payment_items.payment_id").
+		Where("payments.order_id = ?", orderID).
+		Group("payment_items.order_item_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	quantities := make(map[uuid.UUID]int, len(rows))
+	for _, row := range rows {
+		quantities[row.OrderItemID] = row.Quantity
+	}
+	return quantities, nil
+}
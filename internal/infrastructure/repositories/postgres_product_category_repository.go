@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
 	"api-jet-manager/internal/infrastructure/database"
 
 	"github.com/google/uuid"
@@ -31,7 +32,7 @@ func (r *PostgresProductCategoryRepository) FindByID(restaurantID, id uuid.UUID)
 	var category models.ProductCategory
 	if err := r.DB.Where("restaurant_id = ? AND id = ?", restaurantID, id).First(&category).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("category not found")
+			return nil, repositories.ErrCategoryNotFound
 		}
 		return nil, err
 	}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
 	"api-jet-manager/internal/infrastructure/database"
 
 	"github.com/google/uuid"
@@ -31,7 +32,7 @@ func (r *PostgresProductRepository) FindByID(restaurantID, id uuid.UUID) (*model
 	var product models.Product
 	if err := r.DB.Where("restaurant_id = ? AND id = ?", restaurantID, id).First(&product).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("product not found")
+			return nil, repositories.ErrProductNotFound
 		}
 		return nil, err
 	}
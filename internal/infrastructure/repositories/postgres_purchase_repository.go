@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"errors"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresPurchaseRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresPurchaseRepository(db *database.PostgresDB) *PostgresPurchaseRepository {
+	return &PostgresPurchaseRepository{
+		DB: db.DB,
+	}
+}
+
+func (r *PostgresPurchaseRepository) WithTx(tx repositories.Transaction) repositories.PurchaseRepository {
+	return &PostgresPurchaseRepository{DB: txDB(tx, r.DB)}
+}
+
+func (r *PostgresPurchaseRepository) CreateSupplier(supplier *models.Supplier) error {
+	return r.DB.Create(supplier).Error
+}
+
+func (r *PostgresPurchaseRepository) FindSupplierByID(restaurantID, id uuid.UUID) (*models.Supplier, error) {
+	var supplier models.Supplier
+	if err := r.DB.Where("restaurant_id = ? AND id = ?", restaurantID, id).First(&supplier).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrSupplierNotFound
+		}
+		return nil, err
+	}
+	return &supplier, nil
+}
+
+func (r *PostgresPurchaseRepository) ListSuppliers(restaurantID uuid.UUID, activeOnly bool) ([]models.Supplier, error) {
+	query := r.DB.Where("restaurant_id = ?", restaurantID)
+	if activeOnly {
+		query = query.Where("active")
+	}
+
+	var suppliers []models.Supplier
+	if err := query.Order("name ASC").Find(&suppliers).Error; err != nil {
+		return nil, err
+	}
+	return suppliers, nil
+}
+
+func (r *PostgresPurchaseRepository) UpdateSupplier(supplier *models.Supplier) error {
+	return r.DB.Save(supplier).Error
+}
+
+func (r *PostgresPurchaseRepository) DeleteSupplier(restaurantID, id uuid.UUID) error {
+	return r.DB.Where("restaurant_id = ? AND id = ?", restaurantID, id).Delete(&models.Supplier{}).Error
+}
+
+func (r *PostgresPurchaseRepository) SupplierInUse(restaurantID, id uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.DB.Model(&models.PurchaseOrder{}).
+		Where("restaurant_id = ? AND supplier_id = ?", restaurantID, id).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *PostgresPurchaseRepository) CreatePurchaseOrder(order *models.PurchaseOrder) error {
+	return r.DB.Omit("Supplier", "Items.Ingredient", "Transactions").Create(order).Error
+}
+
+func (r *PostgresPurchaseRepository) find(query *gorm.DB, restaurantID, id uuid.UUID) (*models.PurchaseOrder, error) {
+	var order models.PurchaseOrder
+	if err := query.
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Preload("Items.Ingredient").
+		Where("restaurant_id = ? AND id = ?", restaurantID, id).
+		First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrPurchaseOrderNotFound
+		}
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (r *PostgresPurchaseRepository) FindPurchaseOrderByID(restaurantID, id uuid.UUID) (*models.PurchaseOrder, error) {
+	return r.find(r.DB.Preload("Supplier").Preload("Transactions"), restaurantID, id)
+}
+
+func (r *PostgresPurchaseRepository) FindPurchaseOrderByIDForUpdate(restaurantID, id uuid.UUID) (*models.PurchaseOrder, error) {
+	return r.find(r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Supplier"), restaurantID, id)
+}
+
+func (r *PostgresPurchaseRepository) ListPurchaseOrders(restaurantID uuid.UUID, filter repositories.PurchaseOrderFilter) ([]models.PurchaseOrder, error) {
+	query := r.DB.Preload("Supplier").Where("restaurant_id = ?", restaurantID)
+	if filter.SupplierID != nil {
+		query = query.Where("supplier_id = ?", *filter.SupplierID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var orders []models.PurchaseOrder
+	if err := query.Order("created_at DESC").Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (r *PostgresPurchaseRepository) UpdatePurchaseOrder(order *models.PurchaseOrder) error {
+	return r.DB.Omit(clause.Associations).Save(order).Error
+}
+
+func (r *PostgresPurchaseRepository) ReplacePurchaseOrderItems(order *models.PurchaseOrder) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("purchase_order_id = ?", order.ID).Delete(&models.PurchaseOrderItem{}).Error; err != nil {
+			return err
+		}
+		if len(order.Items) == 0 {
+			return nil
+		}
+		for i := range order.Items {
+			order.Items[i].PurchaseOrderID = order.ID
+		}
+		return tx.Omit("Ingredient").Create(&order.Items).Error
+	})
+}
+
+func (r *PostgresPurchaseRepository) UpdatePurchaseOrderItem(item *models.PurchaseOrderItem) error {
+	return r.DB.Omit("Ingredient").Save(item).Error
+}
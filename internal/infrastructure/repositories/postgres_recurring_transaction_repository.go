@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresRecurringTransactionRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresRecurringTransactionRepository(db *database.PostgresDB) *PostgresRecurringTransactionRepository {
+	return &PostgresRecurringTransactionRepository{
+		DB: db.DB,
+	}
+}
+
+func (r *PostgresRecurringTransactionRepository) WithTx(tx repositories.Transaction) repositories.RecurringTransactionRepository {
+	return &PostgresRecurringTransactionRepository{DB: txDB(tx, r.DB)}
+}
+
+func (r *PostgresRecurringTransactionRepository) Create(recurring *models.RecurringTransaction) error {
+	return r.DB.Create(recurring).Error
+}
+
+func (r *PostgresRecurringTransactionRepository) find(query *gorm.DB, restaurantID, id uuid.UUID) (*models.RecurringTransaction, error) {
+	var recurring models.RecurringTransaction
+	if err := query.Where("restaurant_id = ? AND id = ?", restaurantID, id).First(&recurring).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrRecurringTransactionNotFound
+		}
+		return nil, err
+	}
+	return &recurring, nil
+}
+
+func (r *PostgresRecurringTransactionRepository) FindByID(restaurantID, id uuid.UUID) (*models.RecurringTransaction, error) {
+	return r.find(r.DB, restaurantID, id)
+}
+
+func (r *PostgresRecurringTransactionRepository) FindByIDForUpdate(restaurantID, id uuid.UUID) (*models.RecurringTransaction, error) {
+	return r.find(r.DB.Clauses(clause.Locking{Strength: "UPDATE"}), restaurantID, id)
+}
+
+func (r *PostgresRecurringTransactionRepository) List(restaurantID uuid.UUID) ([]models.RecurringTransaction, error) {
+	var recurring []models.RecurringTransaction
+	if err := r.DB.Where("restaurant_id = ?", restaurantID).
+		Order("active DESC, next_date ASC NULLS LAST, description ASC").
+		Find(&recurring).Error; err != nil {
+		return nil, err
+	}
+	return recurring, nil
+}
+
+func (r *PostgresRecurringTransactionRepository) Update(recurring *models.RecurringTransaction) error {
+	return r.DB.Save(recurring).Error
+}
+
+func (r *PostgresRecurringTransactionRepository) Delete(restaurantID, id uuid.UUID) error {
+	return r.DB.Where("restaurant_id = ?", restaurantID).Delete(&models.RecurringTransaction{}, id).Error
+}
+
+func (r *PostgresRecurringTransactionRepository) FindDue(day time.Time) ([]models.RecurringTransaction, error) {
+	var recurring []models.RecurringTransaction
+	if err := r.DB.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("active AND next_date <= ?", day).
+		Order("next_date ASC").
+		Find(&recurring).Error; err != nil {
+		return nil, err
+	}
+	return recurring, nil
+}
+
+func (r *PostgresRecurringTransactionRepository) CreateOccurrence(transaction *models.FinancialTransaction) (bool, error) {
+	result := r.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(transaction)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
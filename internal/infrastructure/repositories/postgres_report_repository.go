@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PostgresReportRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresReportRepository(db *database.PostgresDB) *PostgresReportRepository {
+	return &PostgresReportRepository{
+		DB: db.DB,
+	}
+}
+
+func (r *PostgresReportRepository) WithTx(tx repositories.Transaction) repositories.ReportRepository {
+	return &PostgresReportRepository{DB: txDB(tx, r.DB)}
+}
+
+func (r *PostgresReportRepository) CategoryTotals(restaurantID uuid.UUID, from, to time.Time) ([]repositories.CategoryTotal, error) {
+	var totals []repositories.CategoryTotal
+	if err := r.DB.Model(&models.FinancialTransaction{}).
+		Select("type, category, COALESCE(SUM(amount), 0) AS total, COUNT(*) AS count").
+		Where("restaurant_id = ? AND date >= ? AND date < ?", restaurantID, from, to).
+		Group("type, category").
+		Order("type, total DESC").
+		Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+func (r *PostgresReportRepository) DailyTotals(restaurantID uuid.UUID, from, to time.Time) ([]repositories.DailyTotal, error) {
+	timezone := from.Location().String()
+	lastDay := to.Add(-time.Nanosecond)
+
+	var rows []struct {
+		Day     string
+		Income  models.Money
+		Expense models.Money
+	}
+	// generate_series garante uma linha por dia; o filtro por date usa o índice (restaurant_id, date)
+	if err := r.DB.Raw(`
+		SELECT to_char(d.day, 'YYYY-MM-DD') AS day,
+			COALESCE(SUM(ft.amount) FILTER (WHERE ft.type = ?), 0) AS income,
+			COALESCE(SUM(ft.amount) FILTER (WHERE ft.type = ?), 0) AS expense
+		FROM generate_series(?::date, ?::date, interval '1 day') AS d(day)
+		LEFT JOIN financial_transactions ft
+			ON ft.restaurant_id = ?
+			AND ft.date >= ? AND ft.date < ?
+			AND (ft.date AT TIME ZONE ?)::date = d.day::date
+		GROUP BY d.day
+		ORDER BY d.day`,
+		models.TransactionTypeIncome, models.TransactionTypeExpense,
+		from.Format("2006-01-02"), lastDay.Format("2006-01-02"),
+		restaurantID, from, to, timezone,
+	).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	totals := make([]repositories.DailyTotal, 0, len(rows))
+	for _, row := range rows {
+		day, err := time.ParseInLocation("2006-01-02", row.Day, from.Location())
+		if err != nil {
+			return nil, err
+		}
+		totals = append(totals, repositories.DailyTotal{Day: day, Income: row.Income, Expense: row.Expense})
+	}
+	return totals, nil
+}
+
+func (r *PostgresReportRepository) PaymentMethodTotals(restaurantID uuid.UUID, from, to time.Time) ([]repositories.PaymentMethodTotal, error) {
+	var totals []repositories.PaymentMethodTotal
+	if err := r.DB.Model(&models.FinancialTransaction{}).
+		Select(`COALESCE(NULLIF(payment_method, ''), ?) AS method,
+			COALESCE(SUM(amount) FILTER (WHERE type = ?), 0) AS income,
+			COALESCE(SUM(amount) FILTER (WHERE type = ?), 0) AS expense,
+			COUNT(*) AS count`,
+			models.PaymentMethodOther, models.TransactionTypeIncome, models.TransactionTypeExpense).
+		Where("restaurant_id = ? AND date >= ? AND date < ?", restaurantID, from, to).
+		Group("1").
+		Order("income DESC, method").
+		Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
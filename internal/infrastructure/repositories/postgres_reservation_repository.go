@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresReservationRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresReservationRepository(db *database.PostgresDB) *PostgresReservationRepository {
+	return &PostgresReservationRepository{
+		DB: db.DB,
+	}
+}
+
+func (r *PostgresReservationRepository) WithTx(tx repositories.Transaction) repositories.ReservationRepository {
+	return &PostgresReservationRepository{DB: txDB(tx, r.DB)}
+}
+
+func (r *PostgresReservationRepository) Create(reservation *models.Reservation) error {
+	// Omit("Tables.*") grava apenas o vínculo em reservation_tables, sem tocar nas mesas
+	return r.DB.Omit("Tables.*").Create(reservation).Error
+}
+
+func (r *PostgresReservationRepository) Update(reservation *models.Reservation) error {
+	return r.DB.Omit("Tables").Save(reservation).Error
+}
+
+func (r *PostgresReservationRepository) ReplaceTables(reservation *models.Reservation, tables []models.Table) error {
+	if err := r.DB.Model(reservation).Omit("Tables.*").Association("Tables").Replace(tables); err != nil {
+		return err
+	}
+	reservation.Tables = tables
+	return nil
+}
+
+func (r *PostgresReservationRepository) find(query *gorm.DB, restaurantID, id uuid.UUID) (*models.Reservation, error) {
+	var reservation models.Reservation
+	if err := query.Preload("Tables").
+		Where("restaurant_id = ? AND id = ?", restaurantID, id).
+		First(&reservation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrReservationNotFound
+		}
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+func (r *PostgresReservationRepository) FindByID(restaurantID, id uuid.UUID) (*models.Reservation, error) {
+	return r.find(r.DB, restaurantID, id)
+}
+
+func (r *PostgresReservationRepository) FindByIDForUpdate(restaurantID, id uuid.UUID) (*models.Reservation, error) {
+	return r.find(r.DB.Clauses(clause.Locking{Strength: "UPDATE"}), restaurantID, id)
+}
+
+func (r *PostgresReservationRepository) List(restaurantID uuid.UUID, filter repositories.ReservationFilter) ([]models.Reservation, error) {
+	query := r.DB.Preload("Tables").Where("restaurant_id = ?", restaurantID)
+	if filter.From != nil {
+		query = query.Where("starts_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("starts_at < ?", *filter.To)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var reservations []models.Reservation
+	if err := query.Order("starts_at ASC").Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+func (r *PostgresReservationRepository) FindOverlapping(restaurantID uuid.UUID, tableIDs []uuid.UUID, start, end time.Time, excludeID uuid.UUID) ([]models.Reservation, error) {
+	var reservations []models.Reservation
+	if len(tableIDs) == 0 {
+		return reservations, nil
+	}
+
+	if err := r.DB.Preload("Tables").
+		Where("restaurant_id = ? AND id <> ? AND status IN ?", restaurantID, excludeID,
+			[]models.ReservationStatus{models.ReservationStatusBooked, models.ReservationStatusSeated}).
+		Where("starts_at < ? AND ends_at > ?", end, start).
+		Where("id IN (?)", r.DB.Table("reservation_tables").Select("reservation_id").Where("table_id IN ?", tableIDs)).
+		Order("starts_at ASC").
+		Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+func (r *PostgresReservationRepository) findForScheduler(query *gorm.DB) ([]models.Reservation, error) {
+	var reservations []models.Reservation
+	if err := query.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Preload("Tables").
+		Order("starts_at ASC").
+		Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+func (r *PostgresReservationRepository) FindBookedBetween(from, to time.Time) ([]models.Reservation, error) {
+	return r.findForScheduler(r.DB.Where("status = ? AND starts_at BETWEEN ? AND ?", models.ReservationStatusBooked, from, to))
+}
+
+func (r *PostgresReservationRepository) FindBookedBefore(before time.Time) ([]models.Reservation, error) {
+	return r.findForScheduler(r.DB.Where("status = ? AND starts_at < ?", models.ReservationStatusBooked, before))
+}
+
+func (r *PostgresReservationRepository) FindSeatedEndedBefore(before time.Time) ([]models.Reservation, error) {
+	return r.findForScheduler(r.DB.Where("status = ? AND ends_at < ?", models.ReservationStatusSeated, before))
+}
@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"errors"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresRoleRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresRoleRepository(db *database.PostgresDB) *PostgresRoleRepository {
+	return &PostgresRoleRepository{
+		DB: db.DB,
+	}
+}
+
+func (r *PostgresRoleRepository) WithTx(tx repositories.Transaction) repositories.RoleRepository {
+	return &PostgresRoleRepository{DB: txDB(tx, r.DB)}
+}
+
+// replaceGrants substitui as permissões gravadas pelas do papel
+func (r *PostgresRoleRepository) replaceGrants(role *models.Role) error {
+	if err := r.DB.Where("role_id = ?", role.ID).Delete(&models.RolePermission{}).Error; err != nil {
+		return err
+	}
+	if len(role.Permissions) == 0 {
+		return nil
+	}
+
+	grants := make([]models.RolePermission, 0, len(role.Permissions))
+	for _, permission := range role.Permissions {
+		grants = append(grants, models.RolePermission{RoleID: role.ID, Permission: permission})
+	}
+	return r.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&grants).Error
+}
+
+func (r *PostgresRoleRepository) Create(role *models.Role) error {
+	if err := r.DB.Omit(clause.Associations).Create(role).Error; err != nil {
+		return err
+	}
+	return r.replaceGrants(role)
+}
+
+func (r *PostgresRoleRepository) CreateDefault(role *models.Role) (bool, error) {
+	result := r.DB.Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "restaurant_id"}, {Name: "user_type"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "user_type IS NOT NULL"}}},
+			DoNothing:   true,
+		}).
+		Create(role)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	return true, r.replaceGrants(role)
+}
+
+func (r *PostgresRoleRepository) first(query *gorm.DB) (*models.Role, error) {
+	var role models.Role
+	if err := query.Preload("Grants").First(&role).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrRoleNotFound
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *PostgresRoleRepository) FindByID(restaurantID, id uuid.UUID) (*models.Role, error) {
+	return r.first(r.DB.Where("restaurant_id = ? AND id = ?", restaurantID, id))
+}
+
+func (r *PostgresRoleRepository) FindByUserType(restaurantID uuid.UUID, userType models.UserType) (*models.Role, error) {
+	return r.first(r.DB.Where("restaurant_id = ? AND user_type = ?", restaurantID, userType))
+}
+
+func (r *PostgresRoleRepository) List(restaurantID uuid.UUID) ([]models.Role, error) {
+	var roles []models.Role
+	if err := r.DB.Preload("Grants").
+		Where("restaurant_id = ?", restaurantID).
+		Order("user_type IS NULL, name ASC").
+		Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (r *PostgresRoleRepository) Update(role *models.Role) error {
+	if err := r.DB.Omit(clause.Associations).Save(role).Error; err != nil {
+		return err
+	}
+	return r.replaceGrants(role)
+}
+
+func (r *PostgresRoleRepository) Delete(restaurantID, id uuid.UUID) error {
+	return r.DB.Where("restaurant_id = ?", restaurantID).Delete(&models.Role{}, id).Error
+}
@@ -5,10 +5,12 @@ import (
 	"errors"
 
 	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
 	"api-jet-manager/internal/infrastructure/database"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PostgresTableRepository struct {
@@ -21,6 +23,10 @@ func NewPostgresTableRepository(db *database.PostgresDB) *PostgresTableRepositor
 	}
 }
 
+func (r *PostgresTableRepository) WithTx(tx repositories.Transaction) repositories.TableRepository {
+	return &PostgresTableRepository{DB: txDB(tx, r.DB)}
+}
+
 func (r *PostgresTableRepository) Create(table *models.Table) error {
 	// O restaurant_id já deve estar definido no objeto table antes de chamar este método
 	return r.DB.Create(table).Error
@@ -30,7 +36,19 @@ func (r *PostgresTableRepository) FindByID(restauranteID, id uuid.UUID) (*models
 	var table models.Table
 	if err := r.DB.Where("restaurant_id = ? AND id = ?", restauranteID, id).First(&table).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("table not found")
+			return nil, repositories.ErrTableNotFound
+		}
+		return nil, err
+	}
+	return &table, nil
+}
+
+func (r *PostgresTableRepository) FindByIDForUpdate(restauranteID, id uuid.UUID) (*models.Table, error) {
+	var table models.Table
+	if err := r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("restaurant_id = ? AND id = ?", restauranteID, id).First(&table).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrTableNotFound
 		}
 		return nil, err
 	}
@@ -41,7 +59,7 @@ func (r *PostgresTableRepository) FindByNumber(restauranteID uuid.UUID, number i
 	var table models.Table
 	if err := r.DB.Where("restaurant_id = ? AND number = ?", restauranteID, number).First(&table).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("table not found")
+			return nil, repositories.ErrTableNotFound
 		}
 		return nil, err
 	}
@@ -72,5 +90,153 @@ func (r *PostgresTableRepository) UpdateStatus(restauranteID, id uuid.UUID, stat
 }
 
 func (r *PostgresTableRepository) SetCurrentOrder(restauranteID, id uuid.UUID, orderID *uuid.UUID) error {
-	return r.DB.Model(&models.Table{}).Where("restaurant_id = ? AND id = ?", restauranteID, id).Update("current_order", orderID).Error
+	return r.DB.Model(&models.Table{}).Where("restaurant_id = ? AND id = ?", restauranteID, id).Update("current_order_id", orderID).Error
+}
+
+func (r *PostgresTableRepository) UpdateLayout(table *models.Table) error {
+	return r.DB.Model(table).Where("restaurant_id = ?", table.RestaurantID).
+		Select("area_id", "shape", "pos_x", "pos_y", "width", "height", "rotation").
+		Updates(table).Error
+}
+
+// inGroup restringe a consulta às mesas do mesmo grupo da mesa informada
+func (r *PostgresTableRepository) inGroup(query *gorm.DB, restauranteID, id uuid.UUID) *gorm.DB {
+	return query.Where("restaurant_id = ? AND COALESCE(merged_into_id, id) = (?)", restauranteID,
+		r.DB.Model(&models.Table{}).Select("COALESCE(merged_into_id, id)").Where("restaurant_id = ? AND id = ?", restauranteID, id))
+}
+
+func (r *PostgresTableRepository) Occupy(restauranteID, id uuid.UUID, orderID *uuid.UUID) error {
+	return r.inGroup(r.DB.Model(&models.Table{}), restauranteID, id).
+		Updates(map[string]interface{}{
+			"status":           models.TableStatusOccupied,
+			"current_order_id": orderID,
+		}).Error
+}
+
+func (r *PostgresTableRepository) Release(restauranteID, id uuid.UUID) error {
+	return r.inGroup(r.DB.Model(&models.Table{}), restauranteID, id).
+		Updates(map[string]interface{}{
+			"status":           models.TableStatusFree,
+			"current_order_id": nil,
+		}).Error
+}
+
+func (r *PostgresTableRepository) Reserve(restauranteID uuid.UUID, ids []uuid.UUID) ([]models.Table, error) {
+	var tables []models.Table
+	if len(ids) == 0 {
+		return tables, nil
+	}
+	if err := r.DB.Model(&tables).Clauses(clause.Returning{}).
+		Where("restaurant_id = ? AND id IN ? AND status = ?", restauranteID, ids, models.TableStatusFree).
+		Update("status", models.TableStatusReserved).Error; err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+func (r *PostgresTableRepository) Unreserve(restauranteID uuid.UUID, ids []uuid.UUID) ([]models.Table, error) {
+	var tables []models.Table
+	if len(ids) == 0 {
+		return tables, nil
+	}
+	if err := r.DB.Model(&tables).Clauses(clause.Returning{}).
+		Where("restaurant_id = ? AND id IN ? AND status = ? AND current_order_id IS NULL", restauranteID, ids, models.TableStatusReserved).
+		Update("status", models.TableStatusFree).Error; err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+func (r *PostgresTableRepository) findGroup(query *gorm.DB, restauranteID, id uuid.UUID) ([]models.Table, error) {
+	var tables []models.Table
+	if err := r.inGroup(query, restauranteID, id).
+		Order("merged_into_id NULLS FIRST, number ASC").
+		Find(&tables).Error; err != nil {
+		return nil, err
+	}
+	if len(tables) == 0 {
+		return nil, repositories.ErrTableNotFound
+	}
+	return tables, nil
+}
+
+func (r *PostgresTableRepository) FindGroup(restauranteID, id uuid.UUID) ([]models.Table, error) {
+	return r.findGroup(r.DB, restauranteID, id)
+}
+
+func (r *PostgresTableRepository) FindGroupForUpdate(restauranteID, id uuid.UUID) ([]models.Table, error) {
+	return r.findGroup(r.DB.Clauses(clause.Locking{Strength: "UPDATE"}), restauranteID, id)
+}
+
+func (r *PostgresTableRepository) Merge(restauranteID, primaryID uuid.UUID, memberIDs []uuid.UUID) error {
+	if len(memberIDs) == 0 {
+		return nil
+	}
+	return r.DB.Exec(`
+		UPDATE tables AS t
+		SET merged_into_id = p.id, status = p.status, current_order_id = p.current_order_id, updated_at = NOW()
+		FROM tables AS p
+		WHERE p.restaurant_id = ? AND p.id = ? AND t.restaurant_id = p.restaurant_id AND t.id IN ?`,
+		restauranteID, primaryID, memberIDs).Error
+}
+
+func (r *PostgresTableRepository) Split(restauranteID, primaryID uuid.UUID, memberIDs []uuid.UUID) error {
+	if len(memberIDs) == 0 {
+		return nil
+	}
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		// O pedido aberto em uma das mesas separadas passa a pertencer à mesa principal
+		if err := tx.Exec(`
+			UPDATE orders SET table_id = ?, updated_at = NOW()
+			WHERE restaurant_id = ? AND table_id IN ?
+			AND id = (SELECT current_order_id FROM tables WHERE restaurant_id = ? AND id = ?)`,
+			primaryID, restauranteID, memberIDs, restauranteID, primaryID).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Table{}).
+			Where("restaurant_id = ? AND id IN ? AND merged_into_id = ?", restauranteID, memberIDs, primaryID).
+			Updates(map[string]interface{}{
+				"merged_into_id":   nil,
+				"status":           models.TableStatusFree,
+				"current_order_id": nil,
+			}).Error
+	})
+}
+
+func (r *PostgresTableRepository) CreateArea(area *models.DiningArea) error {
+	return r.DB.Create(area).Error
+}
+
+func (r *PostgresTableRepository) FindAreaByID(restauranteID, id uuid.UUID) (*models.DiningArea, error) {
+	var area models.DiningArea
+	if err := r.DB.Where("restaurant_id = ? AND id = ?", restauranteID, id).First(&area).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrAreaNotFound
+		}
+		return nil, err
+	}
+	return &area, nil
+}
+
+func (r *PostgresTableRepository) ListAreas(restauranteID uuid.UUID) ([]models.DiningArea, error) {
+	var areas []models.DiningArea
+	if err := r.DB.Where("restaurant_id = ?", restauranteID).Order("sort_order ASC, name ASC").Find(&areas).Error; err != nil {
+		return nil, err
+	}
+	return areas, nil
+}
+
+func (r *PostgresTableRepository) UpdateArea(area *models.DiningArea) error {
+	return r.DB.Save(area).Error
+}
+
+func (r *PostgresTableRepository) DeleteArea(restauranteID, id uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Table{}).Where("restaurant_id = ? AND area_id = ?", restauranteID, id).
+			Update("area_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Where("restaurant_id = ? AND id = ?", restauranteID, id).Delete(&models.DiningArea{}).Error
+	})
 }
@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// PostgresTransaction carrega a sessão GORM da transação para os repositórios
+type PostgresTransaction struct {
+	DB          *gorm.DB
+	afterCommit []func()
+}
+
+func (t *PostgresTransaction) AfterCommit(fn func()) {
+	t.afterCommit = append(t.afterCommit, fn)
+}
+
+type PostgresUnitOfWork struct {
+	DB *gorm.DB
+}
+
+func NewPostgresUnitOfWork(db *database.PostgresDB) *PostgresUnitOfWork {
+	return &PostgresUnitOfWork{
+		DB: db.DB,
+	}
+}
+
+func (u *PostgresUnitOfWork) Do(fn func(tx repositories.Transaction) error) error {
+	var transaction *PostgresTransaction
+	err := u.DB.Transaction(func(tx *gorm.DB) error {
+		transaction = &PostgresTransaction{DB: tx}
+		return fn(transaction)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, callback := range transaction.afterCommit {
+		callback()
+	}
+	return nil
+}
+
+// txDB retorna a sessão da transação, ou a conexão padrão se tx não for uma transação Postgres
+func txDB(tx repositories.Transaction, fallback *gorm.DB) *gorm.DB {
+	if t, ok := tx.(*PostgresTransaction); ok && t != nil {
+		return t.DB
+	}
+	return fallback
+}
@@ -3,33 +3,43 @@ package repositories
 
 import (
 	"errors"
+	"time"
 
 	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
 	"api-jet-manager/internal/infrastructure/database"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PostgresUserRepository struct {
 	DB *gorm.DB
 }
 
+// memberOf filtra os usuários vinculados ao restaurante
+const memberOf = "EXISTS (SELECT 1 FROM memberships m WHERE m.user_id = users.id AND m.restaurant_id = ?)"
+
 func NewPostgresUserRepository(db *database.PostgresDB) *PostgresUserRepository {
 	return &PostgresUserRepository{
 		DB: db.DB,
 	}
 }
 
+func (r *PostgresUserRepository) WithTx(tx repositories.Transaction) repositories.UserRepository {
+	return &PostgresUserRepository{DB: txDB(tx, r.DB)}
+}
+
 func (r *PostgresUserRepository) Create(user *models.User) error {
 	return r.DB.Create(user).Error
 }
 
 func (r *PostgresUserRepository) FindByID(restaurantID, id uuid.UUID) (*models.User, error) {
 	var user models.User
-	if err := r.DB.Where("restaurant_id = ? AND id = ?", restaurantID, id).First(&user).Error; err != nil {
+	if err := r.DB.Where(memberOf+" AND id = ?", restaurantID, id).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, repositories.ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -38,9 +48,9 @@ func (r *PostgresUserRepository) FindByID(restaurantID, id uuid.UUID) (*models.U
 
 func (r *PostgresUserRepository) FindByEmail(restaurantID uuid.UUID, email string) (*models.User, error) {
 	var user models.User
-	if err := r.DB.Where("restaurant_id = ? AND email = ?", restaurantID, email).First(&user).Error; err != nil {
+	if err := r.DB.Where(memberOf+" AND email = ?", restaurantID, email).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, repositories.ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -51,7 +61,29 @@ func (r *PostgresUserRepository) FindByEmailGlobal(email string) (*models.User,
 	var user models.User
 	if err := r.DB.Where("email = ?", email).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, repositories.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *PostgresUserRepository) FindByIDGlobal(id uuid.UUID) (*models.User, error) {
+	var user models.User
+	if err := r.DB.Where("id = ?", id).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *PostgresUserRepository) FindByIDForUpdate(id uuid.UUID) (*models.User, error) {
+	var user models.User
+	if err := r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -62,13 +94,20 @@ func (r *PostgresUserRepository) Update(user *models.User) error {
 	return r.DB.Save(user).Error
 }
 
+func (r *PostgresUserRepository) UpdatePINAttempts(id uuid.UUID, failedAttempts int, lockedUntil *time.Time) error {
+	return r.DB.Model(&models.User{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"pin_failed_attempts": failedAttempts,
+		"pin_locked_until":    lockedUntil,
+	}).Error
+}
+
 func (r *PostgresUserRepository) Delete(restaurantID, id uuid.UUID) error {
-	return r.DB.Where("restaurant_id = ?", restaurantID).Delete(&models.User{}, id).Error
+	return r.DB.Where(memberOf, restaurantID).Delete(&models.User{}, id).Error
 }
 
 func (r *PostgresUserRepository) List(restaurantID uuid.UUID) ([]models.User, error) {
 	var users []models.User
-	if err := r.DB.Where("restaurant_id = ?", restaurantID).Find(&users).Error; err != nil {
+	if err := r.DB.Where(memberOf, restaurantID).Find(&users).Error; err != nil {
 		return nil, err
 	}
 	return users, nil
@@ -76,7 +115,7 @@ func (r *PostgresUserRepository) List(restaurantID uuid.UUID) ([]models.User, er
 
 func (r *PostgresUserRepository) FindByType(restaurantID uuid.UUID, userType models.UserType) ([]models.User, error) {
 	var users []models.User
-	if err := r.DB.Where("restaurant_id = ? AND type = ?", restaurantID, userType).Find(&users).Error; err != nil {
+	if err := r.DB.Where("EXISTS (SELECT 1 FROM memberships m WHERE m.user_id = users.id AND m.restaurant_id = ? AND m.type = ?)", restaurantID, userType).Find(&users).Error; err != nil {
 		return nil, err
 	}
 	return users, nil
@@ -92,7 +131,7 @@ func (r *PostgresUserRepository) FindByTypeGlobal(userType models.UserType) ([]m
 
 func (r *PostgresUserRepository) FindByRestaurant(restaurantID uuid.UUID) ([]models.User, error) {
 	var users []models.User
-	if err := r.DB.Where("restaurant_id = ?", restaurantID).Find(&users).Error; err != nil {
+	if err := r.DB.Where(memberOf, restaurantID).Find(&users).Error; err != nil {
 		return nil, err
 	}
 	return users, nil
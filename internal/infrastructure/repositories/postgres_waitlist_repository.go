@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresWaitlistRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresWaitlistRepository(db *database.PostgresDB) *PostgresWaitlistRepository {
+	return &PostgresWaitlistRepository{
+		DB: db.DB,
+	}
+}
+
+func (r *PostgresWaitlistRepository) WithTx(tx repositories.Transaction) repositories.WaitlistRepository {
+	return &PostgresWaitlistRepository{DB: txDB(tx, r.DB)}
+}
+
+func (r *PostgresWaitlistRepository) Create(entry *models.WaitlistEntry) error {
+	return r.DB.Create(entry).Error
+}
+
+func (r *PostgresWaitlistRepository) Update(entry *models.WaitlistEntry) error {
+	return r.DB.Save(entry).Error
+}
+
+func (r *PostgresWaitlistRepository) find(query *gorm.DB, restaurantID, id uuid.UUID) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	if err := query.Where("restaurant_id = ? AND id = ?", restaurantID, id).First(&entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrWaitlistEntryNotFound
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *PostgresWaitlistRepository) FindByID(restaurantID, id uuid.UUID) (*models.WaitlistEntry, error) {
+	return r.find(r.DB, restaurantID, id)
+}
+
+func (r *PostgresWaitlistRepository) FindByIDForUpdate(restaurantID, id uuid.UUID) (*models.WaitlistEntry, error) {
+	return r.find(r.DB.Clauses(clause.Locking{Strength: "UPDATE"}), restaurantID, id)
+}
+
+func (r *PostgresWaitlistRepository) ListActive(restaurantID uuid.UUID) ([]models.WaitlistEntry, error) {
+	var entries []models.WaitlistEntry
+	if err := r.DB.Where("restaurant_id = ? AND status IN ?", restaurantID,
+		[]models.WaitlistStatus{models.WaitlistStatusWaiting, models.WaitlistStatusNotified}).
+		Order("created_at ASC").
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *PostgresWaitlistRepository) ListSince(restaurantID uuid.UUID, since time.Time) ([]models.WaitlistEntry, error) {
+	var entries []models.WaitlistEntry
+	if err := r.DB.Where("restaurant_id = ? AND created_at >= ?", restaurantID, since).
+		Order("created_at ASC").
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"api-jet-manager/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidAddonSelection indica que as opções escolhidas não respeitam as regras dos complementos
+var ErrInvalidAddonSelection = errors.New("invalid add-on selection")
+
+// OptionSelection representa uma opção de complemento escolhida para um item do pedido
+type OptionSelection struct {
+	OptionID uuid.UUID
+	Quantity int
+}
+
+func invalidSelection(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidAddonSelection, fmt.Sprintf(format, args...))
+}
+
+// resolveAddonSelections valida as opções escolhidas contra os complementos do produto e
+// retorna o valor a ser somado ao preço unitário do produto, junto com a cópia das opções
+// que será gravada no item do pedido
+func resolveAddonSelections(addons []models.Addon, selections []OptionSelection) (models.Money, []models.OrderItemOption, error) {
+	type indexedOption struct {
+		addon  *models.Addon
+		option *models.Option
+	}
+
+	index := make(map[uuid.UUID]indexedOption)
+	for i := range addons {
+		for j := range addons[i].Options {
+			index[addons[i].Options[j].ID] = indexedOption{addon: &addons[i], option: &addons[i].Options[j]}
+		}
+	}
+
+	// Agrupar as seleções por complemento, somando quantidades repetidas
+	quantities := make(map[uuid.UUID]map[uuid.UUID]int)
+	order := make(map[uuid.UUID][]uuid.UUID)
+	for _, selection := range selections {
+		entry, ok := index[selection.OptionID]
+		if !ok {
+			return 0, nil, invalidSelection("option %s is not available for this product", selection.OptionID)
+		}
+		if !entry.option.Active {
+			return 0, nil, invalidSelection("option %s is not active", entry.option.Name)
+		}
+
+		quantity := selection.Quantity
+		if quantity <= 0 {
+			quantity = 1
+		}
+
+		addonID := entry.addon.ID
+		if quantities[addonID] == nil {
+			quantities[addonID] = make(map[uuid.UUID]int)
+		}
+		if _, seen := quantities[addonID][selection.OptionID]; !seen {
+			order[addonID] = append(order[addonID], selection.OptionID)
+		}
+		quantities[addonID][selection.OptionID] += quantity
+	}
+
+	var extra models.Money
+	var chosen []models.OrderItemOption
+
+	for i := range addons {
+		addon := &addons[i]
+		selected := quantities[addon.ID]
+
+		units := 0
+		for _, quantity := range selected {
+			units += quantity
+		}
+
+		// Em seleções com repetição cada unidade conta; nas demais conta cada opção distinta
+		count := len(selected)
+		if addon.SelectionType == models.MultipleWithRepeat {
+			count = units
+		}
+
+		if addon.Required && count == 0 {
+			return 0, nil, invalidSelection("%s is required", addon.Title)
+		}
+		// Um grupo opcional só exige o mínimo quando o cliente escolhe alguma opção
+		if (count > 0 || addon.Required) && count < addon.MinSelections {
+			return 0, nil, invalidSelection("%s requires at least %d selection(s)", addon.Title, addon.MinSelections)
+		}
+
+		maxSelections := addon.MaxSelections
+		if addon.SelectionType == models.SingleSelection {
+			maxSelections = 1
+		}
+		if maxSelections > 0 && count > maxSelections {
+			return 0, nil, invalidSelection("%s allows at most %d selection(s)", addon.Title, maxSelections)
+		}
+
+		if count == 0 {
+			continue
+		}
+
+		var sum, highest, lowest models.Money
+
+		for i, optionID := range order[addon.ID] {
+			option := index[optionID].option
+			quantity := selected[optionID]
+
+			switch addon.SelectionType {
+			case models.SingleSelection, models.MultipleNoRepeat:
+				if quantity > 1 {
+					return 0, nil, invalidSelection("%s cannot be repeated in %s", option.Name, addon.Title)
+				}
+			case models.MultipleWithRepeat:
+				if option.MaxQuantity > 0 && quantity > option.MaxQuantity {
+					return 0, nil, invalidSelection("%s allows at most %d unit(s) of %s", addon.Title, option.MaxQuantity, option.Name)
+				}
+			}
+
+			sum = sum.Add(option.Price.Mul(quantity))
+			if i == 0 || option.Price > highest {
+				highest = option.Price
+			}
+			if i == 0 || option.Price < lowest {
+				lowest = option.Price
+			}
+
+			chosen = append(chosen, models.OrderItemOption{
+				AddonID:    addon.ID,
+				OptionID:   option.ID,
+				AddonTitle: addon.Title,
+				Name:       option.Name,
+				Price:      option.Price,
+				Quantity:   quantity,
+			})
+		}
+
+		switch addon.PriceMethod {
+		case models.Average:
+			extra = extra.Add(sum.Div(units))
+		case models.Highest:
+			extra = extra.Add(highest)
+		case models.Lowest:
+			extra = extra.Add(lowest)
+		default:
+			extra = extra.Add(sum)
+		}
+	}
+
+	return extra, chosen, nil
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"api-jet-manager/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	optionBacon  = uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	optionCheese = uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	optionEgg    = uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+	optionOnion  = uuid.MustParse("00000000-0000-0000-0000-00000000000d")
+)
+
+// testAddon monta um complemento com bacon (2,00), queijo (3,00), ovo (4,01) e cebola inativa (1,00)
+func testAddon(selectionType models.SelectionType, priceMethod models.PriceMethod, min, max int, required bool) models.Addon {
+	return models.Addon{
+		ID:            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+		Title:         "Extras",
+		SelectionType: selectionType,
+		PriceMethod:   priceMethod,
+		MinSelections: min,
+		MaxSelections: max,
+		Required:      required,
+		Options: []models.Option{
+			{ID: optionBacon, Name: "Bacon", Price: 200, Active: true, MaxQuantity: 3},
+			{ID: optionCheese, Name: "Queijo", Price: 300, Active: true, MaxQuantity: 3},
+			{ID: optionEgg, Name: "Ovo", Price: 401, Active: true, MaxQuantity: 1},
+			{ID: optionOnion, Name: "Cebola", Price: 100, Active: false, MaxQuantity: 1},
+		},
+	}
+}
+
+func TestResolveAddonSelections(t *testing.T) {
+	tests := []struct {
+		name       string
+		addon      models.Addon
+		selections []OptionSelection
+		want       models.Money
+		wantErr    bool
+	}{
+		{
+			name:       "sum",
+			addon:      testAddon(models.MultipleNoRepeat, models.Sum, 0, 3, false),
+			selections: []OptionSelection{{OptionID: optionBacon}, {OptionID: optionCheese}},
+			want:       500,
+		},
+		{
+			name:       "sum with repeat counts every unit",
+			addon:      testAddon(models.MultipleWithRepeat, models.Sum, 0, 5, false),
+			selections: []OptionSelection{{OptionID: optionBacon, Quantity: 2}, {OptionID: optionCheese}},
+			want:       700,
+		},
+		{
+			name:       "average",
+			addon:      testAddon(models.MultipleNoRepeat, models.Average, 0, 3, false),
+			selections: []OptionSelection{{OptionID: optionBacon}, {OptionID: optionCheese}, {OptionID: optionEgg}},
+			want:       300, // 9,01 / 3 = 3,003
+		},
+		{
+			name:       "average rounds ties away from zero",
+			addon:      testAddon(models.MultipleNoRepeat, models.Average, 0, 3, false),
+			selections: []OptionSelection{{OptionID: optionCheese}, {OptionID: optionEgg}},
+			want:       351, // 7,01 / 2 = 3,505
+		},
+		{
+			name:       "average with repeat is per unit",
+			addon:      testAddon(models.MultipleWithRepeat, models.Average, 0, 5, false),
+			selections: []OptionSelection{{OptionID: optionBacon, Quantity: 2}, {OptionID: optionCheese}},
+			want:       233, // 7,00 / 3
+		},
+		{
+			name:       "highest",
+			addon:      testAddon(models.MultipleNoRepeat, models.Highest, 0, 3, false),
+			selections: []OptionSelection{{OptionID: optionCheese}, {OptionID: optionEgg}, {OptionID: optionBacon}},
+			want:       401,
+		},
+		{
+			name:       "lowest",
+			addon:      testAddon(models.MultipleNoRepeat, models.Lowest, 0, 3, false),
+			selections: []OptionSelection{{OptionID: optionCheese}, {OptionID: optionEgg}, {OptionID: optionBacon}},
+			want:       200,
+		},
+		{
+			name:       "single selection",
+			addon:      testAddon(models.SingleSelection, models.Sum, 0, 0, true),
+			selections: []OptionSelection{{OptionID: optionEgg}},
+			want:       401,
+		},
+		{
+			name:       "repeated selections are merged",
+			addon:      testAddon(models.MultipleWithRepeat, models.Sum, 0, 5, false),
+			selections: []OptionSelection{{OptionID: optionBacon}, {OptionID: optionBacon, Quantity: 2}},
+			want:       600,
+		},
+		{
+			name:  "optional group without selection",
+			addon: testAddon(models.MultipleNoRepeat, models.Sum, 2, 3, false),
+			want:  0,
+		},
+		{
+			name:    "required group without selection",
+			addon:   testAddon(models.SingleSelection, models.Sum, 0, 1, true),
+			wantErr: true,
+		},
+		{
+			name:       "below minimum",
+			addon:      testAddon(models.MultipleNoRepeat, models.Sum, 2, 3, false),
+			selections: []OptionSelection{{OptionID: optionBacon}},
+			wantErr:    true,
+		},
+		{
+			name:       "minimum counts units with repeat",
+			addon:      testAddon(models.MultipleWithRepeat, models.Sum, 2, 5, false),
+			selections: []OptionSelection{{OptionID: optionBacon, Quantity: 2}},
+			want:       400,
+		},
+		{
+			name:       "above maximum",
+			addon:      testAddon(models.MultipleNoRepeat, models.Sum, 0, 2, false),
+			selections: []OptionSelection{{OptionID: optionBacon}, {OptionID: optionCheese}, {OptionID: optionEgg}},
+			wantErr:    true,
+		},
+		{
+			name:       "single selection allows one option",
+			addon:      testAddon(models.SingleSelection, models.Sum, 0, 3, false),
+			selections: []OptionSelection{{OptionID: optionBacon}, {OptionID: optionCheese}},
+			wantErr:    true,
+		},
+		{
+			name:       "repeat without repetition",
+			addon:      testAddon(models.MultipleNoRepeat, models.Sum, 0, 3, false),
+			selections: []OptionSelection{{OptionID: optionBacon, Quantity: 2}},
+			wantErr:    true,
+		},
+		{
+			name:       "above option max quantity",
+			addon:      testAddon(models.MultipleWithRepeat, models.Sum, 0, 5, false),
+			selections: []OptionSelection{{OptionID: optionEgg, Quantity: 2}},
+			wantErr:    true,
+		},
+		{
+			name:       "inactive option",
+			addon:      testAddon(models.MultipleNoRepeat, models.Sum, 0, 3, false),
+			selections: []OptionSelection{{OptionID: optionOnion}},
+			wantErr:    true,
+		},
+		{
+			name:       "unknown option",
+			addon:      testAddon(models.MultipleNoRepeat, models.Sum, 0, 3, false),
+			selections: []OptionSelection{{OptionID: uuid.New()}},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		extra, chosen, err := resolveAddonSelections([]models.Addon{tt.addon}, tt.selections)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidAddonSelection) {
+				t.Errorf("%s: error = %v, want ErrInvalidAddonSelection", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if extra != tt.want {
+			t.Errorf("%s: extra = %s, want %s", tt.name, extra, tt.want)
+		}
+
+		units := 0
+		for _, option := range chosen {
+			units += option.Quantity
+		}
+		wantUnits := 0
+		for _, selection := range tt.selections {
+			if selection.Quantity <= 0 {
+				selection.Quantity = 1
+			}
+			wantUnits += selection.Quantity
+		}
+		if units != wantUnits {
+			t.Errorf("%s: chosen options have %d unit(s), want %d", tt.name, units, wantUnits)
+		}
+	}
+}
+
+func TestResolveAddonSelectionsAcrossGroups(t *testing.T) {
+	drinks := testAddon(models.SingleSelection, models.Sum, 0, 1, true)
+	drinks.ID = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	drinks.Title = "Bebida"
+	drinks.Options = []models.Option{{ID: uuid.MustParse("00000000-0000-0000-0000-0000000000e1"), Name: "Suco", Price: 650, Active: true}}
+
+	extras := testAddon(models.MultipleNoRepeat, models.Highest, 0, 3, false)
+
+	extra, chosen, err := resolveAddonSelections([]models.Addon{drinks, extras}, []OptionSelection{
+		{OptionID: optionBacon},
+		{OptionID: drinks.Options[0].ID},
+		{OptionID: optionCheese},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if extra != 950 {
+		t.Errorf("extra = %s, want 9.50", extra)
+	}
+
+	// As opções são gravadas na ordem dos complementos e, dentro de cada um, na ordem escolhida
+	want := []uuid.UUID{drinks.Options[0].ID, optionBacon, optionCheese}
+	if len(chosen) != len(want) {
+		t.Fatalf("chosen = %d option(s), want %d", len(chosen), len(want))
+	}
+	for i, option := range chosen {
+		if option.OptionID != want[i] {
+			t.Errorf("chosen[%d] = %s, want %s", i, option.OptionID, want[i])
+		}
+	}
+}
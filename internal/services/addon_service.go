@@ -0,0 +1,148 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidAddon = errors.New("invalid add-on configuration")
+
+type AddonService struct {
+	addonRepo   repositories.AddonRepository
+	productRepo repositories.ProductRepository
+}
+
+func NewAddonService(addonRepo repositories.AddonRepository, productRepo repositories.ProductRepository) *AddonService {
+	return &AddonService{
+		addonRepo:   addonRepo,
+		productRepo: productRepo,
+	}
+}
+
+// validateAddon verifica se as regras de seleção do complemento são coerentes
+func validateAddon(addon *models.Addon) error {
+	switch addon.SelectionType {
+	case models.SingleSelection, models.MultipleNoRepeat, models.MultipleWithRepeat:
+	default:
+		return fmt.Errorf("%w: invalid selection type", ErrInvalidAddon)
+	}
+
+	switch addon.PriceMethod {
+	case models.Sum, models.Average, models.Highest, models.Lowest:
+	default:
+		return fmt.Errorf("%w: invalid price method", ErrInvalidAddon)
+	}
+
+	if addon.MinSelections < 0 || addon.MaxSelections < 0 {
+		return fmt.Errorf("%w: selection limits cannot be negative", ErrInvalidAddon)
+	}
+
+	if addon.MaxSelections > 0 && addon.MinSelections > addon.MaxSelections {
+		return fmt.Errorf("%w: min selections cannot be greater than max selections", ErrInvalidAddon)
+	}
+
+	if addon.SelectionType == models.SingleSelection && (addon.MaxSelections > 1 || addon.MinSelections > 1) {
+		return fmt.Errorf("%w: single selection allows only one option", ErrInvalidAddon)
+	}
+
+	return nil
+}
+
+func validateOption(option *models.Option) error {
+	if option.Price < 0 {
+		return fmt.Errorf("%w: option price cannot be negative", ErrInvalidAddon)
+	}
+	if option.MaxQuantity < 0 {
+		return fmt.Errorf("%w: max quantity cannot be negative", ErrInvalidAddon)
+	}
+	return nil
+}
+
+// Create cria um complemento para um produto do restaurante
+func (s *AddonService) Create(restaurantID uuid.UUID, addon *models.Addon) error {
+	if _, err := s.productRepo.FindByID(restaurantID, addon.ProductID); err != nil {
+		return err
+	}
+
+	if err := validateAddon(addon); err != nil {
+		return err
+	}
+
+	for i := range addon.Options {
+		if err := validateOption(&addon.Options[i]); err != nil {
+			return err
+		}
+	}
+
+	return s.addonRepo.CreateAddon(addon)
+}
+
+func (s *AddonService) GetByID(restaurantID, id uuid.UUID) (*models.Addon, error) {
+	return s.addonRepo.GetAddonByID(restaurantID, id)
+}
+
+func (s *AddonService) ListByProduct(restaurantID, productID uuid.UUID) ([]models.Addon, error) {
+	return s.addonRepo.GetAddonsByProductID(restaurantID, productID)
+}
+
+func (s *AddonService) Update(restaurantID uuid.UUID, addon *models.Addon) error {
+	if err := validateAddon(addon); err != nil {
+		return err
+	}
+	return s.addonRepo.UpdateAddon(restaurantID, addon)
+}
+
+func (s *AddonService) Delete(restaurantID, id uuid.UUID) error {
+	return s.addonRepo.DeleteAddon(restaurantID, id)
+}
+
+// CreateOption adiciona uma opção a um complemento do restaurante
+func (s *AddonService) CreateOption(restaurantID uuid.UUID, option *models.Option) error {
+	if _, err := s.addonRepo.GetAddonByID(restaurantID, option.AddonID); err != nil {
+		return err
+	}
+
+	if err := validateOption(option); err != nil {
+		return err
+	}
+
+	return s.addonRepo.CreateOption(option)
+}
+
+func (s *AddonService) GetOption(restaurantID, addonID, id uuid.UUID) (*models.Option, error) {
+	if _, err := s.addonRepo.GetAddonByID(restaurantID, addonID); err != nil {
+		return nil, err
+	}
+	return s.addonRepo.GetOptionByID(addonID, id)
+}
+
+func (s *AddonService) ListOptions(restaurantID, addonID uuid.UUID) ([]models.Option, error) {
+	if _, err := s.addonRepo.GetAddonByID(restaurantID, addonID); err != nil {
+		return nil, err
+	}
+	return s.addonRepo.GetOptionsByAddonID(addonID)
+}
+
+func (s *AddonService) UpdateOption(restaurantID uuid.UUID, option *models.Option) error {
+	if _, err := s.addonRepo.GetAddonByID(restaurantID, option.AddonID); err != nil {
+		return err
+	}
+
+	if err := validateOption(option); err != nil {
+		return err
+	}
+
+	return s.addonRepo.UpdateOption(option)
+}
+
+func (s *AddonService) DeleteOption(restaurantID, addonID, id uuid.UUID) error {
+	if _, err := s.addonRepo.GetAddonByID(restaurantID, addonID); err != nil {
+		return err
+	}
+	return s.addonRepo.DeleteOption(addonID, id)
+}
@@ -0,0 +1,362 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/auth"
+
+	"github.com/google/uuid"
+)
+
+// authCleanupInterval é o intervalo entre as limpezas dos tokens expirados
+const authCleanupInterval = time.Hour
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	// ErrNotMember indica que o usuário não pertence ao restaurante pedido
+	ErrNotMember = errors.New("user does not belong to the restaurant")
+)
+
+// TokenPair é o par de tokens entregue no login e a cada renovação
+type TokenPair struct {
+	AccessToken      string    `json:"token"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+// ClientInfo identifica o dispositivo que abriu a sessão
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+// AuthService emite tokens de acesso de curta duração e refresh tokens rotativos,
+// e mantém a lista de tokens de acesso revogados antes de expirar
+type AuthService struct {
+	uow             repositories.UnitOfWork
+	authRepo        repositories.AuthRepository
+	userRepo        repositories.UserRepository
+	membershipRepo  repositories.MembershipRepository
+	deviceRepo      repositories.DeviceRepository
+	jwtService      *auth.JWTService
+	refreshDuration time.Duration
+}
+
+func NewAuthService(uow repositories.UnitOfWork, authRepo repositories.AuthRepository, userRepo repositories.UserRepository, membershipRepo repositories.MembershipRepository, deviceRepo repositories.DeviceRepository, jwtService *auth.JWTService, refreshDuration time.Duration) *AuthService {
+	return &AuthService{
+		uow:             uow,
+		authRepo:        authRepo,
+		userRepo:        userRepo,
+		membershipRepo:  membershipRepo,
+		deviceRepo:      deviceRepo,
+		jwtService:      jwtService,
+		refreshDuration: refreshDuration,
+	}
+}
+
+// scope retorna uma cópia do usuário com o restaurante ativo e o tipo que ele tem nesse restaurante,
+// que são os dados gravados no token. Superadmins podem abrir qualquer restaurante com o próprio tipo.
+func (s *AuthService) scope(user *models.User, restaurantID *uuid.UUID) (*models.User, error) {
+	scoped := *user
+	scoped.Memberships = nil
+	scoped.RestaurantID = restaurantID
+	if restaurantID == nil || user.Type == models.UserTypeSuperAdmin {
+		return &scoped, nil
+	}
+
+	membership, err := s.membershipRepo.Find(*restaurantID, user.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrMembershipNotFound) {
+			return nil, ErrNotMember
+		}
+		return nil, err
+	}
+	scoped.Type = membership.Type
+	return &scoped, nil
+}
+
+// defaultRestaurant escolhe o restaurante aberto no login: o restaurante padrão do usuário, se ele
+// ainda pertencer a ele, ou o primeiro dos seus restaurantes
+func (s *AuthService) defaultRestaurant(user *models.User) (*uuid.UUID, error) {
+	if user.Type == models.UserTypeSuperAdmin {
+		return user.RestaurantID, nil
+	}
+
+	memberships, err := s.membershipRepo.ListByUser(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(memberships) == 0 {
+		return nil, nil
+	}
+	for _, membership := range memberships {
+		if user.RestaurantID != nil && membership.RestaurantID == *user.RestaurantID {
+			return user.RestaurantID, nil
+		}
+	}
+	return &memberships[0].RestaurantID, nil
+}
+
+// issue emite um token de acesso e um refresh token da família informada, ambos presos ao
+// restaurante ativo do usuário
+func (s *AuthService) issue(tx repositories.Transaction, user *models.User, familyID uuid.UUID, client ClientInfo) (*TokenPair, error) {
+	accessToken, claims, err := s.jwtService.GenerateToken(user, familyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	refreshToken, hash, err := auth.NewRefreshToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	if len(client.UserAgent) > 255 {
+		client.UserAgent = client.UserAgent[:255]
+	}
+
+	stored := &models.RefreshToken{
+		UserID:       user.ID,
+		FamilyID:     familyID,
+		RestaurantID: user.RestaurantID,
+		TokenHash:    hash,
+		AccessJTI:    uuid.MustParse(claims.ID),
+		UserAgent:    client.UserAgent,
+		IP:           client.IP,
+		ExpiresAt:    time.Now().Add(s.refreshDuration),
+	}
+	if err := s.authRepo.WithTx(tx).CreateRefreshToken(stored); err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:      accessToken,
+		ExpiresAt:        claims.ExpiresAt.Time,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: stored.ExpiresAt,
+	}, nil
+}
+
+// deny inclui na lista de revogação os tokens de acesso emitidos com os refresh tokens
+// revogados que ainda não expiraram
+func (s *AuthService) deny(tx repositories.Transaction, revoked []models.RefreshToken) error {
+	now := time.Now()
+	var tokens []models.RevokedToken
+	for _, token := range revoked {
+		expiresAt := token.CreatedAt.Add(s.jwtService.TokenDuration())
+		if expiresAt.After(now) {
+			tokens = append(tokens, models.RevokedToken{JTI: token.AccessJTI, UserID: token.UserID, ExpiresAt: expiresAt})
+		}
+	}
+	return s.authRepo.WithTx(tx).DenyTokens(tokens)
+}
+
+// Login abre uma nova sessão para o usuário já autenticado no seu restaurante padrão.
+// Retorna o usuário com o restaurante ativo e o tipo que ele tem ali.
+func (s *AuthService) Login(user *models.User, client ClientInfo) (*TokenPair, *models.User, error) {
+	restaurantID, err := s.defaultRestaurant(user)
+	if err != nil {
+		return nil, nil, err
+	}
+	scoped, err := s.scope(user, restaurantID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var pair *TokenPair
+	err = s.uow.Do(func(tx repositories.Transaction) error {
+		var err error
+		pair, err = s.issue(tx, scoped, uuid.New(), client)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return pair, scoped, nil
+}
+
+// Switch troca o restaurante ativo: encerra a sessão atual e abre outra com tokens do restaurante informado
+func (s *AuthService) Switch(userID uuid.UUID, jti, sessionID string, expiresAt time.Time, restaurantID uuid.UUID, client ClientInfo) (*TokenPair, *models.User, error) {
+	user, err := s.userRepo.FindByIDGlobal(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	scoped, err := s.scope(user, &restaurantID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var pair *TokenPair
+	err = s.uow.Do(func(tx repositories.Transaction) error {
+		if err := s.endSession(tx, userID, jti, sessionID, expiresAt); err != nil {
+			return err
+		}
+		var err error
+		pair, err = s.issue(tx, scoped, uuid.New(), client)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return pair, scoped, nil
+}
+
+// Refresh troca o refresh token por um novo par. Um token já trocado indica que foi copiado:
+// nesse caso toda a sessão é revogada, inclusive o token legítimo mais recente.
+func (s *AuthService) Refresh(refreshToken string, client ClientInfo) (*TokenPair, *models.User, error) {
+	var (
+		pair   *TokenPair
+		user   *models.User
+		reused bool
+	)
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		authRepo := s.authRepo.WithTx(tx)
+
+		stored, err := authRepo.FindRefreshTokenForUpdate(auth.HashRefreshToken(refreshToken))
+		if err != nil {
+			if errors.Is(err, repositories.ErrRefreshTokenNotFound) {
+				return ErrInvalidRefreshToken
+			}
+			return err
+		}
+
+		now := time.Now()
+		if stored.RotatedAt != nil && stored.RevokedAt == nil {
+			// A revogação precisa ser confirmada, então o erro só é devolvido após o commit
+			reused = true
+			revoked, err := authRepo.RevokeFamily(stored.FamilyID, now)
+			if err != nil {
+				return err
+			}
+			return s.deny(tx, revoked)
+		}
+		if !stored.Usable(now) {
+			return ErrInvalidRefreshToken
+		}
+
+		found, err := s.userRepo.FindByIDGlobal(stored.UserID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrUserNotFound) {
+				return ErrInvalidRefreshToken
+			}
+			return err
+		}
+		// A sessão continua no mesmo restaurante enquanto o usuário pertencer a ele
+		user, err = s.scope(found, stored.RestaurantID)
+		if err != nil {
+			if errors.Is(err, ErrNotMember) {
+				return ErrInvalidRefreshToken
+			}
+			return err
+		}
+
+		stored.RotatedAt = &now
+		if err := authRepo.UpdateRefreshToken(stored); err != nil {
+			return err
+		}
+
+		pair, err = s.issue(tx, user, stored.FamilyID, client)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if reused {
+		log.Printf("Refresh token reutilizado; sessão revogada")
+		return nil, nil, ErrInvalidRefreshToken
+	}
+	return pair, user, nil
+}
+
+// endSession revoga o token de acesso informado e os refresh tokens da sua sessão
+func (s *AuthService) endSession(tx repositories.Transaction, userID uuid.UUID, jti, sessionID string, expiresAt time.Time) error {
+	authRepo := s.authRepo.WithTx(tx)
+
+	if id, err := uuid.Parse(jti); err == nil {
+		if err := authRepo.DenyTokens([]models.RevokedToken{{JTI: id, UserID: userID, ExpiresAt: expiresAt}}); err != nil {
+			return err
+		}
+	}
+
+	familyID, err := uuid.Parse(sessionID)
+	if err != nil {
+		return nil
+	}
+	revoked, err := authRepo.RevokeFamily(familyID, time.Now())
+	if err != nil {
+		return err
+	}
+	return s.deny(tx, revoked)
+}
+
+// Logout encerra a sessão do token de acesso informado e revoga o próprio token imediatamente
+func (s *AuthService) Logout(userID uuid.UUID, jti, sessionID string, expiresAt time.Time) error {
+	return s.uow.Do(func(tx repositories.Transaction) error {
+		return s.endSession(tx, userID, jti, sessionID, expiresAt)
+	})
+}
+
+// RevokeUser encerra todas as sessões do usuário; os tokens de acesso emitidos deixam de valer imediatamente.
+// Os tokens do login por PIN não têm refresh token: os terminais em que ele está conectado são bloqueados.
+func (s *AuthService) RevokeUser(userID uuid.UUID) error {
+	return s.uow.Do(func(tx repositories.Transaction) error {
+		revoked, err := s.authRepo.WithTx(tx).RevokeUser(userID, time.Now())
+		if err != nil {
+			return err
+		}
+		if err := s.deny(tx, revoked); err != nil {
+			return err
+		}
+		return s.deviceRepo.WithTx(tx).EndUserSessions(userID)
+	})
+}
+
+// RevokeRestaurantUser encerra as sessões de um usuário abertas no restaurante, inclusive as dos
+// terminais; as sessões dele em outros restaurantes continuam valendo
+func (s *AuthService) RevokeRestaurantUser(restaurantID, userID uuid.UUID) error {
+	if _, err := s.userRepo.FindByID(restaurantID, userID); err != nil {
+		return err
+	}
+	return s.uow.Do(func(tx repositories.Transaction) error {
+		revoked, err := s.authRepo.WithTx(tx).RevokeUserInRestaurant(userID, restaurantID, time.Now())
+		if err != nil {
+			return err
+		}
+		if err := s.deny(tx, revoked); err != nil {
+			return err
+		}
+		return s.deviceRepo.WithTx(tx).EndUserSessionsInRestaurant(userID, restaurantID)
+	})
+}
+
+// IsRevoked informa se o token de acesso está na lista de revogação
+func (s *AuthService) IsRevoked(jti string) (bool, error) {
+	id, err := uuid.Parse(jti)
+	if err != nil {
+		return true, nil
+	}
+	return s.authRepo.IsTokenDenied(id)
+}
+
+// RunCleanup remove periodicamente os tokens expirados até ctx ser cancelado
+func (s *AuthService) RunCleanup(ctx context.Context) {
+	ticker := time.NewTicker(authCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.authRepo.DeleteExpired(now); err != nil {
+				log.Printf("Falha ao limpar tokens expirados: %v", err)
+			}
+		}
+	}
+}
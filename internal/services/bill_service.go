@@ -0,0 +1,305 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidBill = errors.New("invalid bill")
+	// ErrBillStatus indica uma operação não permitida no status atual da conta
+	ErrBillStatus = errors.New("operation not allowed for the bill status")
+)
+
+// BillInput descreve uma conta a pagar ou a receber; DueDate é um dia do calendário
+type BillInput struct {
+	Kind         models.BillKind
+	Category     models.TransactionCategory
+	Description  string
+	Counterparty string
+	SupplierID   *uuid.UUID
+	Document     string
+	Amount       models.Money
+	DueDate      time.Time
+	Notes        string
+}
+
+// SettlementInput registra uma baixa; Amount zero baixa todo o saldo e PaidAt vazio usa o momento atual
+type SettlementInput struct {
+	Amount        models.Money
+	PaymentMethod string
+	PaidAt        *time.Time
+	Notes         string
+}
+
+// AttachmentInput traz os dados de um arquivo já enviado para o armazenamento externo
+type AttachmentInput struct {
+	FileName    string
+	ContentType string
+	Size        int64
+	URL         string
+}
+
+type BillService struct {
+	uow            repositories.UnitOfWork
+	billRepo       repositories.BillRepository
+	purchaseRepo   repositories.PurchaseRepository
+	financeService *FinanceService
+}
+
+func NewBillService(uow repositories.UnitOfWork, billRepo repositories.BillRepository, purchaseRepo repositories.PurchaseRepository, financeService *FinanceService) *BillService {
+	return &BillService{
+		uow:            uow,
+		billRepo:       billRepo,
+		purchaseRepo:   purchaseRepo,
+		financeService: financeService,
+	}
+}
+
+// apply valida a entrada e a copia para a conta
+func (s *BillService) apply(tx repositories.Transaction, bill *models.Bill, in BillInput) error {
+	in.Description = strings.TrimSpace(in.Description)
+	switch {
+	case !in.Kind.Valid():
+		return fmt.Errorf("%w: invalid kind", ErrInvalidBill)
+	case !in.Category.Valid():
+		return fmt.Errorf("%w: invalid category", ErrInvalidBill)
+	case in.Description == "":
+		return fmt.Errorf("%w: description is required", ErrInvalidBill)
+	case len(in.Description) > 255:
+		return fmt.Errorf("%w: description must have at most 255 characters", ErrInvalidBill)
+	case len(in.Counterparty) > 100, len(in.Document) > 100:
+		return fmt.Errorf("%w: counterparty and document must have at most 100 characters", ErrInvalidBill)
+	case in.Amount <= 0:
+		return fmt.Errorf("%w: amount must be greater than zero", ErrInvalidBill)
+	case in.Amount < bill.PaidAmount:
+		return fmt.Errorf("%w: amount cannot be less than the %s already settled", ErrInvalidBill, bill.PaidAmount)
+	case in.DueDate.IsZero():
+		return fmt.Errorf("%w: due date is required", ErrInvalidBill)
+	case bill.PaidAmount > 0 && in.Kind != bill.Kind:
+		return fmt.Errorf("%w: kind cannot change after settlements", ErrInvalidBill)
+	}
+
+	if in.SupplierID != nil {
+		supplier, err := s.purchaseRepo.WithTx(tx).FindSupplierByID(bill.RestaurantID, *in.SupplierID)
+		if err != nil {
+			return err
+		}
+		if in.Counterparty == "" {
+			in.Counterparty = supplier.Name
+		}
+	}
+
+	bill.Kind = in.Kind
+	bill.Category = in.Category
+	bill.Description = in.Description
+	bill.Counterparty = in.Counterparty
+	bill.SupplierID = in.SupplierID
+	bill.Document = in.Document
+	bill.Amount = in.Amount
+	bill.DueDate = models.Day(in.DueDate)
+	bill.Notes = in.Notes
+	return nil
+}
+
+func (s *BillService) Create(restaurantID, actorID uuid.UUID, in BillInput) (*models.Bill, error) {
+	bill := &models.Bill{RestaurantID: restaurantID, UserID: actorID}
+	if err := s.apply(nil, bill, in); err != nil {
+		return nil, err
+	}
+	if err := s.billRepo.Create(bill); err != nil {
+		return nil, err
+	}
+	return s.billRepo.FindByID(restaurantID, bill.ID)
+}
+
+func (s *BillService) Get(restaurantID, id uuid.UUID) (*models.Bill, error) {
+	return s.billRepo.FindByID(restaurantID, id)
+}
+
+func (s *BillService) List(restaurantID uuid.UUID, filter repositories.BillFilter) ([]models.Bill, error) {
+	return s.billRepo.List(restaurantID, filter)
+}
+
+// DueThisWeek lista as contas em aberto que vencem de hoje até o domingo desta semana
+func (s *BillService) DueThisWeek(restaurantID uuid.UUID, kind models.BillKind) ([]models.Bill, error) {
+	today := models.Day(time.Now())
+	sunday := today.AddDate(0, 0, (7-int(today.Weekday()))%7)
+	return s.billRepo.List(restaurantID, repositories.BillFilter{
+		Kind:    kind,
+		Unpaid:  true,
+		DueFrom: &today,
+		DueTo:   &sunday,
+	})
+}
+
+// Overdue lista as contas em aberto vencidas
+func (s *BillService) Overdue(restaurantID uuid.UUID, kind models.BillKind) ([]models.Bill, error) {
+	return s.billRepo.List(restaurantID, repositories.BillFilter{Kind: kind, Status: models.BillStatusOverdue})
+}
+
+// Update altera os dados da conta enquanto ela aceita baixas; as baixas já feitas são mantidas
+func (s *BillService) Update(restaurantID, id uuid.UUID, in BillInput) (*models.Bill, error) {
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		billRepo := s.billRepo.WithTx(tx)
+
+		bill, err := billRepo.FindByIDForUpdate(restaurantID, id)
+		if err != nil {
+			return err
+		}
+		if !bill.Settleable() {
+			return fmt.Errorf("%w: bill is %s", ErrBillStatus, bill.Status)
+		}
+
+		if err := s.apply(tx, bill, in); err != nil {
+			return err
+		}
+		return billRepo.Update(bill)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.billRepo.FindByID(restaurantID, id)
+}
+
+// Settle registra uma baixa da conta e lança a receita ou despesa correspondente na mesma transação
+func (s *BillService) Settle(restaurantID, id, actorID uuid.UUID, in SettlementInput) (*models.Bill, error) {
+	if in.Amount < 0 {
+		return nil, fmt.Errorf("%w: settlement amount cannot be negative", ErrInvalidBill)
+	}
+	if len(in.PaymentMethod) > 30 || len(in.Notes) > 255 {
+		return nil, fmt.Errorf("%w: payment method must have at most 30 characters and notes at most 255", ErrInvalidBill)
+	}
+
+	paidAt := time.Now()
+	if in.PaidAt != nil {
+		if in.PaidAt.After(paidAt) {
+			return nil, fmt.Errorf("%w: settlement date cannot be in the future", ErrInvalidBill)
+		}
+		paidAt = *in.PaidAt
+	}
+
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		billRepo := s.billRepo.WithTx(tx)
+
+		bill, err := billRepo.FindByIDForUpdate(restaurantID, id)
+		if err != nil {
+			return err
+		}
+		if !bill.Settleable() {
+			return fmt.Errorf("%w: bill is %s", ErrBillStatus, bill.Status)
+		}
+
+		amount := in.Amount
+		if amount.IsZero() {
+			amount = bill.Remaining()
+		}
+		if amount > bill.Remaining() {
+			return fmt.Errorf("%w: settlement exceeds the remaining %s", ErrInvalidBill, bill.Remaining())
+		}
+
+		transaction := &models.FinancialTransaction{
+			RestaurantID:  restaurantID,
+			Type:          bill.Kind.TransactionType(),
+			Category:      bill.Category,
+			Amount:        amount,
+			Description:   bill.Description,
+			UserID:        actorID,
+			PaymentMethod: in.PaymentMethod,
+			Date:          paidAt,
+		}
+		if err := s.financeService.record(tx, transaction); err != nil {
+			return fmt.Errorf("failed to register settlement: %w", err)
+		}
+
+		if err := billRepo.CreateSettlement(&models.BillSettlement{
+			RestaurantID:           restaurantID,
+			BillID:                 bill.ID,
+			Amount:                 amount,
+			PaymentMethod:          in.PaymentMethod,
+			PaidAt:                 paidAt,
+			Notes:                  in.Notes,
+			FinancialTransactionID: &transaction.ID,
+			UserID:                 actorID,
+		}); err != nil {
+			return err
+		}
+
+		bill.PaidAmount = bill.PaidAmount.Add(amount)
+		if bill.Remaining().IsZero() {
+			bill.PaidAt = &paidAt
+		}
+		return billRepo.Update(bill)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.billRepo.FindByID(restaurantID, id)
+}
+
+// Cancel encerra uma conta que não será quitada; as baixas parciais continuam lançadas
+func (s *BillService) Cancel(restaurantID, id uuid.UUID) (*models.Bill, error) {
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		billRepo := s.billRepo.WithTx(tx)
+
+		bill, err := billRepo.FindByIDForUpdate(restaurantID, id)
+		if err != nil {
+			return err
+		}
+		if !bill.Settleable() {
+			return fmt.Errorf("%w: bill is already %s", ErrBillStatus, bill.Status)
+		}
+
+		now := time.Now()
+		bill.Status = models.BillStatusCancelled
+		bill.CancelledAt = &now
+		return billRepo.Update(bill)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.billRepo.FindByID(restaurantID, id)
+}
+
+func (s *BillService) AddAttachment(restaurantID, billID, actorID uuid.UUID, in AttachmentInput) (*models.BillAttachment, error) {
+	in.FileName = strings.TrimSpace(in.FileName)
+	switch {
+	case in.FileName == "" || in.URL == "":
+		return nil, fmt.Errorf("%w: attachment file name and URL are required", ErrInvalidBill)
+	case len(in.FileName) > 255, len(in.ContentType) > 100, len(in.URL) > 1024:
+		return nil, fmt.Errorf("%w: attachment metadata is too long", ErrInvalidBill)
+	case in.Size < 0:
+		return nil, fmt.Errorf("%w: attachment size cannot be negative", ErrInvalidBill)
+	}
+
+	if _, err := s.billRepo.FindByID(restaurantID, billID); err != nil {
+		return nil, err
+	}
+
+	attachment := &models.BillAttachment{
+		BillID:      billID,
+		FileName:    in.FileName,
+		ContentType: in.ContentType,
+		Size:        in.Size,
+		URL:         in.URL,
+		UserID:      actorID,
+	}
+	if err := s.billRepo.CreateAttachment(attachment); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func (s *BillService) DeleteAttachment(restaurantID, billID, id uuid.UUID) error {
+	if _, err := s.billRepo.FindByID(restaurantID, billID); err != nil {
+		return err
+	}
+	return s.billRepo.DeleteAttachment(billID, id)
+}
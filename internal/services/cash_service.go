@@ -0,0 +1,314 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidCashSession = errors.New("invalid cash session operation")
+	ErrCashSessionClosed  = errors.New("cash session is closed")
+	ErrCashRegisterInUse  = errors.New("register already has an open cash session")
+	// ErrCashSessionRequired indica um pagamento em dinheiro sem turno de caixa aberto para recebê-lo
+	ErrCashSessionRequired = errors.New("an open cash session is required")
+)
+
+// CashCloseInput traz os valores contados no fechamento; formas de pagamento ausentes contam zero
+type CashCloseInput struct {
+	Counted map[models.PaymentMethod]models.Money
+	Notes   string
+}
+
+// CashSummary é a posição do turno: o que deveria haver no caixa em cada forma de pagamento
+type CashSummary struct {
+	Session  *models.CashSession                   `json:"session"`
+	Payments map[models.PaymentMethod]models.Money `json:"payments"`
+	CashIn   models.Money                          `json:"cash_in"`
+	CashOut  models.Money                          `json:"cash_out"`
+	Expected map[models.PaymentMethod]models.Money `json:"expected"`
+}
+
+// CashService controla os turnos de caixa: abertura com fundo de troco, suprimentos e sangrias,
+// e o fechamento com a conferência do esperado contra o contado em cada forma de pagamento
+type CashService struct {
+	uow      repositories.UnitOfWork
+	cashRepo repositories.CashRepository
+}
+
+func NewCashService(uow repositories.UnitOfWork, cashRepo repositories.CashRepository) *CashService {
+	return &CashService{
+		uow:      uow,
+		cashRepo: cashRepo,
+	}
+}
+
+// expectedTotals calcula o esperado por forma de pagamento; em dinheiro soma o fundo de troco
+// e os suprimentos e desconta as sangrias
+func expectedTotals(session *models.CashSession, totals *repositories.CashTotals) map[models.PaymentMethod]models.Money {
+	expected := make(map[models.PaymentMethod]models.Money, len(totals.Payments)+1)
+	for method, amount := range totals.Payments {
+		expected[method] = amount
+	}
+	expected[models.PaymentMethodCash] = expected[models.PaymentMethodCash].
+		Add(session.OpeningFloat).Add(totals.CashIn).Sub(totals.CashOut)
+	return expected
+}
+
+// Open abre um turno no caixa informado; cada caixa tem no máximo um turno aberto
+func (s *CashService) Open(restaurantID, userID uuid.UUID, register string, openingFloat models.Money, notes string) (*models.CashSession, error) {
+	register = strings.TrimSpace(register)
+	if register == "" {
+		register = models.DefaultCashRegister
+	}
+	switch {
+	case len(register) > 50:
+		return nil, fmt.Errorf("%w: register must have at most 50 characters", ErrInvalidCashSession)
+	case openingFloat.IsNegative():
+		return nil, fmt.Errorf("%w: opening float cannot be negative", ErrInvalidCashSession)
+	case len(notes) > 255:
+		return nil, fmt.Errorf("%w: notes must have at most 255 characters", ErrInvalidCashSession)
+	}
+
+	session := &models.CashSession{
+		RestaurantID: restaurantID,
+		Register:     register,
+		Status:       models.CashSessionStatusOpen,
+		OpeningFloat: openingFloat,
+		Notes:        notes,
+		OpenedBy:     userID,
+		OpenedAt:     time.Now(),
+	}
+
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		cashRepo := s.cashRepo.WithTx(tx)
+
+		open, err := cashRepo.FindOpenSessions(restaurantID)
+		if err != nil {
+			return err
+		}
+		for _, existing := range open {
+			if existing.Register == register {
+				return fmt.Errorf("%w: %s", ErrCashRegisterInUse, register)
+			}
+		}
+
+		return cashRepo.CreateSession(session)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// AddMovement registra um suprimento (cash_in) ou uma sangria (cash_out) no turno aberto.
+// A sangria não pode retirar mais dinheiro do que o esperado no caixa.
+func (s *CashService) AddMovement(restaurantID, sessionID, userID uuid.UUID, movementType models.CashMovementType, amount models.Money, reason string) (*models.CashMovement, error) {
+	switch {
+	case movementType != models.CashMovementIn && movementType != models.CashMovementOut:
+		return nil, fmt.Errorf("%w: type must be cash_in or cash_out", ErrInvalidCashSession)
+	case amount <= 0:
+		return nil, fmt.Errorf("%w: amount must be greater than zero", ErrInvalidCashSession)
+	case len(reason) > 255:
+		return nil, fmt.Errorf("%w: reason must have at most 255 characters", ErrInvalidCashSession)
+	}
+
+	movement := &models.CashMovement{
+		RestaurantID: restaurantID,
+		SessionID:    sessionID,
+		Type:         movementType,
+		Amount:       amount,
+		Reason:       reason,
+		UserID:       userID,
+	}
+
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		cashRepo := s.cashRepo.WithTx(tx)
+
+		session, err := cashRepo.FindSessionByIDForUpdate(restaurantID, sessionID)
+		if err != nil {
+			return err
+		}
+		if !session.IsOpen() {
+			return ErrCashSessionClosed
+		}
+
+		if movementType == models.CashMovementOut {
+			totals, err := cashRepo.Totals(session.ID)
+			if err != nil {
+				return err
+			}
+			if available := expectedTotals(session, totals)[models.PaymentMethodCash]; amount > available {
+				return fmt.Errorf("%w: cash out %s exceeds the %s expected in the drawer", ErrInvalidCashSession, amount, available)
+			}
+		}
+
+		return cashRepo.CreateMovement(movement)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+// Summary retorna a posição atual do turno, aberto ou fechado
+func (s *CashService) Summary(restaurantID, sessionID uuid.UUID) (*CashSummary, error) {
+	session, err := s.cashRepo.FindSessionByID(restaurantID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.cashRepo.Totals(session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CashSummary{
+		Session:  session,
+		Payments: totals.Payments,
+		CashIn:   totals.CashIn,
+		CashOut:  totals.CashOut,
+		Expected: expectedTotals(session, totals),
+	}, nil
+}
+
+// Close fecha o turno registrando, para cada forma de pagamento, o esperado, o contado e a diferença.
+// Depois do fechamento o turno não recebe pagamentos nem movimentações.
+func (s *CashService) Close(restaurantID, sessionID, userID uuid.UUID, in CashCloseInput) (*CashSummary, error) {
+	for method, amount := range in.Counted {
+		if !validPaymentMethod(method) {
+			return nil, fmt.Errorf("%w: unknown payment method %q", ErrInvalidCashSession, method)
+		}
+		if amount.IsNegative() {
+			return nil, fmt.Errorf("%w: counted amount cannot be negative", ErrInvalidCashSession)
+		}
+	}
+	if len(in.Notes) > 255 {
+		return nil, fmt.Errorf("%w: notes must have at most 255 characters", ErrInvalidCashSession)
+	}
+
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		cashRepo := s.cashRepo.WithTx(tx)
+
+		session, err := cashRepo.FindSessionByIDForUpdate(restaurantID, sessionID)
+		if err != nil {
+			return err
+		}
+		if !session.IsOpen() {
+			return ErrCashSessionClosed
+		}
+
+		totals, err := cashRepo.Totals(session.ID)
+		if err != nil {
+			return err
+		}
+		expected := expectedTotals(session, totals)
+
+		methods := make([]models.PaymentMethod, 0, len(expected)+len(in.Counted))
+		for method := range expected {
+			methods = append(methods, method)
+		}
+		for method := range in.Counted {
+			if _, ok := expected[method]; !ok {
+				methods = append(methods, method)
+			}
+		}
+		sort.Slice(methods, func(i, j int) bool { return methods[i] < methods[j] })
+
+		session.ExpectedTotal, session.CountedTotal = 0, 0
+		counts := make([]models.CashSessionCount, 0, len(methods))
+		for _, method := range methods {
+			counted := in.Counted[method]
+			counts = append(counts, models.CashSessionCount{
+				SessionID:  session.ID,
+				Method:     method,
+				Expected:   expected[method],
+				Counted:    counted,
+				Difference: counted.Sub(expected[method]),
+			})
+			session.ExpectedTotal = session.ExpectedTotal.Add(expected[method])
+			session.CountedTotal = session.CountedTotal.Add(counted)
+		}
+
+		if err := cashRepo.CreateCounts(counts); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		session.Status = models.CashSessionStatusClosed
+		session.Difference = session.CountedTotal.Sub(session.ExpectedTotal)
+		session.ClosedBy = &userID
+		session.ClosedAt = &now
+		if in.Notes != "" {
+			session.Notes = in.Notes
+		}
+		return cashRepo.UpdateSession(session)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Summary(restaurantID, sessionID)
+}
+
+func (s *CashService) List(restaurantID uuid.UUID, filter repositories.CashSessionFilter) ([]models.CashSession, error) {
+	return s.cashRepo.ListSessions(restaurantID, filter)
+}
+
+// sessionForPayment escolhe e bloqueia o turno que recebe um pagamento: o informado, o turno aberto
+// pelo próprio operador ou o único turno aberto do restaurante. Pagamentos em dinheiro exigem um turno;
+// nas demais formas o pagamento fica sem turno quando não há como escolher um.
+func (s *CashService) sessionForPayment(tx repositories.Transaction, restaurantID uuid.UUID, requested *uuid.UUID, actorID uuid.UUID, method models.PaymentMethod) (*models.CashSession, error) {
+	cashRepo := s.cashRepo.WithTx(tx)
+
+	sessionID := requested
+	if sessionID == nil {
+		open, err := cashRepo.FindOpenSessions(restaurantID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range open {
+			if open[i].OpenedBy == actorID {
+				sessionID = &open[i].ID
+				break
+			}
+		}
+		if sessionID == nil && len(open) == 1 {
+			sessionID = &open[0].ID
+		}
+	}
+
+	if sessionID == nil {
+		if method == models.PaymentMethodCash {
+			return nil, ErrCashSessionRequired
+		}
+		return nil, nil
+	}
+
+	session, err := cashRepo.FindSessionByIDForUpdate(restaurantID, *sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsOpen() {
+		return nil, ErrCashSessionClosed
+	}
+	return session, nil
+}
+
+// ensureOpen bloqueia o turno e garante que ele ainda aceita alterações
+func (s *CashService) ensureOpen(tx repositories.Transaction, restaurantID, sessionID uuid.UUID) error {
+	session, err := s.cashRepo.WithTx(tx).FindSessionByIDForUpdate(restaurantID, sessionID)
+	if err != nil {
+		return err
+	}
+	if !session.IsOpen() {
+		return ErrCashSessionClosed
+	}
+	return nil
+}
@@ -0,0 +1,361 @@
+package services
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/auth"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// deviceTouchInterval evita gravar o acesso e a atividade do terminal a cada requisição; por isso o
+	// bloqueio automático pode acontecer até esse intervalo depois do previsto
+	deviceTouchInterval = 30 * time.Second
+	// pairingCodeDuration é a validade do código de pareamento
+	pairingCodeDuration = 10 * time.Minute
+	// pairingMaxAttempts é o limite de códigos errados com o seletor de um terminal; ao atingi-lo o
+	// código deixa de valer e precisa ser gerado de novo no painel
+	pairingMaxAttempts = 5
+	// pairingSelectorTries limita as novas tentativas quando o seletor gerado já está em uso
+	pairingSelectorTries = 5
+)
+
+var (
+	ErrInvalidDevice      = errors.New("invalid device")
+	ErrInvalidPairingCode = errors.New("invalid or expired pairing code")
+	// ErrDeviceScope indica que o tipo do terminal não permite a ação
+	ErrDeviceScope = errors.New("device is not allowed to perform this action")
+)
+
+// DeviceInput descreve um terminal; sem tipo vale pos e sem tempo de bloqueio vale models.DefaultAutoLockMinutes
+type DeviceInput struct {
+	Name            string
+	Kind            models.DeviceKind
+	AutoLockMinutes int
+}
+
+// Pairing é o código que o terminal informa para receber a sua credencial
+type Pairing struct {
+	Code      string    `json:"pairing_code"`
+	ExpiresAt time.Time `json:"pairing_expires_at"`
+}
+
+// DeviceService mantém os terminais dos restaurantes, o pareamento e as credenciais de cada um
+// e as sessões abertas com PIN nos terminais do salão
+type DeviceService struct {
+	uow        repositories.UnitOfWork
+	deviceRepo repositories.DeviceRepository
+}
+
+func NewDeviceService(uow repositories.UnitOfWork, deviceRepo repositories.DeviceRepository) *DeviceService {
+	return &DeviceService{
+		uow:        uow,
+		deviceRepo: deviceRepo,
+	}
+}
+
+// apply valida a entrada e a copia para o terminal
+func (s *DeviceService) apply(device *models.Device, in DeviceInput) error {
+	in.Name = strings.TrimSpace(in.Name)
+	if in.Kind == "" {
+		in.Kind = models.DeviceKindPOS
+	}
+	if in.AutoLockMinutes == 0 {
+		in.AutoLockMinutes = models.DefaultAutoLockMinutes
+	}
+	switch {
+	case in.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidDevice)
+	case len(in.Name) > 100:
+		return fmt.Errorf("%w: name must have at most 100 characters", ErrInvalidDevice)
+	case !in.Kind.Valid():
+		return fmt.Errorf("%w: invalid kind %q", ErrInvalidDevice, in.Kind)
+	case in.AutoLockMinutes < 1 || in.AutoLockMinutes > 60:
+		return fmt.Errorf("%w: auto_lock_minutes must be between 1 and 60", ErrInvalidDevice)
+	}
+
+	device.Name = in.Name
+	device.Kind = in.Kind
+	device.AutoLockMinutes = in.AutoLockMinutes
+	return nil
+}
+
+// newPairing gera um código de pareamento para o terminal; o código anterior deixa de valer.
+// O seletor precisa ser único entre os códigos pendentes, então um seletor em uso é sorteado de novo.
+func (s *DeviceService) newPairing(deviceRepo repositories.DeviceRepository, device *models.Device) (*Pairing, error) {
+	for try := 0; try < pairingSelectorTries; try++ {
+		code, selector, hash, err := auth.NewPairingCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate pairing code: %w", err)
+		}
+
+		other, err := deviceRepo.FindByPairingSelector(selector)
+		if err != nil && !errors.Is(err, repositories.ErrDeviceNotFound) {
+			return nil, err
+		}
+		if other != nil && other.ID != device.ID {
+			continue
+		}
+
+		expiresAt := time.Now().Add(pairingCodeDuration)
+		device.PairingSelector = &selector
+		device.PairingCodeHash = &hash
+		device.PairingAttempts = 0
+		device.PairingExpiresAt = &expiresAt
+		return &Pairing{Code: code, ExpiresAt: expiresAt}, nil
+	}
+	return nil, errors.New("failed to generate pairing code: no free selector")
+}
+
+// clearPairing invalida o código de pareamento do terminal
+func clearPairing(device *models.Device) {
+	device.PairingSelector = nil
+	device.PairingCodeHash = nil
+	device.PairingAttempts = 0
+	device.PairingExpiresAt = nil
+}
+
+func (s *DeviceService) List(restaurantID uuid.UUID) ([]models.Device, error) {
+	return s.deviceRepo.List(restaurantID)
+}
+
+func (s *DeviceService) Get(restaurantID, id uuid.UUID) (*models.Device, error) {
+	return s.deviceRepo.FindByID(restaurantID, id)
+}
+
+// Register cadastra um terminal e retorna o código para pareá-lo. As ações do terminal que precisam
+// de um responsável ficam registradas em nome de quem o cadastrou.
+func (s *DeviceService) Register(restaurantID uuid.UUID, actor Actor, in DeviceInput) (*models.Device, *Pairing, error) {
+	device := &models.Device{RestaurantID: restaurantID, RegisteredByID: &actor.ID}
+	if err := s.apply(device, in); err != nil {
+		return nil, nil, err
+	}
+
+	pairing, err := s.newPairing(s.deviceRepo, device)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.deviceRepo.Create(device); err != nil {
+		return nil, nil, err
+	}
+
+	device, err = s.deviceRepo.FindByID(restaurantID, device.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return device, pairing, nil
+}
+
+func (s *DeviceService) Update(restaurantID, id uuid.UUID, in DeviceInput) (*models.Device, error) {
+	device, err := s.deviceRepo.FindByID(restaurantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(device, in); err != nil {
+		return nil, err
+	}
+
+	if err := s.deviceRepo.Update(device); err != nil {
+		return nil, err
+	}
+	return s.deviceRepo.FindByID(restaurantID, id)
+}
+
+func (s *DeviceService) Delete(restaurantID, id uuid.UUID) error {
+	if _, err := s.deviceRepo.FindByID(restaurantID, id); err != nil {
+		return err
+	}
+	return s.deviceRepo.Delete(restaurantID, id)
+}
+
+// RenewPairing gera um novo código para parear o terminal de novo, por exemplo após uma revogação
+// ou a troca do aparelho. A credencial atual continua valendo até o novo pareamento.
+func (s *DeviceService) RenewPairing(restaurantID, id uuid.UUID) (*models.Device, *Pairing, error) {
+	var pairing *Pairing
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		deviceRepo := s.deviceRepo.WithTx(tx)
+
+		device, err := deviceRepo.FindByIDForUpdate(restaurantID, id)
+		if err != nil {
+			return err
+		}
+		if pairing, err = s.newPairing(deviceRepo, device); err != nil {
+			return err
+		}
+		return deviceRepo.Update(device)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	device, err := s.deviceRepo.FindByID(restaurantID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return device, pairing, nil
+}
+
+// Pair troca o código de pareamento pela credencial do terminal. O token só é exibido aqui; o banco
+// guarda apenas o hash. Uma credencial anterior do mesmo terminal deixa de valer. Códigos errados
+// com o seletor de um terminal contam tentativas; ao atingir pairingMaxAttempts o código é invalidado.
+func (s *DeviceService) Pair(code, ip string) (*models.Device, string, error) {
+	var (
+		device  *models.Device
+		token   string
+		failure error
+	)
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		deviceRepo := s.deviceRepo.WithTx(tx)
+
+		var err error
+		device, err = deviceRepo.FindByPairingSelectorForUpdate(auth.PairingSelector(code))
+		if err != nil {
+			if errors.Is(err, repositories.ErrDeviceNotFound) {
+				return ErrInvalidPairingCode
+			}
+			return err
+		}
+
+		now := time.Now()
+		if device.PairingExpiresAt == nil || now.After(*device.PairingExpiresAt) {
+			return ErrInvalidPairingCode
+		}
+
+		if device.PairingCodeHash == nil ||
+			subtle.ConstantTimeCompare([]byte(*device.PairingCodeHash), []byte(auth.HashPairingCode(code))) != 1 {
+			// As tentativas precisam ser gravadas, então o erro só é devolvido após o commit
+			failure = ErrInvalidPairingCode
+			device.PairingAttempts++
+			if device.PairingAttempts >= pairingMaxAttempts {
+				clearPairing(device)
+			}
+			return deviceRepo.Update(device)
+		}
+
+		var hash string
+		token, hash, err = auth.NewDeviceToken()
+		if err != nil {
+			return fmt.Errorf("failed to generate device token: %w", err)
+		}
+
+		clearPairing(device)
+		device.TokenHash = &hash
+		device.PairedAt = &now
+		device.RevokedAt = nil
+		device.LastSeenAt = &now
+		device.LastSeenIP = ip
+		device.ActiveUserID = nil
+		device.SessionJTI = nil
+		return deviceRepo.Update(device)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if failure != nil {
+		return nil, "", failure
+	}
+
+	device, err = s.deviceRepo.FindByID(device.RestaurantID, device.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	return device, token, nil
+}
+
+// Revoke revoga a credencial do terminal, por exemplo após a perda do aparelho. O terminal deixa de
+// acessar a API imediatamente, e o funcionário conectado nele também.
+func (s *DeviceService) Revoke(restaurantID, id uuid.UUID) (*models.Device, error) {
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		deviceRepo := s.deviceRepo.WithTx(tx)
+
+		device, err := deviceRepo.FindByIDForUpdate(restaurantID, id)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		clearPairing(device)
+		device.TokenHash = nil
+		device.RevokedAt = &now
+		device.ActiveUserID = nil
+		device.SessionJTI = nil
+		return deviceRepo.Update(device)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.deviceRepo.FindByID(restaurantID, id)
+}
+
+// Authenticate localiza o terminal pela credencial e registra o acesso. Retorna nil se a
+// credencial não pertencer a um terminal pareado.
+func (s *DeviceService) Authenticate(token, ip string) (*models.Device, error) {
+	device, err := s.deviceRepo.FindByToken(auth.HashDeviceToken(token))
+	if err != nil {
+		if errors.Is(err, repositories.ErrDeviceNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if device.LastSeenAt == nil || now.Sub(*device.LastSeenAt) >= deviceTouchInterval || device.LastSeenIP != ip {
+		if err := s.deviceRepo.Seen(device.ID, now, ip); err != nil {
+			return nil, err
+		}
+		device.LastSeenAt = &now
+		device.LastSeenIP = ip
+	}
+	return device, nil
+}
+
+// Lock bloqueia o terminal; o funcionário conectado precisa informar o PIN de novo
+func (s *DeviceService) Lock(device *models.Device) error {
+	if device.SessionJTI == nil {
+		return nil
+	}
+	return s.deviceRepo.EndSession(device.ID, *device.SessionJTI)
+}
+
+// SessionActive informa se o token emitido no login por PIN ainda vale: ele precisa ser o do último
+// desbloqueio do terminal, e o terminal não pode ter passado do tempo de inatividade. Um terminal
+// inativo é bloqueado aqui; nos demais casos a atividade é registrada.
+func (s *DeviceService) SessionActive(restaurantID *uuid.UUID, deviceID, jti string) (bool, error) {
+	id, err := uuid.Parse(deviceID)
+	if err != nil || restaurantID == nil {
+		return false, nil
+	}
+	tokenID, err := uuid.Parse(jti)
+	if err != nil {
+		return false, nil
+	}
+
+	device, err := s.deviceRepo.FindByID(*restaurantID, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrDeviceNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if device.SessionJTI == nil || *device.SessionJTI != tokenID {
+		return false, nil
+	}
+
+	now := time.Now()
+	if device.Idle(now) {
+		return false, s.deviceRepo.EndSession(device.ID, tokenID)
+	}
+	if now.Sub(*device.LastActivityAt) >= deviceTouchInterval {
+		if err := s.deviceRepo.Touch(device.ID, now); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
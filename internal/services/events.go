@@ -0,0 +1,87 @@
+package services
+
+import (
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/realtime"
+
+	"github.com/google/uuid"
+)
+
+// Tipos dos eventos publicados em tempo real
+const (
+	EventOrderCreated       = "order.created"
+	EventOrderStatusChanged = "order.status_changed"
+	EventOrderItemAdded     = "order.item_added"
+	EventOrderItemRemoved   = "order.item_removed"
+	EventOrderItemMoved     = "order.item_moved"
+	EventOrderTableChanged  = "order.table_changed"
+	EventOrderMerged        = "order.merged"
+	EventPaymentRegistered  = "payment.registered"
+	EventPaymentVoided      = "payment.voided"
+	EventTableCreated       = "table.created"
+	EventTableUpdated       = "table.updated"
+	EventTableDeleted       = "table.deleted"
+	EventReservationCreated = "reservation.created"
+	EventReservationUpdated = "reservation.updated"
+	EventWaitlistCreated    = "waitlist.created"
+	EventWaitlistUpdated    = "waitlist.updated"
+)
+
+// OrderItemEvent é o payload dos eventos de itens adicionados ou removidos
+type OrderItemEvent struct {
+	OrderID     uuid.UUID         `json:"order_id"`
+	Item        *models.OrderItem `json:"item"`
+	TotalAmount models.Money      `json:"total_amount"`
+}
+
+// OrderItemMovedEvent é o payload do evento de item movido entre pedidos
+type OrderItemMovedEvent struct {
+	FromOrderID uuid.UUID         `json:"from_order_id"`
+	ToOrderID   uuid.UUID         `json:"to_order_id"`
+	Item        *models.OrderItem `json:"item"`
+	FromTotal   models.Money      `json:"from_total"`
+	ToTotal     models.Money      `json:"to_total"`
+}
+
+// OrderMergedEvent é o payload do evento de junção de pedidos
+type OrderMergedEvent struct {
+	SourceOrderID uuid.UUID     `json:"source_order_id"`
+	Order         *models.Order `json:"order"`
+}
+
+// PaymentEvent é o payload dos eventos de pagamento
+type PaymentEvent struct {
+	OrderID    uuid.UUID       `json:"order_id"`
+	Payment    *models.Payment `json:"payment"`
+	AmountPaid models.Money    `json:"amount_paid"`
+	Balance    models.Money    `json:"balance"`
+}
+
+// publishAfterCommit adia a publicação até a confirmação da transação,
+// para que nenhuma tela veja um estado que acabou sendo desfeito
+func publishAfterCommit(tx repositories.Transaction, events realtime.Publisher, restaurantID uuid.UUID, topic realtime.Topic, eventType string, data interface{}) {
+	tx.AfterCommit(func() {
+		events.Publish(restaurantID, topic, eventType, data)
+	})
+}
+
+// publishOrder publica um evento de pedido no tópico de pedidos e, para deliveries, também no de entregas
+func publishOrder(events realtime.Publisher, order *models.Order, eventType string, data interface{}) {
+	events.Publish(order.RestaurantID, realtime.TopicOrders, eventType, data)
+	if order.Type == models.OrderTypeDelivery {
+		events.Publish(order.RestaurantID, realtime.TopicDelivery, eventType, data)
+	}
+}
+
+// publishTableGroup publica, após o commit, o estado da mesa e das mesas juntadas a ela
+func publishTableGroup(tx repositories.Transaction, tableRepo repositories.TableRepository, events realtime.Publisher, restaurantID, tableID uuid.UUID) error {
+	tables, err := tableRepo.FindGroup(restaurantID, tableID)
+	if err != nil {
+		return err
+	}
+	for i := range tables {
+		publishAfterCommit(tx, events, restaurantID, realtime.TopicTables, EventTableUpdated, &tables[i])
+	}
+	return nil
+}
@@ -2,6 +2,8 @@
 package services
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"api-jet-manager/internal/domain/models"
@@ -10,6 +12,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// ErrLinkedTransaction indica que a transação foi gerada por outro registro e só pode mudar por ele
+var ErrLinkedTransaction = errors.New("transaction is linked to another record")
+
 type FinanceService struct {
 	financeRepo repositories.FinanceRepository
 }
@@ -24,18 +29,53 @@ func (s *FinanceService) Create(transaction *models.FinancialTransaction) error
 	return s.financeRepo.Create(transaction)
 }
 
+// record lança a transação dentro de uma transação de banco aberta por outro serviço,
+// para que o lançamento seja desfeito junto com a operação que o originou
+func (s *FinanceService) record(tx repositories.Transaction, transaction *models.FinancialTransaction) error {
+	return s.financeRepo.WithTx(tx).Create(transaction)
+}
+
 func (s *FinanceService) GetByID(restaurant_id, id uuid.UUID) (*models.FinancialTransaction, error) {
 	return s.financeRepo.FindByID(restaurant_id, id)
 }
 
+// Update altera uma transação lançada diretamente no financeiro
 func (s *FinanceService) Update(transaction *models.FinancialTransaction) error {
+	if err := s.ensureManual(transaction.RestaurantID, transaction.ID); err != nil {
+		return err
+	}
 	return s.financeRepo.Update(transaction)
 }
 
+// Delete exclui uma transação lançada diretamente no financeiro
 func (s *FinanceService) Delete(restaurant_id, id uuid.UUID) error {
+	if err := s.ensureManual(restaurant_id, id); err != nil {
+		return err
+	}
 	return s.financeRepo.Delete(restaurant_id, id)
 }
 
+// ensureManual recusa transações geradas por pagamentos, recebimentos de compras, lançamentos
+// recorrentes ou baixas de contas. Alterá-las aqui faria o financeiro divergir do registro de origem.
+func (s *FinanceService) ensureManual(restaurantID, id uuid.UUID) error {
+	source, err := s.financeRepo.FindSource(restaurantID, id)
+	if err != nil {
+		return err
+	}
+
+	switch source {
+	case models.TransactionSourcePayment:
+		return fmt.Errorf("%w: it was created by a payment; void the payment instead", ErrLinkedTransaction)
+	case models.TransactionSourcePurchaseOrder:
+		return fmt.Errorf("%w: it was created by a purchase order receipt", ErrLinkedTransaction)
+	case models.TransactionSourceRecurring:
+		return fmt.Errorf("%w: it was posted by a recurring transaction; change the recurring transaction instead", ErrLinkedTransaction)
+	case models.TransactionSourceBillSettlement:
+		return fmt.Errorf("%w: it was created by a bill settlement", ErrLinkedTransaction)
+	}
+	return nil
+}
+
 func (s *FinanceService) List(restaurant_id uuid.UUID) ([]models.FinancialTransaction, error) {
 	return s.financeRepo.List(restaurant_id)
 }
@@ -52,10 +92,10 @@ func (s *FinanceService) GetByOrder(restaurant_id uuid.UUID, orderID uuid.UUID)
 	return s.financeRepo.FindByOrder(restaurant_id, orderID)
 }
 
-func (s *FinanceService) GetDailySummary(restaurant_id uuid.UUID, date time.Time) (float64, float64, error) {
+func (s *FinanceService) GetDailySummary(restaurant_id uuid.UUID, date time.Time) (models.Money, models.Money, error) {
 	return s.financeRepo.GetDailySummary(restaurant_id, date)
 }
 
-func (s *FinanceService) GetMonthlySummary(restaurant_id uuid.UUID, year, month int) (float64, float64, error) {
+func (s *FinanceService) GetMonthlySummary(restaurant_id uuid.UUID, year, month int) (models.Money, models.Money, error) {
 	return s.financeRepo.GetMonthlySummary(restaurant_id, year, month)
 }
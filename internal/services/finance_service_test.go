@@ -0,0 +1,96 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+// fakeFinanceRepository guarda uma transação existente, a origem informada pelo teste e as criadas
+type fakeFinanceRepository struct {
+	repositories.FinanceRepository
+	transaction *models.FinancialTransaction
+	source      models.TransactionSource
+	created     []*models.FinancialTransaction
+	updated     bool
+	deleted     bool
+}
+
+func (r *fakeFinanceRepository) WithTx(tx repositories.Transaction) repositories.FinanceRepository {
+	return r
+}
+
+func (r *fakeFinanceRepository) Create(transaction *models.FinancialTransaction) error {
+	transaction.ID = uuid.New()
+	r.created = append(r.created, transaction)
+	return nil
+}
+
+func (r *fakeFinanceRepository) FindSource(restaurantID, id uuid.UUID) (models.TransactionSource, error) {
+	if r.transaction == nil || r.transaction.RestaurantID != restaurantID || r.transaction.ID != id {
+		return models.TransactionSourceManual, repositories.ErrTransactionNotFound
+	}
+	return r.source, nil
+}
+
+func (r *fakeFinanceRepository) Update(transaction *models.FinancialTransaction) error {
+	r.updated = true
+	return nil
+}
+
+func (r *fakeFinanceRepository) Delete(restaurantID, id uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+func TestFinanceServiceRejectsLinkedTransactions(t *testing.T) {
+	tests := []struct {
+		source  models.TransactionSource
+		wantErr error
+	}{
+		{source: models.TransactionSourceManual},
+		{source: models.TransactionSourcePayment, wantErr: ErrLinkedTransaction},
+		{source: models.TransactionSourcePurchaseOrder, wantErr: ErrLinkedTransaction},
+		{source: models.TransactionSourceRecurring, wantErr: ErrLinkedTransaction},
+		{source: models.TransactionSourceBillSettlement, wantErr: ErrLinkedTransaction},
+	}
+
+	for _, tt := range tests {
+		transaction := &models.FinancialTransaction{ID: uuid.New(), RestaurantID: uuid.New(), Amount: 1000}
+
+		repo := &fakeFinanceRepository{transaction: transaction, source: tt.source}
+		service := NewFinanceService(repo)
+
+		err := service.Update(transaction)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("source %q: Update error = %v, want %v", tt.source, err, tt.wantErr)
+		}
+		if repo.updated != (tt.wantErr == nil) {
+			t.Errorf("source %q: Update saved = %v", tt.source, repo.updated)
+		}
+
+		err = service.Delete(transaction.RestaurantID, transaction.ID)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("source %q: Delete error = %v, want %v", tt.source, err, tt.wantErr)
+		}
+		if repo.deleted != (tt.wantErr == nil) {
+			t.Errorf("source %q: Delete removed = %v", tt.source, repo.deleted)
+		}
+	}
+}
+
+func TestFinanceServiceMissingTransaction(t *testing.T) {
+	repo := &fakeFinanceRepository{}
+	service := NewFinanceService(repo)
+
+	if err := service.Delete(uuid.New(), uuid.New()); !errors.Is(err, repositories.ErrTransactionNotFound) {
+		t.Errorf("Delete error = %v, want ErrTransactionNotFound", err)
+	}
+	if repo.deleted {
+		t.Error("Delete removed a missing transaction")
+	}
+}
@@ -0,0 +1,399 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidIngredient    = errors.New("invalid ingredient")
+	ErrInvalidRecipe        = errors.New("invalid recipe")
+	ErrInvalidStockMovement = errors.New("invalid stock movement")
+	ErrIngredientInUse      = errors.New("ingredient is used in a recipe")
+)
+
+// StockAdjustment é um lançamento manual no estoque de um insumo.
+// Em compras e perdas Quantity é sempre positiva; em ajustes é a diferença com sinal.
+type StockAdjustment struct {
+	Type     models.StockMovementType
+	Quantity models.Quantity
+	Notes    string
+}
+
+// InventoryService controla o estoque de insumos e as fichas técnicas dos produtos.
+// Os insumos dos itens são baixados quando o pedido entra em preparo e devolvidos se ele for
+// cancelado; a disponibilidade (InStock) dos produtos com ficha técnica acompanha o estoque.
+type InventoryService struct {
+	uow           repositories.UnitOfWork
+	inventoryRepo repositories.InventoryRepository
+	orderRepo     repositories.OrderRepository
+	orderService  *OrderService
+}
+
+func NewInventoryService(uow repositories.UnitOfWork, inventoryRepo repositories.InventoryRepository, orderRepo repositories.OrderRepository, orderService *OrderService) *InventoryService {
+	s := &InventoryService{
+		uow:           uow,
+		inventoryRepo: inventoryRepo,
+		orderRepo:     orderRepo,
+		orderService:  orderService,
+	}
+	s.registerHooks()
+	return s
+}
+
+// registerHooks liga o estoque ao ciclo de vida dos pedidos. StockDeductedAt marca os pedidos
+// cujos itens já foram baixados, já que um pedido pode voltar ao preparo várias vezes.
+func (s *InventoryService) registerHooks() {
+	stateMachine := s.orderService.StateMachine()
+
+	stateMachine.OnEnter(models.OrderStatusPreparing, func(t *OrderTransition) error {
+		if t.Order.StockDeductedAt != nil {
+			return nil
+		}
+		items, err := s.orderRepo.WithTx(t.Tx).FindItems(t.Order.RestaurantID, t.Order.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.consume(t.Tx, t.Order, items, models.StockMovementConsumption, t.ActorID); err != nil {
+			return err
+		}
+		t.Order.StockDeductedAt = &t.At
+		return nil
+	})
+
+	stateMachine.OnEnter(models.OrderStatusCancelled, func(t *OrderTransition) error {
+		if t.Order.StockDeductedAt == nil {
+			return nil
+		}
+		items, err := s.orderRepo.WithTx(t.Tx).FindItems(t.Order.RestaurantID, t.Order.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.consume(t.Tx, t.Order, items, models.StockMovementReturn, t.ActorID); err != nil {
+			return err
+		}
+		t.Order.StockDeductedAt = nil
+		return nil
+	})
+
+	// Outras ações podem ter levado o pedido ao preparo, então a marca é sempre lida do banco
+	s.orderService.OnItemAdded(func(tx repositories.Transaction, order *models.Order, item *models.OrderItem, actorID uuid.UUID) error {
+		current, err := s.orderRepo.WithTx(tx).FindByIDForUpdate(order.RestaurantID, order.ID)
+		if err != nil || current.StockDeductedAt == nil {
+			return err
+		}
+		return s.consume(tx, current, []models.OrderItem{*item}, models.StockMovementConsumption, actorID)
+	})
+
+	s.orderService.OnItemRemoved(func(tx repositories.Transaction, order *models.Order, item *models.OrderItem, actorID uuid.UUID) error {
+		current, err := s.orderRepo.WithTx(tx).FindByIDForUpdate(order.RestaurantID, order.ID)
+		if err != nil || current.StockDeductedAt == nil {
+			return err
+		}
+		return s.consume(tx, current, []models.OrderItem{*item}, models.StockMovementReturn, actorID)
+	})
+
+	// O item já foi baixado se a origem foi; só há movimentação quando os dois pedidos diferem
+	s.orderService.OnItemMoved(func(tx repositories.Transaction, from, to *models.Order, item *models.OrderItem, actorID uuid.UUID) error {
+		orderRepo := s.orderRepo.WithTx(tx)
+		source, err := orderRepo.FindByIDForUpdate(from.RestaurantID, from.ID)
+		if err != nil {
+			return err
+		}
+		target, err := orderRepo.FindByIDForUpdate(to.RestaurantID, to.ID)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case source.StockDeductedAt != nil && target.StockDeductedAt == nil:
+			return s.consume(tx, source, []models.OrderItem{*item}, models.StockMovementReturn, actorID)
+		case source.StockDeductedAt == nil && target.StockDeductedAt != nil:
+			return s.consume(tx, target, []models.OrderItem{*item}, models.StockMovementConsumption, actorID)
+		}
+		return nil
+	})
+}
+
+// consume baixa (consumption) ou devolve (return) os insumos das fichas técnicas dos itens
+// do pedido, incluindo os das opções de complemento escolhidas
+func (s *InventoryService) consume(tx repositories.Transaction, order *models.Order, items []models.OrderItem, movementType models.StockMovementType, actorID uuid.UUID) error {
+	if len(items) == 0 {
+		return nil
+	}
+	inventoryRepo := s.inventoryRepo.WithTx(tx)
+
+	var productIDs, optionIDs []uuid.UUID
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
+		for _, option := range item.Options {
+			optionIDs = append(optionIDs, option.OptionID)
+		}
+	}
+
+	recipes, err := inventoryRepo.FindRecipes(order.RestaurantID, productIDs, optionIDs)
+	if err != nil {
+		return err
+	}
+	if len(recipes) == 0 {
+		return nil
+	}
+
+	productRecipes := make(map[uuid.UUID][]models.RecipeItem)
+	optionRecipes := make(map[uuid.UUID][]models.RecipeItem)
+	for _, recipe := range recipes {
+		if recipe.ProductID != nil {
+			productRecipes[*recipe.ProductID] = append(productRecipes[*recipe.ProductID], recipe)
+		}
+		if recipe.OptionID != nil {
+			optionRecipes[*recipe.OptionID] = append(optionRecipes[*recipe.OptionID], recipe)
+		}
+	}
+
+	// Cada opção acompanha cada unidade do item, como no cálculo do preço
+	used := make(map[uuid.UUID]models.Quantity)
+	for _, item := range items {
+		for _, recipe := range productRecipes[item.ProductID] {
+			used[recipe.IngredientID] += recipe.Quantity.Mul(item.Quantity)
+		}
+		for _, option := range item.Options {
+			for _, recipe := range optionRecipes[option.OptionID] {
+				used[recipe.IngredientID] += recipe.Quantity.Mul(option.Quantity * item.Quantity)
+			}
+		}
+	}
+
+	deltas := make(map[uuid.UUID]models.Quantity, len(used))
+	for ingredientID, quantity := range used {
+		if movementType == models.StockMovementConsumption {
+			quantity = -quantity
+		}
+		deltas[ingredientID] = quantity
+	}
+
+	movement := models.StockMovement{Type: movementType, OrderID: &order.ID}
+	if actorID != uuid.Nil {
+		movement.UserID = &actorID
+	}
+	return s.applyStock(tx, order.RestaurantID, deltas, movement)
+}
+
+// applyStock altera o estoque, registra as movimentações e recalcula a disponibilidade dos produtos.
+// Cada movimentação copia o tipo, as referências e as observações de movement.
+func (s *InventoryService) applyStock(tx repositories.Transaction, restaurantID uuid.UUID, deltas map[uuid.UUID]models.Quantity, movement models.StockMovement) error {
+	inventoryRepo := s.inventoryRepo.WithTx(tx)
+
+	ingredients, err := inventoryRepo.AdjustStock(restaurantID, deltas)
+	if err != nil {
+		return err
+	}
+
+	if movement.UserID != nil && *movement.UserID == uuid.Nil {
+		movement.UserID = nil
+	}
+
+	movements := make([]models.StockMovement, 0, len(ingredients))
+	ingredientIDs := make([]uuid.UUID, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		entry := movement
+		entry.RestaurantID = restaurantID
+		entry.IngredientID = ingredient.ID
+		entry.Quantity = deltas[ingredient.ID]
+		movements = append(movements, entry)
+		ingredientIDs = append(ingredientIDs, ingredient.ID)
+	}
+
+	if err := inventoryRepo.CreateMovements(movements); err != nil {
+		return err
+	}
+	return inventoryRepo.RefreshProductStock(restaurantID, ingredientIDs)
+}
+
+func validateIngredient(ingredient *models.Ingredient) error {
+	ingredient.Name = strings.TrimSpace(ingredient.Name)
+	switch {
+	case ingredient.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidIngredient)
+	case len(ingredient.Name) > 100:
+		return fmt.Errorf("%w: name must have at most 100 characters", ErrInvalidIngredient)
+	case !ingredient.Unit.Valid():
+		return fmt.Errorf("%w: unit must be g, kg, ml, l or un", ErrInvalidIngredient)
+	case ingredient.MinimumStock.IsNegative():
+		return fmt.Errorf("%w: minimum stock cannot be negative", ErrInvalidIngredient)
+	}
+	return nil
+}
+
+// CreateIngredient cadastra o insumo; o estoque inicial, se houver, entra como uma compra
+func (s *InventoryService) CreateIngredient(ingredient *models.Ingredient, actorID uuid.UUID) error {
+	if err := validateIngredient(ingredient); err != nil {
+		return err
+	}
+	if ingredient.OnHand.IsNegative() {
+		return fmt.Errorf("%w: initial stock cannot be negative", ErrInvalidIngredient)
+	}
+
+	initial := ingredient.OnHand
+	ingredient.OnHand = 0
+
+	return s.uow.Do(func(tx repositories.Transaction) error {
+		if err := s.inventoryRepo.WithTx(tx).CreateIngredient(ingredient); err != nil {
+			return err
+		}
+		if initial.IsZero() {
+			return nil
+		}
+		deltas := map[uuid.UUID]models.Quantity{ingredient.ID: initial}
+		if err := s.applyStock(tx, ingredient.RestaurantID, deltas, models.StockMovement{
+			Type:   models.StockMovementPurchase,
+			UserID: &actorID,
+			Notes:  "initial stock",
+		}); err != nil {
+			return err
+		}
+		ingredient.OnHand = initial
+		return nil
+	})
+}
+
+func (s *InventoryService) GetIngredient(restaurantID, id uuid.UUID) (*models.Ingredient, error) {
+	return s.inventoryRepo.FindIngredientByID(restaurantID, id)
+}
+
+func (s *InventoryService) ListIngredients(restaurantID uuid.UUID, lowStock bool) ([]models.Ingredient, error) {
+	return s.inventoryRepo.ListIngredients(restaurantID, lowStock)
+}
+
+// UpdateIngredient altera os dados cadastrais; o estoque só muda através de AdjustStock
+func (s *InventoryService) UpdateIngredient(ingredient *models.Ingredient) error {
+	if err := validateIngredient(ingredient); err != nil {
+		return err
+	}
+	return s.inventoryRepo.UpdateIngredient(ingredient)
+}
+
+// DeleteIngredient remove o insumo e seu histórico; insumos usados em fichas técnicas não podem ser removidos
+func (s *InventoryService) DeleteIngredient(restaurantID, id uuid.UUID) error {
+	if _, err := s.inventoryRepo.FindIngredientByID(restaurantID, id); err != nil {
+		return err
+	}
+
+	inUse, err := s.inventoryRepo.IngredientInUse(restaurantID, id)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return ErrIngredientInUse
+	}
+	return s.inventoryRepo.DeleteIngredient(restaurantID, id)
+}
+
+// AdjustStock registra uma compra, perda ou ajuste de contagem no estoque do insumo
+func (s *InventoryService) AdjustStock(restaurantID, ingredientID, actorID uuid.UUID, adjustment StockAdjustment) (*models.Ingredient, error) {
+	delta := adjustment.Quantity
+	switch adjustment.Type {
+	case models.StockMovementPurchase, models.StockMovementWaste:
+		if delta <= 0 {
+			return nil, fmt.Errorf("%w: quantity must be greater than zero", ErrInvalidStockMovement)
+		}
+		if adjustment.Type == models.StockMovementWaste {
+			delta = -delta
+		}
+	case models.StockMovementAdjustment:
+		if delta.IsZero() {
+			return nil, fmt.Errorf("%w: quantity cannot be zero", ErrInvalidStockMovement)
+		}
+	default:
+		return nil, fmt.Errorf("%w: type must be purchase, waste or adjustment", ErrInvalidStockMovement)
+	}
+	if len(adjustment.Notes) > 255 {
+		return nil, fmt.Errorf("%w: notes must have at most 255 characters", ErrInvalidStockMovement)
+	}
+
+	var ingredient *models.Ingredient
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		deltas := map[uuid.UUID]models.Quantity{ingredientID: delta}
+		if err := s.applyStock(tx, restaurantID, deltas, models.StockMovement{
+			Type:   adjustment.Type,
+			UserID: &actorID,
+			Notes:  adjustment.Notes,
+		}); err != nil {
+			return err
+		}
+
+		var err error
+		ingredient, err = s.inventoryRepo.WithTx(tx).FindIngredientByID(restaurantID, ingredientID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ingredient, nil
+}
+
+func (s *InventoryService) ListMovements(restaurantID uuid.UUID, filter repositories.StockMovementFilter) ([]models.StockMovement, error) {
+	return s.inventoryRepo.ListMovements(restaurantID, filter)
+}
+
+// validateRecipe exige quantidades positivas e no máximo uma linha por insumo
+func validateRecipe(items []models.RecipeItem) error {
+	seen := make(map[uuid.UUID]bool, len(items))
+	for _, item := range items {
+		if item.IngredientID == uuid.Nil {
+			return fmt.Errorf("%w: ingredient is required", ErrInvalidRecipe)
+		}
+		if item.Quantity <= 0 {
+			return fmt.Errorf("%w: quantity must be greater than zero", ErrInvalidRecipe)
+		}
+		if seen[item.IngredientID] {
+			return fmt.Errorf("%w: ingredient %s appears more than once", ErrInvalidRecipe, item.IngredientID)
+		}
+		seen[item.IngredientID] = true
+	}
+	return nil
+}
+
+func (s *InventoryService) GetProductRecipe(restaurantID, productID uuid.UUID) ([]models.RecipeItem, error) {
+	return s.inventoryRepo.FindProductRecipe(restaurantID, productID)
+}
+
+// SetProductRecipe substitui a ficha técnica do produto e recalcula sua disponibilidade.
+// Uma ficha vazia deixa o produto disponível e sem controle de estoque.
+func (s *InventoryService) SetProductRecipe(restaurantID, productID uuid.UUID, items []models.RecipeItem) ([]models.RecipeItem, error) {
+	if err := validateRecipe(items); err != nil {
+		return nil, err
+	}
+
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		inventoryRepo := s.inventoryRepo.WithTx(tx)
+		if err := inventoryRepo.SetProductRecipe(restaurantID, productID, items); err != nil {
+			return err
+		}
+		return inventoryRepo.RefreshProductAvailability(restaurantID, []uuid.UUID{productID})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.inventoryRepo.FindProductRecipe(restaurantID, productID)
+}
+
+func (s *InventoryService) GetOptionRecipe(restaurantID, optionID uuid.UUID) ([]models.RecipeItem, error) {
+	return s.inventoryRepo.FindOptionRecipe(restaurantID, optionID)
+}
+
+// SetOptionRecipe substitui a ficha técnica da opção de complemento
+func (s *InventoryService) SetOptionRecipe(restaurantID, optionID uuid.UUID, items []models.RecipeItem) ([]models.RecipeItem, error) {
+	if err := validateRecipe(items); err != nil {
+		return nil, err
+	}
+	if err := s.inventoryRepo.SetOptionRecipe(restaurantID, optionID, items); err != nil {
+		return nil, err
+	}
+	return s.inventoryRepo.FindOptionRecipe(restaurantID, optionID)
+}
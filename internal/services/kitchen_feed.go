@@ -0,0 +1,124 @@
+package services
+
+import (
+	"encoding/json"
+	"log"
+	"strings"
+	"sync"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/infrastructure/realtime"
+
+	"github.com/google/uuid"
+)
+
+type KitchenEventType string
+
+const (
+	KitchenTicketCreated KitchenEventType = "ticket_created"
+	KitchenTicketUpdated KitchenEventType = "ticket_updated"
+	KitchenTicketRemoved KitchenEventType = "ticket_removed"
+)
+
+// KitchenEvent é enviado às telas da cozinha sempre que uma comanda muda
+type KitchenEvent struct {
+	Type   KitchenEventType     `json:"type"`
+	Ticket models.KitchenTicket `json:"ticket"`
+}
+
+// kitchenFeedBuffer limita quantos eventos uma tela lenta pode acumular antes de ser desconectada
+const kitchenFeedBuffer = 64
+
+type kitchenSubscription struct {
+	restaurantID uuid.UUID
+	stationID    *uuid.UUID
+	events       chan KitchenEvent
+}
+
+// kitchenEventPrefix separa os eventos das comandas dos demais eventos do tópico da cozinha
+const kitchenEventPrefix = "kitchen."
+
+// KitchenFeed distribui os eventos das comandas para as telas conectadas de cada praça.
+// Os eventos chegam pelo tópico da cozinha do hub, inclusive os publicados por outras instâncias.
+type KitchenFeed struct {
+	mu            sync.Mutex
+	subscriptions map[*kitchenSubscription]struct{}
+}
+
+func NewKitchenFeed(hub *realtime.Hub) *KitchenFeed {
+	feed := &KitchenFeed{
+		subscriptions: make(map[*kitchenSubscription]struct{}),
+	}
+	hub.Observe(realtime.TopicKitchen, feed.relay)
+	return feed
+}
+
+// relay converte o envelope do hub de volta em um evento de comanda
+func (f *KitchenFeed) relay(event realtime.Event) {
+	if !strings.HasPrefix(event.Type, kitchenEventPrefix) {
+		return
+	}
+
+	var ticket models.KitchenTicket
+	if err := json.Unmarshal(event.Data, &ticket); err != nil {
+		log.Printf("Evento da cozinha inválido: %v", err)
+		return
+	}
+
+	f.Publish(KitchenEvent{
+		Type:   KitchenEventType(strings.TrimPrefix(event.Type, kitchenEventPrefix)),
+		Ticket: ticket,
+	})
+}
+
+// Subscribe inscreve uma tela nos eventos de uma praça, ou de todas se stationID for nulo.
+// O canal é fechado quando a inscrição é cancelada ou quando a tela não acompanha o volume
+// de eventos; nesse caso ela deve reconectar e recarregar as comandas abertas.
+func (f *KitchenFeed) Subscribe(restaurantID uuid.UUID, stationID *uuid.UUID) (<-chan KitchenEvent, func()) {
+	sub := &kitchenSubscription{
+		restaurantID: restaurantID,
+		stationID:    stationID,
+		events:       make(chan KitchenEvent, kitchenFeedBuffer),
+	}
+
+	f.mu.Lock()
+	f.subscriptions[sub] = struct{}{}
+	f.mu.Unlock()
+
+	return sub.events, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.remove(sub)
+	}
+}
+
+// remove deve ser chamado com o lock adquirido
+func (f *KitchenFeed) remove(sub *kitchenSubscription) {
+	if _, ok := f.subscriptions[sub]; ok {
+		delete(f.subscriptions, sub)
+		close(sub.events)
+	}
+}
+
+// Publish entrega os eventos às telas do restaurante interessadas na praça de cada comanda
+func (f *KitchenFeed) Publish(events ...KitchenEvent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, event := range events {
+		for sub := range f.subscriptions {
+			if sub.restaurantID != event.Ticket.RestaurantID {
+				continue
+			}
+			if sub.stationID != nil && (event.Ticket.StationID == nil || *event.Ticket.StationID != *sub.stationID) {
+				continue
+			}
+
+			select {
+			case sub.events <- event:
+			default:
+				f.remove(sub)
+			}
+		}
+	}
+}
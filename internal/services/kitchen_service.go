@@ -0,0 +1,453 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/realtime"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidStation      = errors.New("invalid kitchen station")
+	ErrInvalidTicketAction = errors.New("invalid ticket action")
+)
+
+// TicketActor identifica quem agiu sobre uma comanda. Na tela autenticada pela credencial do
+// terminal não há usuário conectado: UserID fica uuid.Nil e a ação é registrada em DeviceID.
+type TicketActor struct {
+	UserID   uuid.UUID
+	DeviceID *uuid.UUID
+}
+
+// KitchenService transforma os itens dos pedidos em comandas nas praças de preparo.
+// As comandas são criadas quando o pedido entra em preparo e, quando todas ficam prontas,
+// o pedido é movido automaticamente para pronto.
+type KitchenService struct {
+	uow          repositories.UnitOfWork
+	kitchenRepo  repositories.KitchenRepository
+	orderRepo    repositories.OrderRepository
+	orderService *OrderService
+	feed         *KitchenFeed
+	events       realtime.Publisher
+}
+
+func NewKitchenService(uow repositories.UnitOfWork, kitchenRepo repositories.KitchenRepository, orderRepo repositories.OrderRepository, orderService *OrderService, feed *KitchenFeed, events realtime.Publisher) *KitchenService {
+	s := &KitchenService{
+		uow:          uow,
+		kitchenRepo:  kitchenRepo,
+		orderRepo:    orderRepo,
+		orderService: orderService,
+		feed:         feed,
+		events:       events,
+	}
+	s.registerHooks()
+	return s
+}
+
+// registerHooks liga a cozinha ao ciclo de vida dos pedidos
+func (s *KitchenService) registerHooks() {
+	stateMachine := s.orderService.StateMachine()
+
+	stateMachine.OnEnter(models.OrderStatusPreparing, func(t *OrderTransition) error {
+		return s.sendToKitchen(t.Tx, t.Order)
+	})
+
+	stateMachine.OnEnter(models.OrderStatusCancelled, func(t *OrderTransition) error {
+		// Comandas já prontas ficam no histórico; as demais saem das telas
+		removed, err := s.kitchenRepo.WithTx(t.Tx).DeleteOpenTickets(t.Order.RestaurantID, t.Order.ID)
+		if err != nil {
+			return err
+		}
+		s.publish(t.Tx, KitchenTicketRemoved, removed)
+		return nil
+	})
+
+	s.orderService.OnItemAdded(func(tx repositories.Transaction, order *models.Order, item *models.OrderItem, actorID uuid.UUID) error {
+		switch order.Status {
+		case models.OrderStatusPreparing:
+			return s.sendToKitchen(tx, order)
+		case models.OrderStatusReady, models.OrderStatusDelivered:
+			// O pedido volta ao preparo e a ação de entrada cria a comanda do novo item. Pedidos
+			// entregues que não podem voltar ao preparo enviam a comanda sem mudar de status.
+			if !stateMachine.CanTransition(order.Type, order.Status, models.OrderStatusPreparing) {
+				return s.sendToKitchen(tx, order)
+			}
+			_, err := s.orderService.transition(tx, order.RestaurantID, order.ID, models.OrderStatusPreparing, actorID)
+			return err
+		}
+		return nil
+	})
+
+	s.orderService.OnItemRemoved(func(tx repositories.Transaction, order *models.Order, item *models.OrderItem, actorID uuid.UUID) error {
+		removed, err := s.kitchenRepo.WithTx(tx).DeleteItemTickets(order.RestaurantID, item.ID)
+		if err != nil {
+			return err
+		}
+		s.publish(tx, KitchenTicketRemoved, removed)
+
+		// O item removido pode ser o último que faltava ficar pronto
+		if order.Status == models.OrderStatusPreparing {
+			return s.completeIfReady(tx, order.RestaurantID, order.ID, TicketActor{UserID: actorID})
+		}
+		return nil
+	})
+
+	s.orderService.OnItemMoved(func(tx repositories.Transaction, from, to *models.Order, item *models.OrderItem, actorID uuid.UUID) error {
+		moved, err := s.kitchenRepo.WithTx(tx).MoveItemTickets(to.RestaurantID, item.ID, to)
+		if err != nil {
+			return err
+		}
+		s.publish(tx, KitchenTicketUpdated, moved)
+
+		// Ações de itens movidos antes podem ter mudado o status do destino
+		target, err := s.orderRepo.WithTx(tx).FindByIDForUpdate(to.RestaurantID, to.ID)
+		if err != nil {
+			return err
+		}
+
+		pending := len(moved) == 0
+		for _, ticket := range moved {
+			if ticket.Status != models.TicketStatusDone {
+				pending = true
+			}
+		}
+
+		switch target.Status {
+		case models.OrderStatusPreparing:
+			if err := s.sendToKitchen(tx, target); err != nil {
+				return err
+			}
+		case models.OrderStatusReady:
+			// Um item ainda não finalizado devolve o destino ao preparo
+			if pending {
+				if _, err := s.orderService.transition(tx, target.RestaurantID, target.ID, models.OrderStatusPreparing, actorID); err != nil {
+					return err
+				}
+			}
+		}
+
+		// O item movido pode ser o último que faltava ficar pronto na origem
+		source, err := s.orderRepo.WithTx(tx).FindByIDForUpdate(from.RestaurantID, from.ID)
+		if err != nil {
+			return err
+		}
+		if source.Status == models.OrderStatusPreparing {
+			return s.completeIfReady(tx, source.RestaurantID, source.ID, TicketActor{UserID: actorID})
+		}
+		return nil
+	})
+}
+
+// publish envia os eventos ao tópico da cozinha somente depois que a transação for confirmada;
+// as telas das praças os recebem através do KitchenFeed
+func (s *KitchenService) publish(tx repositories.Transaction, eventType KitchenEventType, tickets []models.KitchenTicket) {
+	if len(tickets) == 0 {
+		return
+	}
+
+	tx.AfterCommit(func() {
+		for _, ticket := range tickets {
+			s.events.Publish(ticket.RestaurantID, realtime.TopicKitchen, kitchenEventPrefix+string(eventType), ticket)
+		}
+	})
+}
+
+// sendToKitchen cria as comandas dos itens do pedido que ainda não têm comanda.
+// Pode ser chamada várias vezes para o mesmo pedido sem duplicar comandas.
+func (s *KitchenService) sendToKitchen(tx repositories.Transaction, order *models.Order) error {
+	kitchenRepo := s.kitchenRepo.WithTx(tx)
+
+	items, err := s.orderRepo.WithTx(tx).FindItems(order.RestaurantID, order.ID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := kitchenRepo.FindTickets(order.RestaurantID, repositories.KitchenTicketFilter{OrderID: &order.ID})
+	if err != nil {
+		return err
+	}
+
+	sent := make(map[uuid.UUID]bool, len(existing))
+	for _, ticket := range existing {
+		sent[ticket.OrderItemID] = true
+	}
+
+	var pending []models.OrderItem
+	productIDs := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		if !sent[item.ID] {
+			pending = append(pending, item)
+			productIDs = append(productIDs, item.ProductID)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	routes, err := kitchenRepo.ResolveRoutes(order.RestaurantID, productIDs)
+	if err != nil {
+		return err
+	}
+
+	tickets := make([]models.KitchenTicket, 0, len(pending))
+	for _, item := range pending {
+		route := routes[item.ProductID]
+		tickets = append(tickets, models.KitchenTicket{
+			RestaurantID: order.RestaurantID,
+			StationID:    route.StationID,
+			OrderID:      order.ID,
+			OrderItemID:  item.ID,
+			OrderCode:    order.Code,
+			OrderType:    order.Type,
+			ProductName:  route.ProductName,
+			Quantity:     item.Quantity,
+			Options:      describeOptions(item.Options),
+			Notes:        item.Notes,
+			Status:       models.TicketStatusQueued,
+		})
+	}
+
+	if err := kitchenRepo.CreateTickets(tickets); err != nil {
+		return fmt.Errorf("failed to create kitchen tickets: %w", err)
+	}
+
+	s.publish(tx, KitchenTicketCreated, tickets)
+	return nil
+}
+
+// describeOptions resume os complementos escolhidos para exibição na tela da cozinha
+func describeOptions(options []models.OrderItemOption) string {
+	parts := make([]string, 0, len(options))
+	for _, option := range options {
+		if option.Quantity > 1 {
+			parts = append(parts, fmt.Sprintf("%s x%d", option.Name, option.Quantity))
+		} else {
+			parts = append(parts, option.Name)
+		}
+	}
+
+	description := strings.Join(parts, ", ")
+	if len(description) > 500 {
+		description = description[:497] + "..."
+	}
+	return description
+}
+
+// completeIfReady move o pedido para pronto quando todas as suas comandas estão prontas
+func (s *KitchenService) completeIfReady(tx repositories.Transaction, restaurantID, orderID uuid.UUID, actor TicketActor) error {
+	tickets, err := s.kitchenRepo.WithTx(tx).FindTickets(restaurantID, repositories.KitchenTicketFilter{OrderID: &orderID})
+	if err != nil {
+		return err
+	}
+	if len(tickets) == 0 {
+		return nil
+	}
+
+	for _, ticket := range tickets {
+		if ticket.Status != models.TicketStatusDone {
+			return nil
+		}
+	}
+
+	_, err = s.orderService.transitionOnDevice(tx, restaurantID, orderID, models.OrderStatusReady, actor.UserID, actor.DeviceID)
+	return err
+}
+
+// updateTicket bloqueia o pedido e a comanda, nessa ordem, aplica a alteração em apply, grava a comanda
+// e então executa after, se informado. Bloquear o pedido primeiro serializa as ações sobre comandas do
+// mesmo pedido, evitando que duas comandas finalizadas ao mesmo tempo deixem o pedido em preparo.
+func (s *KitchenService) updateTicket(restaurantID, ticketID uuid.UUID, apply func(order *models.Order, ticket *models.KitchenTicket) error, after func(tx repositories.Transaction, order *models.Order) error) (*models.KitchenTicket, error) {
+	current, err := s.kitchenRepo.FindTicketByID(restaurantID, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ticket *models.KitchenTicket
+	err = s.uow.Do(func(tx repositories.Transaction) error {
+		kitchenRepo := s.kitchenRepo.WithTx(tx)
+
+		order, err := s.orderRepo.WithTx(tx).FindByIDForUpdate(restaurantID, current.OrderID)
+		if err != nil {
+			return err
+		}
+
+		ticket, err = kitchenRepo.FindTicketByIDForUpdate(restaurantID, ticketID)
+		if err != nil {
+			return err
+		}
+
+		if err := apply(order, ticket); err != nil {
+			return err
+		}
+
+		if err := kitchenRepo.UpdateTicket(ticket); err != nil {
+			return err
+		}
+		s.publish(tx, KitchenTicketUpdated, []models.KitchenTicket{*ticket})
+
+		if after != nil {
+			return after(tx, order)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+// Start marca a comanda como em preparo
+func (s *KitchenService) Start(restaurantID, ticketID uuid.UUID) (*models.KitchenTicket, error) {
+	return s.updateTicket(restaurantID, ticketID, func(order *models.Order, ticket *models.KitchenTicket) error {
+		if ticket.Status != models.TicketStatusQueued {
+			return fmt.Errorf("%w: ticket is %s", ErrInvalidTicketAction, ticket.Status)
+		}
+
+		now := time.Now()
+		ticket.Status = models.TicketStatusInProgress
+		ticket.StartedAt = &now
+		return nil
+	}, nil)
+}
+
+// Bump finaliza a comanda. Se era a última comanda aberta do pedido, o pedido vai para pronto.
+func (s *KitchenService) Bump(restaurantID, ticketID uuid.UUID, actor TicketActor) (*models.KitchenTicket, error) {
+	return s.updateTicket(restaurantID, ticketID, func(order *models.Order, ticket *models.KitchenTicket) error {
+		if ticket.Status == models.TicketStatusDone {
+			return fmt.Errorf("%w: ticket is already done", ErrInvalidTicketAction)
+		}
+
+		now := time.Now()
+		if ticket.StartedAt == nil {
+			ticket.StartedAt = &now
+		}
+		ticket.Status = models.TicketStatusDone
+		ticket.DoneAt = &now
+		ticket.DoneByID = nil
+		if actor.UserID != uuid.Nil {
+			ticket.DoneByID = &actor.UserID
+		}
+		ticket.DoneDeviceID = actor.DeviceID
+		return nil
+	}, func(tx repositories.Transaction, order *models.Order) error {
+		if order.Status != models.OrderStatusPreparing {
+			return nil
+		}
+		return s.completeIfReady(tx, restaurantID, order.ID, actor)
+	})
+}
+
+// Recall devolve uma comanda pronta para o preparo. Um pedido pronto volta para em preparo.
+func (s *KitchenService) Recall(restaurantID, ticketID uuid.UUID, actor TicketActor) (*models.KitchenTicket, error) {
+	return s.updateTicket(restaurantID, ticketID, func(order *models.Order, ticket *models.KitchenTicket) error {
+		if ticket.Status != models.TicketStatusDone {
+			return fmt.Errorf("%w: only done tickets can be recalled", ErrInvalidTicketAction)
+		}
+		if order.Status != models.OrderStatusPreparing && order.Status != models.OrderStatusReady {
+			return fmt.Errorf("%w: order is already %s", ErrInvalidTicketAction, order.Status)
+		}
+
+		ticket.Status = models.TicketStatusInProgress
+		ticket.DoneAt = nil
+		ticket.DoneByID = nil
+		ticket.DoneDeviceID = nil
+		return nil
+	}, func(tx repositories.Transaction, order *models.Order) error {
+		if order.Status != models.OrderStatusReady {
+			return nil
+		}
+		_, err := s.orderService.transitionOnDevice(tx, restaurantID, order.ID, models.OrderStatusPreparing, actor.UserID, actor.DeviceID)
+		return err
+	})
+}
+
+// ListTickets retorna as comandas do restaurante de acordo com o filtro
+func (s *KitchenService) ListTickets(restaurantID uuid.UUID, filter repositories.KitchenTicketFilter) ([]models.KitchenTicket, error) {
+	return s.kitchenRepo.FindTickets(restaurantID, filter)
+}
+
+// OpenTickets retorna as comandas ainda não finalizadas de uma praça, ou de todas se stationID for nulo
+func (s *KitchenService) OpenTickets(restaurantID uuid.UUID, stationID *uuid.UUID) ([]models.KitchenTicket, error) {
+	return s.kitchenRepo.FindTickets(restaurantID, repositories.KitchenTicketFilter{
+		StationID: stationID,
+		Statuses:  []models.TicketStatus{models.TicketStatusQueued, models.TicketStatusInProgress},
+	})
+}
+
+// Subscribe inscreve uma tela nos eventos da praça; veja KitchenFeed.Subscribe
+func (s *KitchenService) Subscribe(restaurantID uuid.UUID, stationID *uuid.UUID) (<-chan KitchenEvent, func()) {
+	return s.feed.Subscribe(restaurantID, stationID)
+}
+
+func validateStation(station *models.KitchenStation) error {
+	station.Name = strings.TrimSpace(station.Name)
+	if station.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidStation)
+	}
+	if len(station.Name) > 100 {
+		return fmt.Errorf("%w: name must have at most 100 characters", ErrInvalidStation)
+	}
+	return nil
+}
+
+func (s *KitchenService) CreateStation(station *models.KitchenStation) error {
+	if err := validateStation(station); err != nil {
+		return err
+	}
+	return s.kitchenRepo.CreateStation(station)
+}
+
+func (s *KitchenService) GetStation(restaurantID, id uuid.UUID) (*models.KitchenStation, error) {
+	return s.kitchenRepo.FindStationByID(restaurantID, id)
+}
+
+func (s *KitchenService) ListStations(restaurantID uuid.UUID) ([]models.KitchenStation, error) {
+	return s.kitchenRepo.ListStations(restaurantID)
+}
+
+func (s *KitchenService) UpdateStation(station *models.KitchenStation) error {
+	if err := validateStation(station); err != nil {
+		return err
+	}
+	return s.kitchenRepo.UpdateStation(station)
+}
+
+// DeleteStation remove a praça; produtos e comandas vinculados ficam sem praça
+func (s *KitchenService) DeleteStation(restaurantID, id uuid.UUID) error {
+	if _, err := s.kitchenRepo.FindStationByID(restaurantID, id); err != nil {
+		return err
+	}
+	return s.kitchenRepo.DeleteStation(restaurantID, id)
+}
+
+// checkStation garante que a praça, se informada, pertence ao restaurante
+func (s *KitchenService) checkStation(restaurantID uuid.UUID, stationID *uuid.UUID) error {
+	if stationID == nil {
+		return nil
+	}
+	_, err := s.kitchenRepo.FindStationByID(restaurantID, *stationID)
+	return err
+}
+
+// SetProductStation define a praça do produto; nulo faz o produto seguir a praça da categoria
+func (s *KitchenService) SetProductStation(restaurantID, productID uuid.UUID, stationID *uuid.UUID) error {
+	if err := s.checkStation(restaurantID, stationID); err != nil {
+		return err
+	}
+	return s.kitchenRepo.SetProductStation(restaurantID, productID, stationID)
+}
+
+// SetCategoryStation define a praça padrão dos produtos da categoria
+func (s *KitchenService) SetCategoryStation(restaurantID, categoryID uuid.UUID, stationID *uuid.UUID) error {
+	if err := s.checkStation(restaurantID, stationID); err != nil {
+		return err
+	}
+	return s.kitchenRepo.SetCategoryStation(restaurantID, categoryID, stationID)
+}
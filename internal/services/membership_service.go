@@ -0,0 +1,137 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidMembership = errors.New("invalid membership")
+
+// MembershipInput define o tipo e o papel do usuário no restaurante; sem tipo vale staff na inclusão
+// e o tipo atual na alteração
+type MembershipInput struct {
+	Type   models.UserType
+	RoleID *uuid.UUID
+}
+
+// MembershipService mantém os vínculos dos usuários com os restaurantes
+type MembershipService struct {
+	membershipRepo repositories.MembershipRepository
+	userRepo       repositories.UserRepository
+	roleService    *RoleService
+}
+
+func NewMembershipService(membershipRepo repositories.MembershipRepository, userRepo repositories.UserRepository, roleService *RoleService) *MembershipService {
+	return &MembershipService{
+		membershipRepo: membershipRepo,
+		userRepo:       userRepo,
+		roleService:    roleService,
+	}
+}
+
+func (s *MembershipService) Find(restaurantID, userID uuid.UUID) (*models.Membership, error) {
+	return s.membershipRepo.Find(restaurantID, userID)
+}
+
+// ListForUser retorna os restaurantes do usuário
+func (s *MembershipService) ListForUser(userID uuid.UUID) ([]models.Membership, error) {
+	return s.membershipRepo.ListByUser(userID)
+}
+
+// ListMembers retorna os usuários do restaurante
+func (s *MembershipService) ListMembers(restaurantID uuid.UUID) ([]models.Membership, error) {
+	return s.membershipRepo.ListByRestaurant(restaurantID)
+}
+
+// AddMember vincula ao restaurante um usuário já cadastrado, localizado pelo email
+func (s *MembershipService) AddMember(restaurantID uuid.UUID, actor Actor, email string, in MembershipInput) (*models.Membership, error) {
+	user, err := s.userRepo.FindByEmailGlobal(email)
+	if err != nil {
+		return nil, err
+	}
+	if user.Type == models.UserTypeSuperAdmin {
+		return nil, fmt.Errorf("%w: superadmins already access every restaurant", ErrInvalidMembership)
+	}
+
+	if in.Type == "" {
+		in.Type = models.UserTypeStaff
+	}
+	if in.Type == models.UserTypeSuperAdmin {
+		return nil, fmt.Errorf("%w: memberships cannot be of type superadmin", ErrInvalidMembership)
+	}
+	if err := s.roleService.CheckAssignment(restaurantID, actor, in.Type, in.RoleID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.membershipRepo.Find(restaurantID, user.ID); err == nil {
+		return nil, fmt.Errorf("%w: user already belongs to the restaurant", ErrInvalidMembership)
+	} else if !errors.Is(err, repositories.ErrMembershipNotFound) {
+		return nil, err
+	}
+
+	membership := &models.Membership{
+		UserID:       user.ID,
+		RestaurantID: restaurantID,
+		Type:         in.Type,
+		RoleID:       in.RoleID,
+	}
+	if err := s.membershipRepo.Create(membership); err != nil {
+		return nil, err
+	}
+	membership.User = user
+	return membership, nil
+}
+
+// managed busca o vínculo de outro usuário que o autor pode gerenciar
+func (s *MembershipService) managed(restaurantID, userID uuid.UUID, actor Actor) (*models.Membership, error) {
+	if userID == actor.ID {
+		return nil, fmt.Errorf("%w: you cannot change your own membership", ErrPermissionDenied)
+	}
+
+	membership, err := s.membershipRepo.Find(restaurantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.Type.CanManage(membership.Type) {
+		return nil, fmt.Errorf("%w: you cannot manage %s users", ErrPermissionDenied, membership.Type)
+	}
+	return membership, nil
+}
+
+// UpdateMember altera o tipo e o papel do usuário no restaurante
+func (s *MembershipService) UpdateMember(restaurantID, userID uuid.UUID, actor Actor, in MembershipInput) (*models.Membership, error) {
+	membership, err := s.managed(restaurantID, userID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Type == "" {
+		in.Type = membership.Type
+	}
+	if in.Type == models.UserTypeSuperAdmin {
+		return nil, fmt.Errorf("%w: memberships cannot be of type superadmin", ErrInvalidMembership)
+	}
+	if err := s.roleService.CheckAssignment(restaurantID, actor, in.Type, in.RoleID); err != nil {
+		return nil, err
+	}
+
+	membership.Type = in.Type
+	membership.RoleID = in.RoleID
+	if err := s.membershipRepo.Update(membership); err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+// RemoveMember desvincula o usuário do restaurante; a conta continua valendo nos demais restaurantes
+func (s *MembershipService) RemoveMember(restaurantID, userID uuid.UUID, actor Actor) error {
+	if _, err := s.managed(restaurantID, userID, actor); err != nil {
+		return err
+	}
+	return s.membershipRepo.Delete(restaurantID, userID)
+}
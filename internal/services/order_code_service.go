@@ -0,0 +1,71 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidOrderCodeSettings = errors.New("invalid order code settings")
+
+type OrderCodeService struct {
+	codeRepo repositories.OrderCodeRepository
+}
+
+func NewOrderCodeService(codeRepo repositories.OrderCodeRepository) *OrderCodeService {
+	return &OrderCodeService{
+		codeRepo: codeRepo,
+	}
+}
+
+// Next gera o próximo código de pedido do restaurante de acordo com o formato configurado e retorna
+// também o período da sequência, que junto com o código identifica o pedido
+func (s *OrderCodeService) Next(restaurantID uuid.UUID, at time.Time) (string, string, error) {
+	settings, err := s.codeRepo.FindSettings(restaurantID)
+	if err != nil {
+		return "", "", err
+	}
+
+	period := settings.Period(at)
+	number, err := s.codeRepo.NextValue(restaurantID, period)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate order code: %w", err)
+	}
+
+	return settings.Format(at, number), period, nil
+}
+
+func (s *OrderCodeService) GetSettings(restaurantID uuid.UUID) (*models.OrderCodeSettings, error) {
+	return s.codeRepo.FindSettings(restaurantID)
+}
+
+// UpdateSettings altera o formato dos próximos códigos; pedidos já criados mantêm o código original
+func (s *OrderCodeService) UpdateSettings(settings *models.OrderCodeSettings) error {
+	switch settings.Reset {
+	case models.OrderCodeResetDaily, models.OrderCodeResetMonthly, models.OrderCodeResetNever:
+	default:
+		return fmt.Errorf("%w: reset must be daily, monthly or never", ErrInvalidOrderCodeSettings)
+	}
+
+	if len(settings.Prefix) > 10 {
+		return fmt.Errorf("%w: prefix must have at most 10 characters", ErrInvalidOrderCodeSettings)
+	}
+
+	if settings.Padding < 1 || settings.Padding > 6 {
+		return fmt.Errorf("%w: padding must be between 1 and 6", ErrInvalidOrderCodeSettings)
+	}
+
+	if settings.Timezone == "" {
+		settings.Timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(settings.Timezone); err != nil || settings.Timezone == "Local" {
+		return fmt.Errorf("%w: invalid timezone", ErrInvalidOrderCodeSettings)
+	}
+
+	return s.codeRepo.SaveSettings(settings)
+}
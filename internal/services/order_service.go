@@ -8,47 +8,213 @@ import (
 
 	"api-jet-manager/internal/domain/models"
 	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/realtime"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrTableNotAvailable = errors.New("table is not available")
+	ErrOrderClosed       = errors.New("cannot modify a paid or cancelled order")
+)
+
+// OrderItemHook é executada dentro da transação quando um item é adicionado ou removido de um pedido
+type OrderItemHook func(tx repositories.Transaction, order *models.Order, item *models.OrderItem, actorID uuid.UUID) error
+
+// OrderItemMoveHook é executada dentro da transação quando um item passa de um pedido para outro
+type OrderItemMoveHook func(tx repositories.Transaction, from, to *models.Order, item *models.OrderItem, actorID uuid.UUID) error
+
 type OrderService struct {
-	orderRepo   repositories.OrderRepository
-	tableRepo   repositories.TableRepository
-	financeRepo repositories.FinanceRepository
-	productRepo repositories.ProductRepository
+	uow          repositories.UnitOfWork
+	orderRepo    repositories.OrderRepository
+	tableRepo    repositories.TableRepository
+	productRepo  repositories.ProductRepository
+	addonRepo    repositories.AddonRepository
+	codeService  *OrderCodeService
+	events       realtime.Publisher
+	stateMachine *OrderStateMachine
+	itemAdded    []OrderItemHook
+	itemRemoved  []OrderItemHook
+	itemMoved    []OrderItemMoveHook
 }
 
-func NewOrderService(orderRepo repositories.OrderRepository, tableRepo repositories.TableRepository, financeRepo repositories.FinanceRepository, productRepo repositories.ProductRepository) *OrderService {
-	return &OrderService{
-		orderRepo:   orderRepo,
-		tableRepo:   tableRepo,
-		financeRepo: financeRepo,
-		productRepo: productRepo,
+func NewOrderService(uow repositories.UnitOfWork, orderRepo repositories.OrderRepository, tableRepo repositories.TableRepository, productRepo repositories.ProductRepository, addonRepo repositories.AddonRepository, codeService *OrderCodeService, events realtime.Publisher) *OrderService {
+	s := &OrderService{
+		uow:          uow,
+		orderRepo:    orderRepo,
+		tableRepo:    tableRepo,
+		productRepo:  productRepo,
+		addonRepo:    addonRepo,
+		codeService:  codeService,
+		events:       events,
+		stateMachine: NewOrderStateMachine(),
 	}
+	s.registerEntryActions()
+	return s
+}
+
+// registerEntryActions registra os efeitos colaterais executados na entrada de cada status
+func (s *OrderService) registerEntryActions() {
+	s.stateMachine.OnEnter(models.OrderStatusDelivered, func(t *OrderTransition) error {
+		t.Order.DeliveredAt = &t.At
+		return nil
+	})
+
+	s.stateMachine.OnEnter(models.OrderStatusPaid, func(t *OrderTransition) error {
+		// O pedido só é pago quando os pagamentos registrados quitam o total
+		if t.Order.Balance() > 0 {
+			return ErrOutstandingBalance
+		}
+		t.Order.PaidAt = &t.At
+		// Na retirada o pedido pode ser pago e entregue no mesmo momento
+		if t.Order.Type == models.OrderTypeTakeaway && t.Order.DeliveredAt == nil {
+			t.Order.DeliveredAt = &t.At
+		}
+		return s.releaseTable(t.Tx, t.Order)
+	})
+
+	s.stateMachine.OnEnter(models.OrderStatusCancelled, func(t *OrderTransition) error {
+		// Pagamentos precisam ser estornados antes do cancelamento
+		if t.Order.AmountPaid > 0 {
+			return ErrOrderHasPayments
+		}
+		t.Order.CancelledAt = &t.At
+		return s.releaseTable(t.Tx, t.Order)
+	})
+}
+
+// StateMachine expõe a máquina de estados para que outros serviços registrem ações de entrada
+func (s *OrderService) StateMachine() *OrderStateMachine {
+	return s.stateMachine
+}
+
+// OnItemAdded registra uma ação executada quando um item é adicionado a um pedido já aberto
+func (s *OrderService) OnItemAdded(hook OrderItemHook) {
+	s.itemAdded = append(s.itemAdded, hook)
+}
+
+// OnItemRemoved registra uma ação executada quando um item é removido de um pedido
+func (s *OrderService) OnItemRemoved(hook OrderItemHook) {
+	s.itemRemoved = append(s.itemRemoved, hook)
+}
+
+// OnItemMoved registra uma ação executada quando um item é movido entre pedidos
+func (s *OrderService) OnItemMoved(hook OrderItemMoveHook) {
+	s.itemMoved = append(s.itemMoved, hook)
 }
 
+// releaseTable libera a mesa vinculada ao pedido, caso ela ainda aponte para ele
+func (s *OrderService) releaseTable(tx repositories.Transaction, order *models.Order) error {
+	if order.TableID == nil {
+		return nil
+	}
+
+	tableRepo := s.tableRepo.WithTx(tx)
+	table, err := tableRepo.FindByIDForUpdate(order.RestaurantID, *order.TableID)
+	if err != nil {
+		return err
+	}
+
+	if table.CurrentOrderID != nil && *table.CurrentOrderID != order.ID {
+		return nil
+	}
+
+	if err := tableRepo.Release(order.RestaurantID, table.ID); err != nil {
+		return fmt.Errorf("failed to free table: %w", err)
+	}
+
+	return publishTableGroup(tx, tableRepo, s.events, order.RestaurantID, table.ID)
+}
+
+// reserveCode reserva o código do pedido fora da transação, para não segurar a sequência do restaurante
+func (s *OrderService) reserveCode(order *models.Order) error {
+	if order.Code != "" {
+		return nil
+	}
+	code, period, err := s.codeService.Next(order.RestaurantID, time.Now())
+	if err != nil {
+		return err
+	}
+	order.Code = code
+	order.CodePeriod = period
+	return nil
+}
+
+// CreateOrder cria o pedido com seus itens e, se houver mesa, ocupa a mesa, tudo na mesma transação
 func (s *OrderService) CreateOrder(order *models.Order, orderItems []models.OrderItem) error {
-	// Calcular o valor total do pedido
-	var totalAmount float64
-	for _, item := range orderItems {
-		totalAmount += item.Price * float64(item.Quantity)
+	if err := s.reserveCode(order); err != nil {
+		return err
+	}
+
+	return s.uow.Do(func(tx repositories.Transaction) error {
+		return s.createOrder(tx, order, orderItems)
+	})
+}
+
+// createOrder grava o pedido dentro da transação informada, que já deve ter o código reservado.
+// O evento de criação é publicado após o commit.
+func (s *OrderService) createOrder(tx repositories.Transaction, order *models.Order, orderItems []models.OrderItem) error {
+	orderRepo := s.orderRepo.WithTx(tx)
+	tableRepo := s.tableRepo.WithTx(tx)
+
+	// Bloquear a mesa para que dois pedidos não a ocupem ao mesmo tempo
+	var table *models.Table
+	if order.TableID != nil {
+		var err error
+		table, err = tableRepo.FindByIDForUpdate(order.RestaurantID, *order.TableID)
+		if err != nil {
+			return err
+		}
+
+		if table.Status != models.TableStatusFree && table.Status != models.TableStatusReserved {
+			return ErrTableNotAvailable
+		}
 	}
-	order.TotalAmount = totalAmount
 
 	// Criar o pedido
-	if err := s.orderRepo.Create(order); err != nil {
+	if err := orderRepo.Create(order); err != nil {
 		return err
 	}
 
 	// Adicionar os itens ao pedido
 	for i := range orderItems {
 		orderItems[i].OrderID = order.ID
-		if err := s.orderRepo.AddItem(&orderItems[i]); err != nil {
+		if err := orderRepo.AddItem(&orderItems[i]); err != nil {
+			return err
+		}
+	}
+
+	// Calcular o valor total do pedido a partir dos itens gravados
+	total, err := orderRepo.RecalculateTotal(order.RestaurantID, order.ID)
+	if err != nil {
+		return err
+	}
+	order.TotalAmount = total
+	order.OrderItems = orderItems
+
+	if table != nil {
+		if err := tableRepo.Occupy(order.RestaurantID, table.ID, &order.ID); err != nil {
+			return fmt.Errorf("failed to link order to table: %w", err)
+		}
+
+		if err := publishTableGroup(tx, tableRepo, s.events, order.RestaurantID, table.ID); err != nil {
 			return err
 		}
 	}
 
+	// Registrar o status inicial no histórico
+	if err := orderRepo.AddStatusTransition(&models.OrderStatusTransition{
+		RestaurantID: order.RestaurantID,
+		OrderID:      order.ID,
+		ToStatus:     order.Status,
+		UserID:       &order.UserID,
+	}); err != nil {
+		return err
+	}
+
+	tx.AfterCommit(func() {
+		publishOrder(s.events, order, EventOrderCreated, order)
+	})
 	return nil
 }
 
@@ -84,86 +250,221 @@ func (s *OrderService) List(restaurant_id uuid.UUID) ([]models.Order, error) {
 	return s.orderRepo.List(restaurant_id)
 }
 
-func (s *OrderService) UpdateStatus(restaurant_id uuid.UUID, id uuid.UUID, status models.OrderStatus) error {
-	return s.orderRepo.UpdateStatus(restaurant_id, id, status)
-}
-
-func (s *OrderService) AddItem(restaurant_id uuid.UUID, item *models.OrderItem) error {
-	// Verificar se o produto existe
-	product, err := s.GetProductByID(restaurant_id, item.ProductID)
-	if err != nil {
+// UpdateStatus move o pedido para um novo status respeitando a máquina de estados.
+// Retorna *InvalidTransitionError se a transição não for permitida.
+func (s *OrderService) UpdateStatus(restaurant_id uuid.UUID, id uuid.UUID, status models.OrderStatus, actorID uuid.UUID) (*models.Order, error) {
+	var order *models.Order
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		var err error
+		order, err = s.transition(tx, restaurant_id, id, status, actorID)
 		return err
+	})
+	if err != nil {
+		return nil, err
 	}
+	return order, nil
+}
 
-	// Definir o preço do item de acordo com o preço atual do produto
-	item.Price = product.Price
+// transition aplica a mudança de status dentro de uma transação já aberta.
+// O pedido é bloqueado para que transições concorrentes sejam serializadas.
+func (s *OrderService) transition(tx repositories.Transaction, restaurantID, id uuid.UUID, status models.OrderStatus, actorID uuid.UUID) (*models.Order, error) {
+	return s.transitionOnDevice(tx, restaurantID, id, status, actorID, nil)
+}
 
-	// Adicionar o item
-	if err := s.orderRepo.AddItem(item); err != nil {
-		return err
-	}
+// transitionOnDevice é como transition, registrando também o terminal em que a ação foi feita.
+// actorID pode ser uuid.Nil quando o próprio terminal, sem usuário conectado, é o responsável.
+func (s *OrderService) transitionOnDevice(tx repositories.Transaction, restaurantID, id uuid.UUID, status models.OrderStatus, actorID uuid.UUID, deviceID *uuid.UUID) (*models.Order, error) {
+	orderRepo := s.orderRepo.WithTx(tx)
 
-	// Atualizar o valor total do pedido
-	order, err := s.orderRepo.FindByID(restaurant_id, item.OrderID)
+	order, err := orderRepo.FindByIDForUpdate(restaurantID, id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	order.TotalAmount += item.Price * float64(item.Quantity)
-	return s.orderRepo.Update(order)
+	return s.applyTransition(&OrderTransition{
+		Tx:      tx,
+		Order:   order,
+		To:      status,
+		ActorID: actorID,
+		Device:  deviceID,
+	})
 }
 
-func (s *OrderService) RemoveItem(restaurant_id uuid.UUID, orderID, itemID uuid.UUID) error {
-	// Encontrar o item
-	items, err := s.orderRepo.FindItems(restaurant_id, orderID)
-	if err != nil {
-		return err
+// applyTransition executa a transição sobre um pedido já bloqueado, grava o pedido e o histórico
+// e publica a mudança após o commit
+func (s *OrderService) applyTransition(transition *OrderTransition) (*models.Order, error) {
+	tx, order, actorID := transition.Tx, transition.Order, transition.ActorID
+	orderRepo := s.orderRepo.WithTx(tx)
+
+	if err := s.stateMachine.Apply(transition); err != nil {
+		return nil, err
 	}
 
-	var itemToRemove *models.OrderItem
-	for _, item := range items {
-		if item.ID == itemID {
-			itemToRemove = &item
-			break
-		}
+	if err := orderRepo.Update(order); err != nil {
+		return nil, err
 	}
 
-	if itemToRemove == nil {
-		return errors.New("item not found")
+	var userID *uuid.UUID
+	if actorID != uuid.Nil {
+		userID = &actorID
 	}
 
-	// Remover o item
-	if err := s.orderRepo.RemoveItem(restaurant_id, orderID, itemID); err != nil {
-		return err
+	if err := orderRepo.AddStatusTransition(&models.OrderStatusTransition{
+		RestaurantID: order.RestaurantID,
+		OrderID:      order.ID,
+		FromStatus:   transition.From,
+		ToStatus:     transition.To,
+		UserID:       userID,
+		DeviceID:     transition.Device,
+		CreatedAt:    transition.At,
+	}); err != nil {
+		return nil, err
+	}
+
+	snapshot := *order
+	tx.AfterCommit(func() {
+		publishOrder(s.events, &snapshot, EventOrderStatusChanged, &snapshot)
+	})
+
+	return order, nil
+}
+
+// GetStatusHistory retorna todas as transições de status do pedido em ordem cronológica
+func (s *OrderService) GetStatusHistory(restaurant_id uuid.UUID, id uuid.UUID) ([]models.OrderStatusTransition, error) {
+	return s.orderRepo.FindStatusTransitions(restaurant_id, id)
+}
+
+// AllowedTransitions retorna os próximos status possíveis para o pedido
+func (s *OrderService) AllowedTransitions(order *models.Order) []models.OrderStatus {
+	return s.stateMachine.AllowedTransitions(order.Type, order.Status)
+}
+
+// BuildItem monta o item do pedido a partir do produto e das opções escolhidas.
+// O preço unitário é o preço do produto acrescido do valor dos complementos,
+// calculado de acordo com o PriceMethod de cada grupo.
+func (s *OrderService) BuildItem(restaurant_id uuid.UUID, product *models.Product, quantity int, notes string, selections []OptionSelection) (models.OrderItem, error) {
+	item := models.OrderItem{
+		ProductID: product.ID,
+		Quantity:  quantity,
+		Price:     product.Price,
+		Notes:     notes,
 	}
 
-	// Atualizar o valor total do pedido
-	order, err := s.orderRepo.FindByID(restaurant_id, orderID)
+	addons, err := s.addonRepo.GetAddonsByProductID(restaurant_id, product.ID)
 	if err != nil {
-		return err
+		return item, err
 	}
 
-	order.TotalAmount -= itemToRemove.Price * float64(itemToRemove.Quantity)
-	return s.orderRepo.Update(order)
+	extra, options, err := resolveAddonSelections(addons, selections)
+	if err != nil {
+		return item, err
+	}
+
+	item.Price = item.Price.Add(extra)
+	item.Options = options
+
+	return item, nil
 }
 
-func (s *OrderService) GetProductByID(restaurant_id uuid.UUID, id uuid.UUID) (*models.Product, error) {
-	return s.productRepo.FindByID(restaurant_id, id)
+// AddItem adiciona um item ao pedido e recalcula o total na mesma transação
+func (s *OrderService) AddItem(restaurant_id uuid.UUID, item *models.OrderItem, actorID uuid.UUID) error {
+	return s.uow.Do(func(tx repositories.Transaction) error {
+		orderRepo := s.orderRepo.WithTx(tx)
+
+		// Bloquear o pedido para serializar alterações concorrentes no total
+		order, err := orderRepo.FindByIDForUpdate(restaurant_id, item.OrderID)
+		if err != nil {
+			return err
+		}
+
+		if order.Status == models.OrderStatusPaid || order.Status == models.OrderStatusCancelled {
+			return ErrOrderClosed
+		}
+
+		if err := orderRepo.AddItem(item); err != nil {
+			return err
+		}
+
+		total, err := orderRepo.RecalculateTotal(restaurant_id, order.ID)
+		if err != nil {
+			return err
+		}
+
+		event := OrderItemEvent{OrderID: order.ID, Item: item, TotalAmount: total}
+		tx.AfterCommit(func() {
+			publishOrder(s.events, order, EventOrderItemAdded, event)
+		})
+
+		for _, hook := range s.itemAdded {
+			if err := hook(tx, order, item, actorID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
-func (s *OrderService) RegisterPayment(order *models.Order, userID uuid.UUID) error {
-	// Registrar a transação financeira
-	transaction := &models.FinancialTransaction{
-		Type:        models.TransactionTypeIncome,
-		Category:    models.TransactionCategorySales,
-		Amount:      order.TotalAmount,
-		Description: fmt.Sprintf("Payment for order #%d", order.ID),
-		OrderID:     &order.ID,
-		UserID:      userID,
-		Date:        time.Now(),
-	}
+// RemoveItem remove um item do pedido e recalcula o total na mesma transação
+func (s *OrderService) RemoveItem(restaurant_id uuid.UUID, orderID, itemID uuid.UUID, actorID uuid.UUID) error {
+	return s.uow.Do(func(tx repositories.Transaction) error {
+		orderRepo := s.orderRepo.WithTx(tx)
+
+		order, err := orderRepo.FindByIDForUpdate(restaurant_id, orderID)
+		if err != nil {
+			return err
+		}
+
+		if order.Status == models.OrderStatusPaid || order.Status == models.OrderStatusCancelled {
+			return ErrOrderClosed
+		}
+
+		items, err := orderRepo.FindItems(restaurant_id, orderID)
+		if err != nil {
+			return err
+		}
+
+		var removed *models.OrderItem
+		for i := range items {
+			if items[i].ID == itemID {
+				removed = &items[i]
+				break
+			}
+		}
+
+		if removed == nil {
+			return repositories.ErrOrderItemNotFound
+		}
+
+		if err := orderRepo.RemoveItem(restaurant_id, orderID, itemID); err != nil {
+			return err
+		}
+
+		total, err := orderRepo.RecalculateTotal(restaurant_id, orderID)
+		if err != nil {
+			return err
+		}
 
-	return s.financeRepo.Create(transaction)
+		// O total não pode ficar abaixo do que já foi pago
+		if total < order.AmountPaid {
+			return ErrOrderHasPayments
+		}
+
+		event := OrderItemEvent{OrderID: order.ID, Item: removed, TotalAmount: total}
+		tx.AfterCommit(func() {
+			publishOrder(s.events, order, EventOrderItemRemoved, event)
+		})
+
+		for _, hook := range s.itemRemoved {
+			if err := hook(tx, order, removed, actorID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *OrderService) GetProductByID(restaurant_id uuid.UUID, id uuid.UUID) (*models.Product, error) {
+	return s.productRepo.FindByID(restaurant_id, id)
 }
 
 // FindDeliveryOrdersByDate retorna todos os pedidos de delivery para uma data específica
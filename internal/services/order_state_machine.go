@@ -0,0 +1,127 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+// InvalidTransitionError é retornado quando um pedido tenta mudar para um status não permitido
+type InvalidTransitionError struct {
+	OrderType models.OrderType
+	From      models.OrderStatus
+	To        models.OrderStatus
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("invalid status transition for %s order: %s -> %s", e.OrderType, e.From, e.To)
+}
+
+// OrderTransition descreve uma mudança de status em andamento.
+// As ações de entrada devem usar Tx para que seus efeitos façam parte da mesma transação.
+type OrderTransition struct {
+	Tx      repositories.Transaction
+	Order   *models.Order
+	From    models.OrderStatus
+	To      models.OrderStatus
+	ActorID uuid.UUID  // uuid.Nil quando a ação veio de um terminal sem usuário conectado
+	Device  *uuid.UUID // Terminal em que a ação foi feita, se houver
+	At      time.Time
+	// Forced ignora as regras de transição. Usado apenas por operações que encerram o pedido
+	// por outro caminho, como a junção de pedidos; as ações de entrada são executadas normalmente.
+	Forced bool
+}
+
+// OrderEntryAction é executada quando um pedido entra em um status
+type OrderEntryAction func(transition *OrderTransition) error
+
+// OrderStateMachine define as transições permitidas entre os status de um pedido
+// para cada tipo de pedido e os efeitos colaterais executados na entrada de cada status
+type OrderStateMachine struct {
+	transitions map[models.OrderType]map[models.OrderStatus][]models.OrderStatus
+	onEnter     map[models.OrderStatus][]OrderEntryAction
+}
+
+// Em todos os tipos um pedido pronto pode voltar ao preparo quando a cozinha chama uma comanda de volta
+// ou quando um novo item é adicionado. No consumo no local o pedido já servido também volta ao preparo
+// quando o cliente pede mais alguma coisa, como a sobremesa.
+func NewOrderStateMachine() *OrderStateMachine {
+	return &OrderStateMachine{
+		transitions: map[models.OrderType]map[models.OrderStatus][]models.OrderStatus{
+			// Consumo no local: depois de servido o pedido não pode mais ser cancelado
+			models.OrderTypeInHouse: {
+				models.OrderStatusPending:   {models.OrderStatusPreparing, models.OrderStatusCancelled},
+				models.OrderStatusPreparing: {models.OrderStatusReady, models.OrderStatusCancelled},
+				models.OrderStatusReady:     {models.OrderStatusDelivered, models.OrderStatusPreparing},
+				models.OrderStatusDelivered: {models.OrderStatusPaid, models.OrderStatusPreparing},
+			},
+			// Delivery: o pedido ainda pode ser cancelado se a entrega não sair
+			models.OrderTypeDelivery: {
+				models.OrderStatusPending:   {models.OrderStatusPreparing, models.OrderStatusCancelled},
+				models.OrderStatusPreparing: {models.OrderStatusReady, models.OrderStatusCancelled},
+				models.OrderStatusReady:     {models.OrderStatusDelivered, models.OrderStatusPreparing, models.OrderStatusCancelled},
+				models.OrderStatusDelivered: {models.OrderStatusPaid},
+			},
+			// Retirada: o cliente pode pagar no balcão no momento da retirada
+			models.OrderTypeTakeaway: {
+				models.OrderStatusPending:   {models.OrderStatusPreparing, models.OrderStatusCancelled},
+				models.OrderStatusPreparing: {models.OrderStatusReady, models.OrderStatusCancelled},
+				models.OrderStatusReady:     {models.OrderStatusDelivered, models.OrderStatusPaid, models.OrderStatusPreparing, models.OrderStatusCancelled},
+				models.OrderStatusDelivered: {models.OrderStatusPaid},
+			},
+		},
+		onEnter: make(map[models.OrderStatus][]OrderEntryAction),
+	}
+}
+
+// OnEnter registra uma ação a ser executada quando um pedido entra no status informado.
+// As ações são executadas na ordem em que foram registradas.
+func (m *OrderStateMachine) OnEnter(status models.OrderStatus, action OrderEntryAction) {
+	m.onEnter[status] = append(m.onEnter[status], action)
+}
+
+// AllowedTransitions retorna os status para os quais o pedido pode ir a partir do status atual
+func (m *OrderStateMachine) AllowedTransitions(orderType models.OrderType, from models.OrderStatus) []models.OrderStatus {
+	if orderType == "" {
+		orderType = models.OrderTypeInHouse
+	}
+	return m.transitions[orderType][from]
+}
+
+// CanTransition verifica se a transição é permitida para o tipo de pedido
+func (m *OrderStateMachine) CanTransition(orderType models.OrderType, from, to models.OrderStatus) bool {
+	for _, allowed := range m.AllowedTransitions(orderType, from) {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply valida a transição, executa as ações de entrada do novo status e atualiza o pedido em memória.
+// A persistência do pedido fica a cargo de quem chama.
+func (m *OrderStateMachine) Apply(transition *OrderTransition) error {
+	order := transition.Order
+	transition.From = order.Status
+
+	if !transition.Forced && !m.CanTransition(order.Type, transition.From, transition.To) {
+		return &InvalidTransitionError{OrderType: order.Type, From: transition.From, To: transition.To}
+	}
+
+	if transition.At.IsZero() {
+		transition.At = time.Now()
+	}
+
+	for _, action := range m.onEnter[transition.To] {
+		if err := action(transition); err != nil {
+			return err
+		}
+	}
+
+	order.Status = transition.To
+	return nil
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"api-jet-manager/internal/domain/models"
+)
+
+var allOrderStatuses = []models.OrderStatus{
+	models.OrderStatusPending,
+	models.OrderStatusPreparing,
+	models.OrderStatusReady,
+	models.OrderStatusDelivered,
+	models.OrderStatusPaid,
+	models.OrderStatusCancelled,
+}
+
+func TestOrderStateMachineTransitions(t *testing.T) {
+	tests := []struct {
+		orderType models.OrderType
+		from      models.OrderStatus
+		allowed   []models.OrderStatus
+	}{
+		{models.OrderTypeInHouse, models.OrderStatusPending, []models.OrderStatus{models.OrderStatusPreparing, models.OrderStatusCancelled}},
+		{models.OrderTypeInHouse, models.OrderStatusPreparing, []models.OrderStatus{models.OrderStatusReady, models.OrderStatusCancelled}},
+		{models.OrderTypeInHouse, models.OrderStatusReady, []models.OrderStatus{models.OrderStatusDelivered, models.OrderStatusPreparing}},
+		{models.OrderTypeInHouse, models.OrderStatusDelivered, []models.OrderStatus{models.OrderStatusPaid, models.OrderStatusPreparing}},
+		{models.OrderTypeInHouse, models.OrderStatusPaid, nil},
+		{models.OrderTypeInHouse, models.OrderStatusCancelled, nil},
+
+		{models.OrderTypeDelivery, models.OrderStatusPending, []models.OrderStatus{models.OrderStatusPreparing, models.OrderStatusCancelled}},
+		{models.OrderTypeDelivery, models.OrderStatusPreparing, []models.OrderStatus{models.OrderStatusReady, models.OrderStatusCancelled}},
+		{models.OrderTypeDelivery, models.OrderStatusReady, []models.OrderStatus{models.OrderStatusDelivered, models.OrderStatusPreparing, models.OrderStatusCancelled}},
+		{models.OrderTypeDelivery, models.OrderStatusDelivered, []models.OrderStatus{models.OrderStatusPaid}},
+		{models.OrderTypeDelivery, models.OrderStatusPaid, nil},
+		{models.OrderTypeDelivery, models.OrderStatusCancelled, nil},
+
+		{models.OrderTypeTakeaway, models.OrderStatusPending, []models.OrderStatus{models.OrderStatusPreparing, models.OrderStatusCancelled}},
+		{models.OrderTypeTakeaway, models.OrderStatusPreparing, []models.OrderStatus{models.OrderStatusReady, models.OrderStatusCancelled}},
+		{models.OrderTypeTakeaway, models.OrderStatusReady, []models.OrderStatus{models.OrderStatusDelivered, models.OrderStatusPaid, models.OrderStatusPreparing, models.OrderStatusCancelled}},
+		{models.OrderTypeTakeaway, models.OrderStatusDelivered, []models.OrderStatus{models.OrderStatusPaid}},
+		{models.OrderTypeTakeaway, models.OrderStatusPaid, nil},
+		{models.OrderTypeTakeaway, models.OrderStatusCancelled, nil},
+
+		// Pedidos sem tipo seguem as regras do consumo no local
+		{"", models.OrderStatusDelivered, []models.OrderStatus{models.OrderStatusPaid, models.OrderStatusPreparing}},
+	}
+
+	m := NewOrderStateMachine()
+	for _, tt := range tests {
+		allowed := make(map[models.OrderStatus]bool)
+		for _, status := range tt.allowed {
+			allowed[status] = true
+		}
+
+		for _, to := range allOrderStatuses {
+			if got := m.CanTransition(tt.orderType, tt.from, to); got != allowed[to] {
+				t.Errorf("CanTransition(%q, %s, %s) = %v, want %v", tt.orderType, tt.from, to, got, allowed[to])
+			}
+		}
+	}
+}
+
+func TestOrderStateMachineApply(t *testing.T) {
+	errAction := errors.New("action failed")
+
+	tests := []struct {
+		name      string
+		orderType models.OrderType
+		from      models.OrderStatus
+		to        models.OrderStatus
+		forced    bool
+		actionErr error
+		wantErr   bool
+		wantRun   bool
+	}{
+		{name: "allowed", orderType: models.OrderTypeInHouse, from: models.OrderStatusReady, to: models.OrderStatusDelivered, wantRun: true},
+		{name: "not allowed", orderType: models.OrderTypeInHouse, from: models.OrderStatusDelivered, to: models.OrderStatusCancelled, wantErr: true},
+		{name: "forced", orderType: models.OrderTypeInHouse, from: models.OrderStatusDelivered, to: models.OrderStatusCancelled, forced: true, wantRun: true},
+		{name: "action fails", orderType: models.OrderTypeDelivery, from: models.OrderStatusReady, to: models.OrderStatusDelivered, actionErr: errAction, wantErr: true, wantRun: true},
+	}
+
+	for _, tt := range tests {
+		m := NewOrderStateMachine()
+
+		ran := false
+		m.OnEnter(tt.to, func(transition *OrderTransition) error {
+			ran = true
+			if transition.From != tt.from || transition.At.IsZero() {
+				t.Errorf("%s: action got From=%s At=%v", tt.name, transition.From, transition.At)
+			}
+			return tt.actionErr
+		})
+
+		order := &models.Order{Type: tt.orderType, Status: tt.from}
+		err := m.Apply(&OrderTransition{Order: order, To: tt.to, Forced: tt.forced})
+
+		if ran != tt.wantRun {
+			t.Errorf("%s: entry action ran = %v, want %v", tt.name, ran, tt.wantRun)
+		}
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected error", tt.name)
+			}
+			if order.Status != tt.from {
+				t.Errorf("%s: status changed to %s on error", tt.name, order.Status)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if order.Status != tt.to {
+			t.Errorf("%s: status = %s, want %s", tt.name, order.Status, tt.to)
+		}
+	}
+}
+
+func TestOrderStateMachineInvalidTransitionError(t *testing.T) {
+	m := NewOrderStateMachine()
+	order := &models.Order{Type: models.OrderTypeDelivery, Status: models.OrderStatusPaid}
+
+	err := m.Apply(&OrderTransition{Order: order, To: models.OrderStatusPreparing})
+
+	var invalid *InvalidTransitionError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Apply error = %v, want *InvalidTransitionError", err)
+	}
+	if invalid.OrderType != models.OrderTypeDelivery || invalid.From != models.OrderStatusPaid || invalid.To != models.OrderStatusPreparing {
+		t.Errorf("InvalidTransitionError = %+v", invalid)
+	}
+}
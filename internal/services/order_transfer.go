@@ -0,0 +1,264 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/realtime"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidOrderTransfer = errors.New("invalid order transfer")
+
+// lockOrderPair bloqueia dois pedidos sempre na mesma ordem, evitando deadlocks entre
+// transferências concorrentes em sentidos opostos, e garante que ambos estão abertos
+func (s *OrderService) lockOrderPair(tx repositories.Transaction, restaurantID, fromID, toID uuid.UUID) (*models.Order, *models.Order, error) {
+	if fromID == toID {
+		return nil, nil, fmt.Errorf("%w: source and target orders are the same", ErrInvalidOrderTransfer)
+	}
+
+	orderRepo := s.orderRepo.WithTx(tx)
+	ids := []uuid.UUID{fromID, toID}
+	if toID.String() < fromID.String() {
+		ids = []uuid.UUID{toID, fromID}
+	}
+
+	locked := make(map[uuid.UUID]*models.Order, 2)
+	for _, id := range ids {
+		order, err := orderRepo.FindByIDForUpdate(restaurantID, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		if order.Status == models.OrderStatusPaid || order.Status == models.OrderStatusCancelled {
+			return nil, nil, ErrOrderClosed
+		}
+		locked[id] = order
+	}
+	return locked[fromID], locked[toID], nil
+}
+
+// moveItems passa os itens para o pedido de destino, executa as ações registradas e
+// recalcula os totais dos dois pedidos. O total de origem não pode ficar abaixo do que já foi pago.
+func (s *OrderService) moveItems(tx repositories.Transaction, from, to *models.Order, items []models.OrderItem, actorID uuid.UUID) error {
+	orderRepo := s.orderRepo.WithTx(tx)
+
+	for i := range items {
+		if err := orderRepo.MoveItem(from.RestaurantID, items[i].ID, to.ID); err != nil {
+			return err
+		}
+		items[i].OrderID = to.ID
+
+		for _, hook := range s.itemMoved {
+			if err := hook(tx, from, to, &items[i], actorID); err != nil {
+				return err
+			}
+		}
+	}
+
+	fromTotal, err := orderRepo.RecalculateTotal(from.RestaurantID, from.ID)
+	if err != nil {
+		return err
+	}
+	if fromTotal < from.AmountPaid {
+		return ErrOrderHasPayments
+	}
+
+	toTotal, err := orderRepo.RecalculateTotal(to.RestaurantID, to.ID)
+	if err != nil {
+		return err
+	}
+	from.TotalAmount = fromTotal
+	to.TotalAmount = toTotal
+
+	fromSnapshot := *from
+	toDelivery := to.Type == models.OrderTypeDelivery && from.Type != models.OrderTypeDelivery
+	for i := range items {
+		event := OrderItemMovedEvent{
+			FromOrderID: from.ID,
+			ToOrderID:   to.ID,
+			Item:        &items[i],
+			FromTotal:   fromTotal,
+			ToTotal:     toTotal,
+		}
+		tx.AfterCommit(func() {
+			publishOrder(s.events, &fromSnapshot, EventOrderItemMoved, event)
+			if toDelivery {
+				s.events.Publish(fromSnapshot.RestaurantID, realtime.TopicDelivery, EventOrderItemMoved, event)
+			}
+		})
+	}
+	return nil
+}
+
+// TransferOrder leva um pedido de consumo no local para outra mesa. A mesa de origem é liberada
+// e a de destino é ocupada na mesma transação.
+func (s *OrderService) TransferOrder(restaurantID, orderID, tableID, actorID uuid.UUID) (*models.Order, error) {
+	var order *models.Order
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		orderRepo := s.orderRepo.WithTx(tx)
+		tableRepo := s.tableRepo.WithTx(tx)
+
+		var err error
+		order, err = orderRepo.FindByIDForUpdate(restaurantID, orderID)
+		if err != nil {
+			return err
+		}
+		if order.Status == models.OrderStatusPaid || order.Status == models.OrderStatusCancelled {
+			return ErrOrderClosed
+		}
+		if order.Type != models.OrderTypeInHouse {
+			return fmt.Errorf("%w: only in-house orders can change tables", ErrInvalidOrderTransfer)
+		}
+
+		// Bloquear as mesas em ordem de ID, como nas demais operações com mais de uma mesa
+		tableIDs := []uuid.UUID{tableID}
+		if order.TableID != nil {
+			tableIDs = append(tableIDs, *order.TableID)
+			if order.TableID.String() < tableID.String() {
+				tableIDs[0], tableIDs[1] = tableIDs[1], tableIDs[0]
+			}
+		}
+
+		var target *models.Table
+		for _, id := range tableIDs {
+			table, err := tableRepo.FindByIDForUpdate(restaurantID, id)
+			if err != nil {
+				return err
+			}
+			if id == tableID {
+				target = table
+			}
+		}
+
+		if target.CurrentOrderID != nil && *target.CurrentOrderID == order.ID {
+			return fmt.Errorf("%w: order is already at this table", ErrInvalidOrderTransfer)
+		}
+		if target.Status != models.TableStatusFree && target.Status != models.TableStatusReserved {
+			return ErrTableNotAvailable
+		}
+
+		if err := s.releaseTable(tx, order); err != nil {
+			return err
+		}
+
+		order.TableID = &tableID
+		if err := orderRepo.Update(order); err != nil {
+			return err
+		}
+
+		if err := tableRepo.Occupy(restaurantID, tableID, &order.ID); err != nil {
+			return fmt.Errorf("failed to link order to table: %w", err)
+		}
+		if err := publishTableGroup(tx, tableRepo, s.events, restaurantID, tableID); err != nil {
+			return err
+		}
+
+		snapshot := *order
+		tx.AfterCommit(func() {
+			publishOrder(s.events, &snapshot, EventOrderTableChanged, &snapshot)
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// MoveItems passa itens de um pedido aberto para outro
+func (s *OrderService) MoveItems(restaurantID, fromOrderID, toOrderID uuid.UUID, itemIDs []uuid.UUID, actorID uuid.UUID) error {
+	if len(itemIDs) == 0 {
+		return fmt.Errorf("%w: no items to move", ErrInvalidOrderTransfer)
+	}
+
+	return s.uow.Do(func(tx repositories.Transaction) error {
+		from, to, err := s.lockOrderPair(tx, restaurantID, fromOrderID, toOrderID)
+		if err != nil {
+			return err
+		}
+
+		items, err := s.orderRepo.WithTx(tx).FindItems(restaurantID, from.ID)
+		if err != nil {
+			return err
+		}
+
+		byID := make(map[uuid.UUID]models.OrderItem, len(items))
+		for _, item := range items {
+			byID[item.ID] = item
+		}
+
+		moving := make([]models.OrderItem, 0, len(itemIDs))
+		seen := make(map[uuid.UUID]bool, len(itemIDs))
+		for _, id := range itemIDs {
+			item, ok := byID[id]
+			if !ok {
+				return repositories.ErrOrderItemNotFound
+			}
+			if !seen[id] {
+				seen[id] = true
+				moving = append(moving, item)
+			}
+		}
+
+		return s.moveItems(tx, from, to, moving, actorID)
+	})
+}
+
+// MergeOrders junta o pedido de origem ao de destino: todos os itens passam para o destino,
+// o pedido de origem é encerrado como cancelado e a mesa dele é liberada.
+// O pedido de origem não pode ter pagamentos registrados.
+func (s *OrderService) MergeOrders(restaurantID, targetOrderID, sourceOrderID, actorID uuid.UUID) (*models.Order, error) {
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		source, target, err := s.lockOrderPair(tx, restaurantID, sourceOrderID, targetOrderID)
+		if err != nil {
+			return err
+		}
+		if source.AmountPaid > 0 {
+			return ErrOrderHasPayments
+		}
+
+		orderRepo := s.orderRepo.WithTx(tx)
+		items, err := orderRepo.FindItems(restaurantID, source.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.moveItems(tx, source, target, items, actorID); err != nil {
+			return err
+		}
+
+		// As ações dos itens movidos podem ter alterado o status da origem, então o pedido é recarregado.
+		// O encerramento ignora as regras de transição: um pedido já servido também pode ser juntado.
+		source, err = orderRepo.FindByIDForUpdate(restaurantID, source.ID)
+		if err != nil {
+			return err
+		}
+		if _, err := s.applyTransition(&OrderTransition{
+			Tx:      tx,
+			Order:   source,
+			To:      models.OrderStatusCancelled,
+			ActorID: actorID,
+			Forced:  true,
+		}); err != nil {
+			return err
+		}
+
+		merged, err := orderRepo.FindByID(restaurantID, target.ID)
+		if err != nil {
+			return err
+		}
+		event := OrderMergedEvent{SourceOrderID: source.ID, Order: merged}
+		tx.AfterCommit(func() {
+			publishOrder(s.events, merged, EventOrderMerged, event)
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.orderRepo.FindByID(restaurantID, targetOrderID)
+}
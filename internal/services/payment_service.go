@@ -0,0 +1,332 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/realtime"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidPayment     = errors.New("invalid payment")
+	ErrOutstandingBalance = errors.New("order has an outstanding balance")
+	ErrOrderHasPayments   = errors.New("order has registered payments")
+)
+
+// PaymentItemInput indica a quantidade de um item do pedido paga em uma divisão por item
+type PaymentItemInput struct {
+	OrderItemID uuid.UUID
+	Quantity    int
+}
+
+// PaymentInput descreve um pagamento a ser registrado.
+// Se Amount for zero, paga o valor dos itens informados ou, sem itens, todo o saldo do pedido.
+// Se Tendered for zero, considera que o cliente entregou exatamente o valor pago.
+type PaymentInput struct {
+	Method     models.PaymentMethod
+	Amount     models.Money
+	Tendered   models.Money
+	PayerLabel string
+	Items      []PaymentItemInput
+	ActorID    uuid.UUID
+	// CashSessionID escolhe o turno de caixa; vazio usa o turno do operador ou o único aberto
+	CashSessionID *uuid.UUID
+}
+
+type PaymentService struct {
+	uow          repositories.UnitOfWork
+	paymentRepo  repositories.PaymentRepository
+	orderRepo    repositories.OrderRepository
+	financeRepo  repositories.FinanceRepository
+	cashService  *CashService
+	orderService *OrderService
+	events       realtime.Publisher
+}
+
+func NewPaymentService(uow repositories.UnitOfWork, paymentRepo repositories.PaymentRepository, orderRepo repositories.OrderRepository, financeRepo repositories.FinanceRepository, cashService *CashService, orderService *OrderService, events realtime.Publisher) *PaymentService {
+	return &PaymentService{
+		uow:          uow,
+		paymentRepo:  paymentRepo,
+		orderRepo:    orderRepo,
+		financeRepo:  financeRepo,
+		cashService:  cashService,
+		orderService: orderService,
+		events:       events,
+	}
+}
+
+func invalidPayment(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidPayment, fmt.Sprintf(format, args...))
+}
+
+func validPaymentMethod(method models.PaymentMethod) bool {
+	switch method {
+	case models.PaymentMethodCash, models.PaymentMethodCreditCard, models.PaymentMethodDebitCard,
+		models.PaymentMethodPix, models.PaymentMethodVoucher, models.PaymentMethodOther:
+		return true
+	}
+	return false
+}
+
+// Register registra um pagamento parcial do pedido e a receita correspondente, atribuindo-o ao turno de caixa aberto.
+// Quando o saldo chega a zero e o status atual permite, o pedido é movido para pago na mesma transação.
+func (s *PaymentService) Register(restaurantID, orderID uuid.UUID, input PaymentInput) (*models.Payment, *models.Order, error) {
+	if !validPaymentMethod(input.Method) {
+		return nil, nil, invalidPayment("unknown payment method %q", input.Method)
+	}
+
+	var payment *models.Payment
+	var order *models.Order
+
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		orderRepo := s.orderRepo.WithTx(tx)
+		paymentRepo := s.paymentRepo.WithTx(tx)
+
+		var err error
+		order, err = orderRepo.FindByIDForUpdate(restaurantID, orderID)
+		if err != nil {
+			return err
+		}
+
+		if order.Status == models.OrderStatusPaid || order.Status == models.OrderStatusCancelled {
+			return ErrOrderClosed
+		}
+
+		balance := order.Balance()
+		if balance <= 0 {
+			return invalidPayment("order has no outstanding balance")
+		}
+
+		payment = &models.Payment{
+			RestaurantID: restaurantID,
+			OrderID:      orderID,
+			Method:       input.Method,
+			PayerLabel:   input.PayerLabel,
+			UserID:       input.ActorID,
+		}
+
+		// Divisão por item: o valor padrão é a soma dos itens escolhidos
+		var itemsTotal models.Money
+		if len(input.Items) > 0 {
+			payment.Items, itemsTotal, err = s.resolveItems(orderRepo, paymentRepo, order, input.Items)
+			if err != nil {
+				return err
+			}
+		}
+
+		amount := input.Amount
+		switch {
+		case amount.IsZero() && len(input.Items) > 0:
+			amount = itemsTotal
+		case amount.IsZero():
+			amount = balance
+		}
+
+		if amount <= 0 {
+			return invalidPayment("amount must be greater than zero")
+		}
+		if amount > balance {
+			return invalidPayment("amount %s exceeds outstanding balance %s", amount, balance)
+		}
+
+		tendered := input.Tendered
+		if tendered.IsZero() {
+			tendered = amount
+		}
+		if input.Method == models.PaymentMethodCash {
+			if tendered < amount {
+				return invalidPayment("tendered amount is lower than the payment amount")
+			}
+		} else if tendered != amount {
+			return invalidPayment("change is only given for cash payments")
+		}
+
+		payment.Amount = amount
+		payment.Tendered = tendered
+		payment.ChangeGiven = tendered.Sub(amount)
+
+		session, err := s.cashService.sessionForPayment(tx, restaurantID, input.CashSessionID, input.ActorID, input.Method)
+		if err != nil {
+			return err
+		}
+		if session != nil {
+			payment.CashSessionID = &session.ID
+		}
+
+		// Cada pagamento gera sua própria receita com a forma de pagamento
+		description := fmt.Sprintf("Payment for order %s", order.Code)
+		if payment.PayerLabel != "" {
+			description = fmt.Sprintf("%s (%s)", description, payment.PayerLabel)
+		}
+
+		transaction := &models.FinancialTransaction{
+			RestaurantID:  restaurantID,
+			Type:          models.TransactionTypeIncome,
+			Category:      models.TransactionCategorySales,
+			Amount:        amount,
+			Description:   description,
+			OrderID:       &order.ID,
+			UserID:        input.ActorID,
+			PaymentMethod: string(input.Method),
+			Date:          time.Now(),
+		}
+		if err := s.financeRepo.WithTx(tx).Create(transaction); err != nil {
+			return fmt.Errorf("failed to register payment: %w", err)
+		}
+		payment.FinancialTransactionID = &transaction.ID
+
+		if err := paymentRepo.Create(payment); err != nil {
+			return err
+		}
+
+		order.AmountPaid = order.AmountPaid.Add(amount)
+		if err := orderRepo.Update(order); err != nil {
+			return err
+		}
+
+		// Saldo quitado: fecha o pedido se o fluxo do tipo de pedido permitir
+		if order.Balance().IsZero() && s.orderService.StateMachine().CanTransition(order.Type, order.Status, models.OrderStatusPaid) {
+			order, err = s.orderService.transition(tx, restaurantID, orderID, models.OrderStatusPaid, input.ActorID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	publishOrder(s.events, order, EventPaymentRegistered, PaymentEvent{
+		OrderID:    order.ID,
+		Payment:    payment,
+		AmountPaid: order.AmountPaid,
+		Balance:    order.Balance(),
+	})
+
+	return payment, order, nil
+}
+
+// resolveItems valida as quantidades pagas por item contra o que ainda não foi pago
+func (s *PaymentService) resolveItems(orderRepo repositories.OrderRepository, paymentRepo repositories.PaymentRepository, order *models.Order, inputs []PaymentItemInput) ([]models.PaymentItem, models.Money, error) {
+	items, err := orderRepo.FindItems(order.RestaurantID, order.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	byID := make(map[uuid.UUID]models.OrderItem, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+
+	paid, err := paymentRepo.PaidQuantities(order.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total models.Money
+	paymentItems := make([]models.PaymentItem, 0, len(inputs))
+	for _, input := range inputs {
+		item, ok := byID[input.OrderItemID]
+		if !ok {
+			return nil, 0, invalidPayment("item %s does not belong to this order", input.OrderItemID)
+		}
+
+		quantity := input.Quantity
+		if quantity <= 0 {
+			quantity = item.Quantity - paid[item.ID]
+		}
+
+		if quantity <= 0 || paid[item.ID]+quantity > item.Quantity {
+			return nil, 0, invalidPayment("item %s has only %d unit(s) left to pay", item.ID, item.Quantity-paid[item.ID])
+		}
+		paid[item.ID] += quantity
+
+		amount := item.Price.Mul(quantity)
+		total = total.Add(amount)
+
+		paymentItems = append(paymentItems, models.PaymentItem{
+			OrderItemID: item.ID,
+			Quantity:    quantity,
+			Amount:      amount,
+		})
+	}
+
+	return paymentItems, total, nil
+}
+
+// ListByOrder retorna os pagamentos do pedido em ordem cronológica
+func (s *PaymentService) ListByOrder(restaurantID, orderID uuid.UUID) ([]models.Payment, error) {
+	if _, err := s.orderRepo.FindByID(restaurantID, orderID); err != nil {
+		return nil, err
+	}
+	return s.paymentRepo.FindByOrder(restaurantID, orderID)
+}
+
+// Void estorna um pagamento de um pedido ainda aberto, removendo também a receita gerada.
+// Pagamentos de turnos de caixa já fechados não podem ser estornados.
+func (s *PaymentService) Void(restaurantID, orderID, paymentID uuid.UUID) (*models.Order, error) {
+	var order *models.Order
+	var payment *models.Payment
+
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		orderRepo := s.orderRepo.WithTx(tx)
+		paymentRepo := s.paymentRepo.WithTx(tx)
+
+		var err error
+		order, err = orderRepo.FindByIDForUpdate(restaurantID, orderID)
+		if err != nil {
+			return err
+		}
+
+		if order.Status == models.OrderStatusPaid || order.Status == models.OrderStatusCancelled {
+			return ErrOrderClosed
+		}
+
+		payment, err = paymentRepo.FindByID(restaurantID, paymentID)
+		if err != nil {
+			return err
+		}
+		if payment.OrderID != order.ID {
+			return repositories.ErrPaymentNotFound
+		}
+
+		// Turnos fechados já foram conferidos e não podem mudar
+		if payment.CashSessionID != nil {
+			if err := s.cashService.ensureOpen(tx, restaurantID, *payment.CashSessionID); err != nil {
+				return err
+			}
+		}
+
+		if err := paymentRepo.Delete(restaurantID, payment.ID); err != nil {
+			return err
+		}
+
+		if payment.FinancialTransactionID != nil {
+			if err := s.financeRepo.WithTx(tx).Delete(restaurantID, *payment.FinancialTransactionID); err != nil {
+				return err
+			}
+		}
+
+		order.AmountPaid = order.AmountPaid.Sub(payment.Amount)
+		return orderRepo.Update(order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publishOrder(s.events, order, EventPaymentVoided, PaymentEvent{
+		OrderID:    order.ID,
+		Payment:    payment,
+		AmountPaid: order.AmountPaid,
+		Balance:    order.Balance(),
+	})
+
+	return order, nil
+}
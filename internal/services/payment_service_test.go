@@ -0,0 +1,320 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/realtime"
+
+	"github.com/google/uuid"
+)
+
+// fakeTransaction executa as funções de AfterCommit quando fakeUnitOfWork confirma a transação
+type fakeTransaction struct {
+	afterCommit []func()
+}
+
+func (t *fakeTransaction) AfterCommit(fn func()) {
+	t.afterCommit = append(t.afterCommit, fn)
+}
+
+type fakeUnitOfWork struct{}
+
+func (fakeUnitOfWork) Do(fn func(tx repositories.Transaction) error) error {
+	tx := &fakeTransaction{}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	for _, fn := range tx.afterCommit {
+		fn()
+	}
+	return nil
+}
+
+type fakePublisher struct{}
+
+func (fakePublisher) Publish(restaurantID uuid.UUID, topic realtime.Topic, eventType string, data interface{}) {
+}
+
+// fakeOrderRepository guarda um único pedido; cada leitura devolve uma cópia, como faria o banco
+type fakeOrderRepository struct {
+	repositories.OrderRepository
+	order       models.Order
+	items       []models.OrderItem
+	transitions []models.OrderStatusTransition
+}
+
+func (r *fakeOrderRepository) WithTx(tx repositories.Transaction) repositories.OrderRepository {
+	return r
+}
+
+func (r *fakeOrderRepository) FindByIDForUpdate(restaurantID, id uuid.UUID) (*models.Order, error) {
+	if r.order.RestaurantID != restaurantID || r.order.ID != id {
+		return nil, repositories.ErrOrderNotFound
+	}
+	order := r.order
+	return &order, nil
+}
+
+func (r *fakeOrderRepository) FindItems(restaurantID, orderID uuid.UUID) ([]models.OrderItem, error) {
+	return r.items, nil
+}
+
+func (r *fakeOrderRepository) Update(order *models.Order) error {
+	r.order = *order
+	return nil
+}
+
+func (r *fakeOrderRepository) AddStatusTransition(transition *models.OrderStatusTransition) error {
+	r.transitions = append(r.transitions, *transition)
+	return nil
+}
+
+type fakePaymentRepository struct {
+	repositories.PaymentRepository
+	payments []*models.Payment
+}
+
+func (r *fakePaymentRepository) WithTx(tx repositories.Transaction) repositories.PaymentRepository {
+	return r
+}
+
+func (r *fakePaymentRepository) Create(payment *models.Payment) error {
+	payment.ID = uuid.New()
+	r.payments = append(r.payments, payment)
+	return nil
+}
+
+func (r *fakePaymentRepository) PaidQuantities(orderID uuid.UUID) (map[uuid.UUID]int, error) {
+	paid := make(map[uuid.UUID]int)
+	for _, payment := range r.payments {
+		for _, item := range payment.Items {
+			paid[item.OrderItemID] += item.Quantity
+		}
+	}
+	return paid, nil
+}
+
+// fakeCashRepository tem no máximo um turno aberto
+type fakeCashRepository struct {
+	repositories.CashRepository
+	session *models.CashSession
+}
+
+func (r *fakeCashRepository) WithTx(tx repositories.Transaction) repositories.CashRepository {
+	return r
+}
+
+func (r *fakeCashRepository) FindOpenSessions(restaurantID uuid.UUID) ([]models.CashSession, error) {
+	if r.session == nil {
+		return nil, nil
+	}
+	return []models.CashSession{*r.session}, nil
+}
+
+func (r *fakeCashRepository) FindSessionByIDForUpdate(restaurantID, id uuid.UUID) (*models.CashSession, error) {
+	return r.session, nil
+}
+
+var (
+	burger = uuid.MustParse("00000000-0000-0000-0000-0000000000b1")
+	juice  = uuid.MustParse("00000000-0000-0000-0000-0000000000b2")
+)
+
+type paymentFixture struct {
+	service  *PaymentService
+	orders   *fakeOrderRepository
+	payments *fakePaymentRepository
+	finance  *fakeFinanceRepository
+}
+
+// newPaymentFixture monta um pedido servido de 100,00: dois hambúrgueres de 30,00 e um suco de 40,00
+func newPaymentFixture(cashOpen bool) *paymentFixture {
+	restaurantID := uuid.New()
+	orders := &fakeOrderRepository{
+		order: models.Order{
+			ID:           uuid.New(),
+			RestaurantID: restaurantID,
+			Type:         models.OrderTypeInHouse,
+			Status:       models.OrderStatusDelivered,
+			TotalAmount:  10000,
+		},
+		items: []models.OrderItem{
+			{ID: burger, Price: 3000, Quantity: 2},
+			{ID: juice, Price: 4000, Quantity: 1},
+		},
+	}
+
+	cash := &fakeCashRepository{}
+	if cashOpen {
+		cash.session = &models.CashSession{ID: uuid.New(), RestaurantID: restaurantID, Status: models.CashSessionStatusOpen}
+	}
+
+	payments := &fakePaymentRepository{}
+	finance := &fakeFinanceRepository{}
+	events := fakePublisher{}
+	orderService := NewOrderService(fakeUnitOfWork{}, orders, nil, nil, nil, nil, events)
+	cashService := NewCashService(fakeUnitOfWork{}, cash)
+
+	return &paymentFixture{
+		service:  NewPaymentService(fakeUnitOfWork{}, payments, orders, finance, cashService, orderService, events),
+		orders:   orders,
+		payments: payments,
+		finance:  finance,
+	}
+}
+
+func (f *paymentFixture) register(input PaymentInput) (*models.Payment, *models.Order, error) {
+	return f.service.Register(f.orders.order.RestaurantID, f.orders.order.ID, input)
+}
+
+func TestPaymentServiceRegister(t *testing.T) {
+	tests := []struct {
+		name       string
+		cashOpen   bool
+		input      PaymentInput
+		wantErr    error
+		wantAmount models.Money
+		wantChange models.Money
+		wantStatus models.OrderStatus
+	}{
+		{
+			name:       "partial amount keeps the order open",
+			input:      PaymentInput{Method: models.PaymentMethodCreditCard, Amount: 2500},
+			wantAmount: 2500,
+			wantStatus: models.OrderStatusDelivered,
+		},
+		{
+			name:       "no amount pays the whole balance",
+			input:      PaymentInput{Method: models.PaymentMethodPix},
+			wantAmount: 10000,
+			wantStatus: models.OrderStatusPaid,
+		},
+		{
+			name:       "items set the amount",
+			input:      PaymentInput{Method: models.PaymentMethodDebitCard, Items: []PaymentItemInput{{OrderItemID: burger, Quantity: 1}}},
+			wantAmount: 3000,
+			wantStatus: models.OrderStatusDelivered,
+		},
+		{
+			name:       "item without quantity pays the units left",
+			input:      PaymentInput{Method: models.PaymentMethodDebitCard, Items: []PaymentItemInput{{OrderItemID: burger}, {OrderItemID: juice}}},
+			wantAmount: 10000,
+			wantStatus: models.OrderStatusPaid,
+		},
+		{
+			name:       "cash gives change",
+			cashOpen:   true,
+			input:      PaymentInput{Method: models.PaymentMethodCash, Amount: 4550, Tendered: 5000},
+			wantAmount: 4550,
+			wantChange: 450,
+			wantStatus: models.OrderStatusDelivered,
+		},
+		{
+			name:    "more units than ordered",
+			input:   PaymentInput{Method: models.PaymentMethodDebitCard, Items: []PaymentItemInput{{OrderItemID: burger, Quantity: 3}}},
+			wantErr: ErrInvalidPayment,
+		},
+		{
+			name:    "item from another order",
+			input:   PaymentInput{Method: models.PaymentMethodDebitCard, Items: []PaymentItemInput{{OrderItemID: uuid.New(), Quantity: 1}}},
+			wantErr: ErrInvalidPayment,
+		},
+		{
+			name:    "amount above the balance",
+			input:   PaymentInput{Method: models.PaymentMethodCreditCard, Amount: 10001},
+			wantErr: ErrInvalidPayment,
+		},
+		{
+			name:    "change on a card payment",
+			input:   PaymentInput{Method: models.PaymentMethodCreditCard, Amount: 2000, Tendered: 2500},
+			wantErr: ErrInvalidPayment,
+		},
+		{
+			name:     "cash tendered below the amount",
+			cashOpen: true,
+			input:    PaymentInput{Method: models.PaymentMethodCash, Amount: 2000, Tendered: 1500},
+			wantErr:  ErrInvalidPayment,
+		},
+		{
+			name:    "cash without an open session",
+			input:   PaymentInput{Method: models.PaymentMethodCash, Amount: 2000},
+			wantErr: ErrCashSessionRequired,
+		},
+		{
+			name:    "unknown method",
+			input:   PaymentInput{Method: "cheque", Amount: 2000},
+			wantErr: ErrInvalidPayment,
+		},
+	}
+
+	for _, tt := range tests {
+		f := newPaymentFixture(tt.cashOpen)
+
+		payment, order, err := f.register(tt.input)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%s: error = %v, want %v", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+
+		if payment.Amount != tt.wantAmount || payment.ChangeGiven != tt.wantChange {
+			t.Errorf("%s: payment amount %s change %s, want %s and %s", tt.name, payment.Amount, payment.ChangeGiven, tt.wantAmount, tt.wantChange)
+		}
+		if order.AmountPaid != tt.wantAmount || order.Status != tt.wantStatus {
+			t.Errorf("%s: order paid %s status %s, want %s and %s", tt.name, order.AmountPaid, order.Status, tt.wantAmount, tt.wantStatus)
+		}
+
+		// Cada pagamento gera a sua receita, ligada a ele
+		if len(f.finance.created) != 1 || f.finance.created[0].Amount != tt.wantAmount {
+			t.Errorf("%s: income transactions = %v, want one of %s", tt.name, f.finance.created, tt.wantAmount)
+		} else if payment.FinancialTransactionID == nil || *payment.FinancialTransactionID != f.finance.created[0].ID {
+			t.Errorf("%s: payment is not linked to its income transaction", tt.name)
+		}
+	}
+}
+
+func TestPaymentServiceSplitBill(t *testing.T) {
+	f := newPaymentFixture(false)
+
+	// Cada cliente paga o que consumiu; o último paga o saldo sem informar o valor
+	steps := []struct {
+		input      PaymentInput
+		wantErr    error
+		wantPaid   models.Money
+		wantStatus models.OrderStatus
+	}{
+		{input: PaymentInput{Method: models.PaymentMethodPix, PayerLabel: "Ana", Items: []PaymentItemInput{{OrderItemID: burger, Quantity: 1}}}, wantPaid: 3000, wantStatus: models.OrderStatusDelivered},
+		{input: PaymentInput{Method: models.PaymentMethodPix, PayerLabel: "Bruno", Items: []PaymentItemInput{{OrderItemID: burger, Quantity: 1}}}, wantPaid: 6000, wantStatus: models.OrderStatusDelivered},
+		{input: PaymentInput{Method: models.PaymentMethodPix, PayerLabel: "Carla", Items: []PaymentItemInput{{OrderItemID: burger, Quantity: 1}}}, wantErr: ErrInvalidPayment, wantPaid: 6000, wantStatus: models.OrderStatusDelivered},
+		{input: PaymentInput{Method: models.PaymentMethodCreditCard, PayerLabel: "Carla", Amount: 1500}, wantPaid: 7500, wantStatus: models.OrderStatusDelivered},
+		{input: PaymentInput{Method: models.PaymentMethodCreditCard, PayerLabel: "Davi"}, wantPaid: 10000, wantStatus: models.OrderStatusPaid},
+		{input: PaymentInput{Method: models.PaymentMethodCreditCard, PayerLabel: "Davi", Amount: 100}, wantErr: ErrOrderClosed, wantPaid: 10000, wantStatus: models.OrderStatusPaid},
+	}
+
+	for i, step := range steps {
+		_, _, err := f.register(step.input)
+		if !errors.Is(err, step.wantErr) {
+			t.Fatalf("step %d: error = %v, want %v", i, err, step.wantErr)
+		}
+
+		order := f.orders.order
+		if order.AmountPaid != step.wantPaid || order.Status != step.wantStatus {
+			t.Fatalf("step %d: order paid %s status %s, want %s and %s", i, order.AmountPaid, order.Status, step.wantPaid, step.wantStatus)
+		}
+	}
+
+	if len(f.payments.payments) != 4 {
+		t.Errorf("payments = %d, want 4", len(f.payments.payments))
+	}
+	if len(f.orders.transitions) != 1 || f.orders.transitions[0].ToStatus != models.OrderStatusPaid {
+		t.Errorf("status transitions = %+v, want one to paid", f.orders.transitions)
+	}
+}
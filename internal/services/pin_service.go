@@ -0,0 +1,251 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/auth"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// pinMaxUserAttempts é o limite de PINs errados seguidos de um usuário, em qualquer terminal
+	pinMaxUserAttempts = 5
+	// pinMaxDeviceAttempts é o limite de PINs errados seguidos em um terminal, para qualquer usuário
+	pinMaxDeviceAttempts = 10
+	// pinLockDuration é o tempo de bloqueio ao atingir um dos limites
+	pinLockDuration = 15 * time.Minute
+)
+
+var pinPattern = regexp.MustCompile(`^[0-9]{4,6}$`)
+
+var (
+	ErrInvalidPIN = errors.New("PIN must have 4 to 6 digits")
+	// ErrPINLoginFailed não diferencia usuário inexistente de PIN errado
+	ErrPINLoginFailed = errors.New("invalid user or PIN")
+	// ErrPINLocked indica que o usuário ou o terminal atingiu o limite de tentativas erradas
+	ErrPINLocked       = errors.New("too many failed PIN attempts")
+	ErrInvalidPassword = errors.New("invalid password")
+)
+
+// PINSession é o token entregue no login por PIN; não tem refresh token
+type PINSession struct {
+	AccessToken string    `json:"token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// PINService cadastra os PINs dos usuários e faz o login por PIN nos terminais do restaurante
+type PINService struct {
+	uow               repositories.UnitOfWork
+	deviceRepo        repositories.DeviceRepository
+	userRepo          repositories.UserRepository
+	membershipService *MembershipService
+	jwtService        *auth.JWTService
+	tokenDuration     time.Duration
+}
+
+func NewPINService(uow repositories.UnitOfWork, deviceRepo repositories.DeviceRepository, userRepo repositories.UserRepository, membershipService *MembershipService, jwtService *auth.JWTService, tokenDuration time.Duration) *PINService {
+	return &PINService{
+		uow:               uow,
+		deviceRepo:        deviceRepo,
+		userRepo:          userRepo,
+		membershipService: membershipService,
+		jwtService:        jwtService,
+		tokenDuration:     tokenDuration,
+	}
+}
+
+// setPIN grava o PIN do usuário, com o hash gerado no BeforeSave; PIN vazio remove o PIN.
+// As tentativas erradas e o bloqueio são zerados.
+func (s *PINService) setPIN(user *models.User, pin string) error {
+	if pin != "" && !pinPattern.MatchString(pin) {
+		return ErrInvalidPIN
+	}
+
+	user.PIN = pin
+	user.PINFailedAttempts = 0
+	user.PINLockedUntil = nil
+	return s.userRepo.Update(user)
+}
+
+// SetPIN cadastra ou troca o PIN do próprio usuário, que confirma a senha
+func (s *PINService) SetPIN(userID uuid.UUID, password, pin string) error {
+	user, err := s.userRepo.FindByIDGlobal(userID)
+	if err != nil {
+		return err
+	}
+	if err := user.CheckPassword(password); err != nil {
+		return ErrInvalidPassword
+	}
+	if pin == "" {
+		return ErrInvalidPIN
+	}
+	return s.setPIN(user, pin)
+}
+
+// ClearPIN remove o PIN do próprio usuário, que deixa de entrar nos terminais
+func (s *PINService) ClearPIN(userID uuid.UUID) error {
+	user, err := s.userRepo.FindByIDGlobal(userID)
+	if err != nil {
+		return err
+	}
+	return s.setPIN(user, "")
+}
+
+// ResetMemberPIN define ou, com PIN vazio, remove o PIN de um usuário do restaurante que o autor gerencia
+func (s *PINService) ResetMemberPIN(restaurantID, userID uuid.UUID, actor Actor, pin string) error {
+	if _, err := s.membershipService.managed(restaurantID, userID, actor); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByIDGlobal(userID)
+	if err != nil {
+		return err
+	}
+	return s.setPIN(user, pin)
+}
+
+// Members retorna os usuários do restaurante do terminal que podem entrar nele com PIN
+func (s *PINService) Members(device *models.Device) ([]models.Membership, error) {
+	memberships, err := s.membershipService.ListMembers(device.RestaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]models.Membership, 0, len(memberships))
+	for _, membership := range memberships {
+		if membership.User != nil && membership.User.HasPIN() {
+			members = append(members, membership)
+		}
+	}
+	return members, nil
+}
+
+// member busca o usuário, bloqueado até o fim da transação para que as tentativas erradas em terminais
+// diferentes sejam contadas em sequência, e o seu vínculo com o restaurante. Retorna nil se ele não
+// pertencer ao restaurante.
+func (s *PINService) member(userRepo repositories.UserRepository, restaurantID, userID uuid.UUID) (*models.User, *models.Membership, error) {
+	membership, err := s.membershipService.Find(restaurantID, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrMembershipNotFound) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	user, err := userRepo.FindByIDForUpdate(userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	return user, membership, nil
+}
+
+// lockedError informa quanto tempo falta para o bloqueio terminar
+func lockedError(until, now time.Time) error {
+	minutes := int(math.Ceil(until.Sub(now).Minutes()))
+	return fmt.Errorf("%w: try again in %d minutes", ErrPINLocked, minutes)
+}
+
+// Login desbloqueia o terminal para o usuário com o PIN informado. O token emitido vale para o
+// restaurante do terminal, com o tipo que o usuário tem ali, e substitui o do funcionário que estava
+// conectado. PINs errados contam para o usuário e para o terminal; ao atingir um dos limites o login
+// por PIN fica bloqueado por pinLockDuration.
+func (s *PINService) Login(current *models.Device, userID uuid.UUID, pin string) (*PINSession, *models.User, *models.Device, error) {
+	var (
+		session *PINSession
+		scoped  *models.User
+		device  *models.Device
+		failure error
+	)
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		deviceRepo := s.deviceRepo.WithTx(tx)
+		userRepo := s.userRepo.WithTx(tx)
+
+		var err error
+		device, err = deviceRepo.FindByIDForUpdate(current.RestaurantID, current.ID)
+		if err != nil {
+			return err
+		}
+		if device.Status != models.DeviceStatusActive || !device.Can(models.DeviceScopePINLogin) {
+			return ErrDeviceScope
+		}
+
+		now := time.Now()
+		if device.Locked(now) {
+			return lockedError(*device.LockedUntil, now)
+		}
+
+		user, membership, err := s.member(userRepo, device.RestaurantID, userID)
+		if err != nil {
+			return err
+		}
+		if user != nil && user.PINLocked(now) {
+			return lockedError(*user.PINLockedUntil, now)
+		}
+
+		if user == nil || user.CheckPIN(pin) != nil {
+			// As tentativas precisam ser gravadas, então o erro só é devolvido após o commit
+			failure = ErrPINLoginFailed
+			if user != nil {
+				user.PINFailedAttempts++
+				if user.PINFailedAttempts >= pinMaxUserAttempts {
+					lockedUntil := now.Add(pinLockDuration)
+					user.PINFailedAttempts, user.PINLockedUntil = 0, &lockedUntil
+					failure = lockedError(lockedUntil, now)
+				}
+				if err := userRepo.UpdatePINAttempts(user.ID, user.PINFailedAttempts, user.PINLockedUntil); err != nil {
+					return err
+				}
+			}
+
+			device.FailedAttempts++
+			if device.FailedAttempts >= pinMaxDeviceAttempts {
+				lockedUntil := now.Add(pinLockDuration)
+				device.FailedAttempts, device.LockedUntil = 0, &lockedUntil
+				failure = lockedError(lockedUntil, now)
+			}
+			return deviceRepo.Update(device)
+		}
+
+		if user.PINFailedAttempts > 0 || user.PINLockedUntil != nil {
+			if err := userRepo.UpdatePINAttempts(user.ID, 0, nil); err != nil {
+				return err
+			}
+		}
+
+		scoped = user
+		scoped.Memberships = nil
+		scoped.RestaurantID = &device.RestaurantID
+		scoped.Type = membership.Type
+
+		accessToken, claims, err := s.jwtService.GenerateDeviceToken(scoped, device.ID, s.tokenDuration)
+		if err != nil {
+			return fmt.Errorf("failed to generate token: %w", err)
+		}
+		session = &PINSession{AccessToken: accessToken, ExpiresAt: claims.ExpiresAt.Time}
+
+		jti := uuid.MustParse(claims.ID)
+		device.ActiveUserID = &user.ID
+		device.SessionJTI = &jti
+		device.LastActivityAt = &now
+		device.FailedAttempts = 0
+		device.LockedUntil = nil
+		return deviceRepo.Update(device)
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if failure != nil {
+		return nil, nil, nil, failure
+	}
+	return session, scoped, device, nil
+}
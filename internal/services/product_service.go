@@ -1,20 +1,36 @@
 package services
 
 import (
+	"errors"
+
 	"api-jet-manager/internal/domain/models"
 	"api-jet-manager/internal/domain/repositories"
 
 	"github.com/google/uuid"
 )
 
+// ErrStockFromRecipe indica que a disponibilidade do produto é calculada pela ficha técnica
+var ErrStockFromRecipe = errors.New("product availability is computed from its recipe")
+
 type ProductService struct {
-	productRepo repositories.ProductRepository
+	productRepo   repositories.ProductRepository
+	inventoryRepo repositories.InventoryRepository
 }
 
-func NewProductService(productRepo repositories.ProductRepository) *ProductService {
+func NewProductService(productRepo repositories.ProductRepository, inventoryRepo repositories.InventoryRepository) *ProductService {
 	return &ProductService{
-		productRepo: productRepo,
+		productRepo:   productRepo,
+		inventoryRepo: inventoryRepo,
+	}
+}
+
+// hasRecipe informa se o produto tem ficha técnica, caso em que a disponibilidade segue os insumos
+func (s *ProductService) hasRecipe(restaurantID, id uuid.UUID) (bool, error) {
+	recipe, err := s.inventoryRepo.FindProductRecipe(restaurantID, id)
+	if err != nil {
+		return false, err
 	}
+	return len(recipe) > 0, nil
 }
 
 func (s *ProductService) Create(product *models.Product) error {
@@ -25,7 +41,20 @@ func (s *ProductService) GetByID(restaurant_id uuid.UUID, id uuid.UUID) (*models
 	return s.productRepo.FindByID(restaurant_id, id)
 }
 
+// Update altera o produto. Em produtos com ficha técnica a disponibilidade gravada é mantida,
+// pois é recalculada pelo InventoryService a cada movimentação dos insumos.
 func (s *ProductService) Update(product *models.Product) error {
+	withRecipe, err := s.hasRecipe(product.RestaurantID, product.ID)
+	if err != nil {
+		return err
+	}
+	if withRecipe {
+		current, err := s.productRepo.FindByID(product.RestaurantID, product.ID)
+		if err != nil {
+			return err
+		}
+		product.InStock = current.InStock
+	}
 	return s.productRepo.Update(product)
 }
 
@@ -41,7 +70,16 @@ func (s *ProductService) GetByCategory(restaurantID uuid.UUID, category models.P
 	return s.productRepo.FindByCategory(restaurantID, category)
 }
 
+// UpdateStock altera a disponibilidade manualmente. Produtos com ficha técnica retornam
+// ErrStockFromRecipe, pois o valor é recalculado a cada movimentação dos insumos.
 func (s *ProductService) UpdateStock(restaurant_id uuid.UUID, id uuid.UUID, inStock bool) error {
+	withRecipe, err := s.hasRecipe(restaurant_id, id)
+	if err != nil {
+		return err
+	}
+	if withRecipe {
+		return ErrStockFromRecipe
+	}
 	return s.productRepo.UpdateStock(restaurant_id, id, inStock)
 }
 
@@ -0,0 +1,409 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidSupplier      = errors.New("invalid supplier")
+	ErrSupplierInUse        = errors.New("supplier has purchase orders")
+	ErrInvalidPurchaseOrder = errors.New("invalid purchase order")
+	// ErrPurchaseOrderStatus indica uma operação não permitida no status atual do pedido de compra
+	ErrPurchaseOrderStatus = errors.New("operation not allowed for the purchase order status")
+)
+
+type PurchaseOrderItemInput struct {
+	IngredientID uuid.UUID
+	Quantity     models.Quantity
+	UnitCost     models.Money
+}
+
+type PurchaseOrderInput struct {
+	SupplierID uuid.UUID
+	Notes      string
+	ExpectedAt *time.Time
+	Items      []PurchaseOrderItemInput
+}
+
+// ReceiptLine é a quantidade recebida de um item do pedido de compra
+type ReceiptLine struct {
+	ItemID   uuid.UUID
+	Quantity models.Quantity
+}
+
+// ReceiptInput descreve um recebimento de mercadorias. Sem linhas, tudo o que falta é recebido.
+type ReceiptInput struct {
+	Items         []ReceiptLine
+	PaymentMethod string
+}
+
+// PurchaseService gerencia fornecedores e pedidos de compra. O recebimento das mercadorias
+// dá entrada no estoque dos insumos e lança a despesa correspondente no financeiro.
+type PurchaseService struct {
+	uow              repositories.UnitOfWork
+	purchaseRepo     repositories.PurchaseRepository
+	inventoryRepo    repositories.InventoryRepository
+	inventoryService *InventoryService
+	financeService   *FinanceService
+}
+
+func NewPurchaseService(uow repositories.UnitOfWork, purchaseRepo repositories.PurchaseRepository, inventoryRepo repositories.InventoryRepository, inventoryService *InventoryService, financeService *FinanceService) *PurchaseService {
+	return &PurchaseService{
+		uow:              uow,
+		purchaseRepo:     purchaseRepo,
+		inventoryRepo:    inventoryRepo,
+		inventoryService: inventoryService,
+		financeService:   financeService,
+	}
+}
+
+func validateSupplier(supplier *models.Supplier) error {
+	supplier.Name = strings.TrimSpace(supplier.Name)
+	switch {
+	case supplier.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidSupplier)
+	case len(supplier.Name) > 100:
+		return fmt.Errorf("%w: name must have at most 100 characters", ErrInvalidSupplier)
+	case len(supplier.Document) > 20, len(supplier.Phone) > 20:
+		return fmt.Errorf("%w: document and phone must have at most 20 characters", ErrInvalidSupplier)
+	case len(supplier.ContactName) > 100, len(supplier.Email) > 100:
+		return fmt.Errorf("%w: contact name and email must have at most 100 characters", ErrInvalidSupplier)
+	case len(supplier.Notes) > 255:
+		return fmt.Errorf("%w: notes must have at most 255 characters", ErrInvalidSupplier)
+	}
+	return nil
+}
+
+func (s *PurchaseService) CreateSupplier(supplier *models.Supplier) error {
+	if err := validateSupplier(supplier); err != nil {
+		return err
+	}
+	return s.purchaseRepo.CreateSupplier(supplier)
+}
+
+func (s *PurchaseService) GetSupplier(restaurantID, id uuid.UUID) (*models.Supplier, error) {
+	return s.purchaseRepo.FindSupplierByID(restaurantID, id)
+}
+
+func (s *PurchaseService) ListSuppliers(restaurantID uuid.UUID, activeOnly bool) ([]models.Supplier, error) {
+	return s.purchaseRepo.ListSuppliers(restaurantID, activeOnly)
+}
+
+func (s *PurchaseService) UpdateSupplier(supplier *models.Supplier) error {
+	if err := validateSupplier(supplier); err != nil {
+		return err
+	}
+	return s.purchaseRepo.UpdateSupplier(supplier)
+}
+
+// DeleteSupplier remove o fornecedor; fornecedores com pedidos de compra devem ser desativados
+func (s *PurchaseService) DeleteSupplier(restaurantID, id uuid.UUID) error {
+	if _, err := s.purchaseRepo.FindSupplierByID(restaurantID, id); err != nil {
+		return err
+	}
+
+	inUse, err := s.purchaseRepo.SupplierInUse(restaurantID, id)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return ErrSupplierInUse
+	}
+	return s.purchaseRepo.DeleteSupplier(restaurantID, id)
+}
+
+// buildItems valida os itens informados e monta as linhas do pedido com o total
+func (s *PurchaseService) buildItems(tx repositories.Transaction, restaurantID uuid.UUID, inputs []PurchaseOrderItemInput) ([]models.PurchaseOrderItem, models.Money, error) {
+	inventoryRepo := s.inventoryRepo.WithTx(tx)
+
+	items := make([]models.PurchaseOrderItem, 0, len(inputs))
+	seen := make(map[uuid.UUID]bool, len(inputs))
+	var total models.Money
+	for _, input := range inputs {
+		switch {
+		case input.Quantity <= 0:
+			return nil, 0, fmt.Errorf("%w: quantity must be greater than zero", ErrInvalidPurchaseOrder)
+		case input.UnitCost.IsNegative():
+			return nil, 0, fmt.Errorf("%w: unit cost cannot be negative", ErrInvalidPurchaseOrder)
+		case seen[input.IngredientID]:
+			return nil, 0, fmt.Errorf("%w: ingredient %s appears more than once", ErrInvalidPurchaseOrder, input.IngredientID)
+		}
+		seen[input.IngredientID] = true
+
+		if _, err := inventoryRepo.FindIngredientByID(restaurantID, input.IngredientID); err != nil {
+			return nil, 0, err
+		}
+
+		items = append(items, models.PurchaseOrderItem{
+			IngredientID: input.IngredientID,
+			Quantity:     input.Quantity,
+			UnitCost:     input.UnitCost,
+		})
+		total = total.Add(input.UnitCost.MulQuantity(input.Quantity))
+	}
+	return items, total, nil
+}
+
+// applyInput valida o fornecedor e os itens e copia os dados para o pedido
+func (s *PurchaseService) applyInput(tx repositories.Transaction, order *models.PurchaseOrder, in PurchaseOrderInput) error {
+	if len(in.Notes) > 255 {
+		return fmt.Errorf("%w: notes must have at most 255 characters", ErrInvalidPurchaseOrder)
+	}
+
+	supplier, err := s.purchaseRepo.WithTx(tx).FindSupplierByID(order.RestaurantID, in.SupplierID)
+	if err != nil {
+		return err
+	}
+	if !supplier.Active {
+		return fmt.Errorf("%w: supplier is inactive", ErrInvalidPurchaseOrder)
+	}
+
+	items, total, err := s.buildItems(tx, order.RestaurantID, in.Items)
+	if err != nil {
+		return err
+	}
+
+	order.SupplierID = supplier.ID
+	order.Notes = in.Notes
+	order.ExpectedAt = in.ExpectedAt
+	order.Items = items
+	order.Total = total
+	return nil
+}
+
+// CreatePurchaseOrder cria o pedido de compra em rascunho
+func (s *PurchaseService) CreatePurchaseOrder(restaurantID, userID uuid.UUID, in PurchaseOrderInput) (*models.PurchaseOrder, error) {
+	order := &models.PurchaseOrder{
+		RestaurantID: restaurantID,
+		Status:       models.PurchaseOrderStatusDraft,
+		UserID:       userID,
+	}
+
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		if err := s.applyInput(tx, order, in); err != nil {
+			return err
+		}
+		return s.purchaseRepo.WithTx(tx).CreatePurchaseOrder(order)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.purchaseRepo.FindPurchaseOrderByID(restaurantID, order.ID)
+}
+
+// UpdatePurchaseOrder substitui fornecedor, observações e itens de um pedido ainda em rascunho
+func (s *PurchaseService) UpdatePurchaseOrder(restaurantID, id uuid.UUID, in PurchaseOrderInput) (*models.PurchaseOrder, error) {
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		purchaseRepo := s.purchaseRepo.WithTx(tx)
+
+		order, err := purchaseRepo.FindPurchaseOrderByIDForUpdate(restaurantID, id)
+		if err != nil {
+			return err
+		}
+		if !order.Editable() {
+			return fmt.Errorf("%w: only draft purchase orders can be changed", ErrPurchaseOrderStatus)
+		}
+
+		if err := s.applyInput(tx, order, in); err != nil {
+			return err
+		}
+		if err := purchaseRepo.ReplacePurchaseOrderItems(order); err != nil {
+			return err
+		}
+		return purchaseRepo.UpdatePurchaseOrder(order)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.purchaseRepo.FindPurchaseOrderByID(restaurantID, id)
+}
+
+// PlaceOrder marca o rascunho como enviado ao fornecedor; a partir daí os itens não mudam
+func (s *PurchaseService) PlaceOrder(restaurantID, id uuid.UUID) (*models.PurchaseOrder, error) {
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		purchaseRepo := s.purchaseRepo.WithTx(tx)
+
+		order, err := purchaseRepo.FindPurchaseOrderByIDForUpdate(restaurantID, id)
+		if err != nil {
+			return err
+		}
+		if order.Status != models.PurchaseOrderStatusDraft {
+			return fmt.Errorf("%w: only draft purchase orders can be placed", ErrPurchaseOrderStatus)
+		}
+		if len(order.Items) == 0 {
+			return fmt.Errorf("%w: purchase order has no items", ErrInvalidPurchaseOrder)
+		}
+
+		now := time.Now()
+		order.Status = models.PurchaseOrderStatusOrdered
+		order.OrderedAt = &now
+		return purchaseRepo.UpdatePurchaseOrder(order)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.purchaseRepo.FindPurchaseOrderByID(restaurantID, id)
+}
+
+// Cancel encerra o pedido. Em pedidos parcialmente recebidos o que já entrou no estoque
+// e no financeiro é mantido; apenas o restante deixa de ser aguardado.
+func (s *PurchaseService) Cancel(restaurantID, id uuid.UUID) (*models.PurchaseOrder, error) {
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		purchaseRepo := s.purchaseRepo.WithTx(tx)
+
+		order, err := purchaseRepo.FindPurchaseOrderByIDForUpdate(restaurantID, id)
+		if err != nil {
+			return err
+		}
+		if order.Status == models.PurchaseOrderStatusReceived || order.Status == models.PurchaseOrderStatusCancelled {
+			return fmt.Errorf("%w: purchase order is already %s", ErrPurchaseOrderStatus, order.Status)
+		}
+
+		now := time.Now()
+		order.Status = models.PurchaseOrderStatusCancelled
+		order.CancelledAt = &now
+		return purchaseRepo.UpdatePurchaseOrder(order)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.purchaseRepo.FindPurchaseOrderByID(restaurantID, id)
+}
+
+// Receive registra a chegada de mercadorias: soma as quantidades ao estoque dos insumos e lança
+// uma despesa de insumos com o valor recebido, ligada ao pedido de compra, tudo na mesma transação
+func (s *PurchaseService) Receive(restaurantID, id, actorID uuid.UUID, in ReceiptInput) (*models.PurchaseOrder, error) {
+	if len(in.PaymentMethod) > 30 {
+		return nil, fmt.Errorf("%w: payment method must have at most 30 characters", ErrInvalidPurchaseOrder)
+	}
+
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		purchaseRepo := s.purchaseRepo.WithTx(tx)
+
+		order, err := purchaseRepo.FindPurchaseOrderByIDForUpdate(restaurantID, id)
+		if err != nil {
+			return err
+		}
+		if !order.Receivable() {
+			return fmt.Errorf("%w: purchase order is %s", ErrPurchaseOrderStatus, order.Status)
+		}
+
+		received, err := receiptQuantities(order, in.Items)
+		if err != nil {
+			return err
+		}
+
+		deltas := make(map[uuid.UUID]models.Quantity, len(received))
+		var amount models.Money
+		pending := false
+		for i := range order.Items {
+			item := &order.Items[i]
+			if quantity := received[item.ID]; quantity > 0 {
+				item.QuantityReceived = item.QuantityReceived.Add(quantity)
+				if err := purchaseRepo.UpdatePurchaseOrderItem(item); err != nil {
+					return err
+				}
+				deltas[item.IngredientID] = deltas[item.IngredientID].Add(quantity)
+				amount = amount.Add(item.UnitCost.MulQuantity(quantity))
+			}
+			if item.Remaining() > 0 {
+				pending = true
+			}
+		}
+
+		supplierName := ""
+		if order.Supplier != nil {
+			supplierName = order.Supplier.Name
+		}
+
+		if err := s.inventoryService.applyStock(tx, restaurantID, deltas, models.StockMovement{
+			Type:            models.StockMovementPurchase,
+			PurchaseOrderID: &order.ID,
+			UserID:          &actorID,
+			Notes:           fmt.Sprintf("Received from %s", supplierName),
+		}); err != nil {
+			return err
+		}
+
+		if amount > 0 {
+			if err := s.financeService.record(tx, &models.FinancialTransaction{
+				RestaurantID:    restaurantID,
+				Type:            models.TransactionTypeExpense,
+				Category:        models.TransactionCategoryIngredients,
+				Amount:          amount,
+				Description:     fmt.Sprintf("Purchase from %s", supplierName),
+				PurchaseOrderID: &order.ID,
+				UserID:          actorID,
+				PaymentMethod:   in.PaymentMethod,
+				Date:            time.Now(),
+			}); err != nil {
+				return fmt.Errorf("failed to register purchase expense: %w", err)
+			}
+		}
+
+		now := time.Now()
+		order.ReceivedTotal = order.ReceivedTotal.Add(amount)
+		if pending {
+			order.Status = models.PurchaseOrderStatusPartiallyReceived
+		} else {
+			order.Status = models.PurchaseOrderStatusReceived
+			order.ReceivedAt = &now
+		}
+		return purchaseRepo.UpdatePurchaseOrder(order)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.purchaseRepo.FindPurchaseOrderByID(restaurantID, id)
+}
+
+// receiptQuantities confere as linhas do recebimento contra os itens do pedido.
+// Sem linhas, considera recebido tudo o que ainda falta.
+func receiptQuantities(order *models.PurchaseOrder, lines []ReceiptLine) (map[uuid.UUID]models.Quantity, error) {
+	received := make(map[uuid.UUID]models.Quantity, len(order.Items))
+
+	if len(lines) == 0 {
+		for _, item := range order.Items {
+			if remaining := item.Remaining(); remaining > 0 {
+				received[item.ID] = remaining
+			}
+		}
+		return received, nil
+	}
+
+	items := make(map[uuid.UUID]*models.PurchaseOrderItem, len(order.Items))
+	for i := range order.Items {
+		items[order.Items[i].ID] = &order.Items[i]
+	}
+
+	for _, line := range lines {
+		item, ok := items[line.ItemID]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("%w: item %s does not belong to the purchase order", ErrInvalidPurchaseOrder, line.ItemID)
+		case line.Quantity <= 0:
+			return nil, fmt.Errorf("%w: received quantity must be greater than zero", ErrInvalidPurchaseOrder)
+		case received[line.ItemID].Add(line.Quantity) > item.Remaining():
+			return nil, fmt.Errorf("%w: received quantity exceeds the %s still expected for item %s",
+				ErrInvalidPurchaseOrder, item.Remaining(), line.ItemID)
+		}
+		received[line.ItemID] = received[line.ItemID].Add(line.Quantity)
+	}
+	return received, nil
+}
+
+func (s *PurchaseService) GetPurchaseOrder(restaurantID, id uuid.UUID) (*models.PurchaseOrder, error) {
+	return s.purchaseRepo.FindPurchaseOrderByID(restaurantID, id)
+}
+
+func (s *PurchaseService) ListPurchaseOrders(restaurantID uuid.UUID, filter repositories.PurchaseOrderFilter) ([]models.PurchaseOrder, error) {
+	return s.purchaseRepo.ListPurchaseOrders(restaurantID, filter)
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"api-jet-manager/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+func TestReceiptQuantities(t *testing.T) {
+	flour := uuid.MustParse("00000000-0000-0000-0000-0000000000f1")
+	sugar := uuid.MustParse("00000000-0000-0000-0000-0000000000f2")
+	milk := uuid.MustParse("00000000-0000-0000-0000-0000000000f3")
+
+	// Farinha: faltam 6, açúcar: já recebido, leite: faltam 5
+	order := &models.PurchaseOrder{Items: []models.PurchaseOrderItem{
+		{ID: flour, Quantity: 10000, QuantityReceived: 4000},
+		{ID: sugar, Quantity: 2000, QuantityReceived: 2000},
+		{ID: milk, Quantity: 5000},
+	}}
+
+	tests := []struct {
+		name    string
+		lines   []ReceiptLine
+		want    map[uuid.UUID]models.Quantity
+		wantErr bool
+	}{
+		{
+			name: "no lines receives everything still expected",
+			want: map[uuid.UUID]models.Quantity{flour: 6000, milk: 5000},
+		},
+		{
+			name:  "partial receipt",
+			lines: []ReceiptLine{{ItemID: flour, Quantity: 1500}},
+			want:  map[uuid.UUID]models.Quantity{flour: 1500},
+		},
+		{
+			name:  "exactly the remaining quantity",
+			lines: []ReceiptLine{{ItemID: flour, Quantity: 6000}, {ItemID: milk, Quantity: 250}},
+			want:  map[uuid.UUID]models.Quantity{flour: 6000, milk: 250},
+		},
+		{
+			name:  "repeated lines are added up",
+			lines: []ReceiptLine{{ItemID: flour, Quantity: 3000}, {ItemID: flour, Quantity: 3000}},
+			want:  map[uuid.UUID]models.Quantity{flour: 6000},
+		},
+		{
+			name:    "more than the remaining quantity",
+			lines:   []ReceiptLine{{ItemID: flour, Quantity: 6001}},
+			wantErr: true,
+		},
+		{
+			name:    "repeated lines above the remaining quantity",
+			lines:   []ReceiptLine{{ItemID: flour, Quantity: 4000}, {ItemID: flour, Quantity: 3000}},
+			wantErr: true,
+		},
+		{
+			name:    "item already received",
+			lines:   []ReceiptLine{{ItemID: sugar, Quantity: 1}},
+			wantErr: true,
+		},
+		{
+			name:    "zero quantity",
+			lines:   []ReceiptLine{{ItemID: milk, Quantity: 0}},
+			wantErr: true,
+		},
+		{
+			name:    "negative quantity",
+			lines:   []ReceiptLine{{ItemID: milk, Quantity: -1000}},
+			wantErr: true,
+		},
+		{
+			name:    "item from another purchase order",
+			lines:   []ReceiptLine{{ItemID: uuid.New(), Quantity: 1000}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := receiptQuantities(order, tt.lines)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidPurchaseOrder) {
+				t.Errorf("%s: error = %v, want ErrInvalidPurchaseOrder", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %d item(s), want %d: %v", tt.name, len(got), len(tt.want), got)
+		}
+		for id, quantity := range tt.want {
+			if got[id] != quantity {
+				t.Errorf("%s: item %s = %s, want %s", tt.name, id, got[id], quantity)
+			}
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// recurringSchedulerInterval é o intervalo entre as rodadas que lançam as ocorrências vencidas
+	recurringSchedulerInterval = time.Hour
+	// maxUpcomingDays limita o horizonte da prévia de lançamentos
+	maxUpcomingDays = 366
+)
+
+var ErrInvalidRecurringTransaction = errors.New("invalid recurring transaction")
+
+// RecurringTransactionInput descreve o modelo de um lançamento recorrente.
+// DayOfMonth e Weekday assumem o dia de StartDate quando omitidos; Active omitido mantém o valor atual.
+type RecurringTransactionInput struct {
+	Type          models.TransactionType
+	Category      models.TransactionCategory
+	Amount        models.Money
+	Description   string
+	PaymentMethod string
+	Frequency     models.RecurrenceFrequency
+	Interval      int
+	DayOfMonth    *int
+	Weekday       *int
+	StartDate     time.Time
+	EndDate       *time.Time
+	Active        *bool
+}
+
+// UpcomingTransaction é uma ocorrência prevista e ainda não lançada
+type UpcomingTransaction struct {
+	RecurringID   uuid.UUID                  `json:"recurring_id"`
+	Date          string                     `json:"date"`
+	Type          models.TransactionType     `json:"type"`
+	Category      models.TransactionCategory `json:"category"`
+	Amount        models.Money               `json:"amount"`
+	Description   string                     `json:"description"`
+	PaymentMethod string                     `json:"payment_method"`
+}
+
+type RecurringTransactionService struct {
+	uow           repositories.UnitOfWork
+	recurringRepo repositories.RecurringTransactionRepository
+}
+
+func NewRecurringTransactionService(uow repositories.UnitOfWork, recurringRepo repositories.RecurringTransactionRepository) *RecurringTransactionService {
+	return &RecurringTransactionService{
+		uow:           uow,
+		recurringRepo: recurringRepo,
+	}
+}
+
+// apply copia a entrada para o modelo, validando-a
+func (s *RecurringTransactionService) apply(recurring *models.RecurringTransaction, input RecurringTransactionInput) error {
+	if !input.Type.Valid() {
+		return fmt.Errorf("%w: invalid transaction type", ErrInvalidRecurringTransaction)
+	}
+	if !input.Category.Valid() {
+		return fmt.Errorf("%w: invalid transaction category", ErrInvalidRecurringTransaction)
+	}
+	if input.Amount <= 0 {
+		return fmt.Errorf("%w: amount must be greater than zero", ErrInvalidRecurringTransaction)
+	}
+	if !input.Frequency.Valid() {
+		return fmt.Errorf("%w: invalid frequency", ErrInvalidRecurringTransaction)
+	}
+	if input.StartDate.IsZero() {
+		return fmt.Errorf("%w: start date is required", ErrInvalidRecurringTransaction)
+	}
+	if input.Interval == 0 {
+		input.Interval = 1
+	}
+	if input.Interval < 1 || input.Interval > 365 {
+		return fmt.Errorf("%w: interval must be between 1 and 365", ErrInvalidRecurringTransaction)
+	}
+
+	start := models.Day(input.StartDate)
+	dayOfMonth, weekday := start.Day(), int(start.Weekday())
+	if input.DayOfMonth != nil {
+		dayOfMonth = *input.DayOfMonth
+	}
+	if input.Weekday != nil {
+		weekday = *input.Weekday
+	}
+	if dayOfMonth < 1 || dayOfMonth > 31 {
+		return fmt.Errorf("%w: day of month must be between 1 and 31", ErrInvalidRecurringTransaction)
+	}
+	if weekday < 0 || weekday > 6 {
+		return fmt.Errorf("%w: weekday must be between 0 and 6", ErrInvalidRecurringTransaction)
+	}
+
+	var end *time.Time
+	if input.EndDate != nil {
+		day := models.Day(*input.EndDate)
+		if day.Before(start) {
+			return fmt.Errorf("%w: end date cannot be before start date", ErrInvalidRecurringTransaction)
+		}
+		end = &day
+	}
+
+	recurring.Type = input.Type
+	recurring.Category = input.Category
+	recurring.Amount = input.Amount
+	recurring.Description = input.Description
+	recurring.PaymentMethod = input.PaymentMethod
+	recurring.Frequency = input.Frequency
+	recurring.Interval = input.Interval
+	recurring.DayOfMonth = dayOfMonth
+	recurring.Weekday = weekday
+	recurring.StartDate = start
+	recurring.EndDate = end
+	reactivated := false
+	if input.Active != nil {
+		reactivated = !recurring.Active && *input.Active
+		recurring.Active = *input.Active
+	}
+
+	// Recalcula a próxima ocorrência sem repetir as que já foram lançadas
+	from := start
+	if recurring.LastOccurrence != nil && !recurring.LastOccurrence.Before(start) {
+		from = recurring.LastOccurrence.AddDate(0, 0, 1)
+	}
+	// Um modelo reativado não lança as ocorrências do período em que ficou pausado
+	if today := models.Day(time.Now()); reactivated && from.Before(today) {
+		from = today
+	}
+	recurring.NextDate = recurring.NextOccurrence(from)
+	return nil
+}
+
+// Create cadastra o lançamento recorrente. Ocorrências já vencidas são lançadas na próxima rodada do agendador.
+func (s *RecurringTransactionService) Create(restaurantID, actorID uuid.UUID, input RecurringTransactionInput) (*models.RecurringTransaction, error) {
+	recurring := &models.RecurringTransaction{
+		RestaurantID: restaurantID,
+		UserID:       actorID,
+		Active:       true,
+	}
+	if err := s.apply(recurring, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.recurringRepo.Create(recurring); err != nil {
+		return nil, err
+	}
+	return recurring, nil
+}
+
+func (s *RecurringTransactionService) Get(restaurantID, id uuid.UUID) (*models.RecurringTransaction, error) {
+	return s.recurringRepo.FindByID(restaurantID, id)
+}
+
+func (s *RecurringTransactionService) List(restaurantID uuid.UUID) ([]models.RecurringTransaction, error) {
+	return s.recurringRepo.List(restaurantID)
+}
+
+// Update altera o modelo; as transações já lançadas não são modificadas
+func (s *RecurringTransactionService) Update(restaurantID, id uuid.UUID, input RecurringTransactionInput) (*models.RecurringTransaction, error) {
+	var recurring *models.RecurringTransaction
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		recurringRepo := s.recurringRepo.WithTx(tx)
+
+		var err error
+		recurring, err = recurringRepo.FindByIDForUpdate(restaurantID, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.apply(recurring, input); err != nil {
+			return err
+		}
+		return recurringRepo.Update(recurring)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recurring, nil
+}
+
+// Delete remove o modelo; as transações já lançadas são mantidas
+func (s *RecurringTransactionService) Delete(restaurantID, id uuid.UUID) error {
+	if _, err := s.recurringRepo.FindByID(restaurantID, id); err != nil {
+		return err
+	}
+	return s.recurringRepo.Delete(restaurantID, id)
+}
+
+// Upcoming prevê as ocorrências dos lançamentos ativos até o dia until, inclusive,
+// ordenadas por data. Inclui as vencidas que o agendador ainda não lançou.
+func (s *RecurringTransactionService) Upcoming(restaurantID uuid.UUID, until time.Time) ([]UpcomingTransaction, error) {
+	until = models.Day(until)
+	if until.After(models.Day(time.Now()).AddDate(0, 0, maxUpcomingDays)) {
+		return nil, fmt.Errorf("%w: preview cannot exceed %d days", ErrInvalidRecurringTransaction, maxUpcomingDays)
+	}
+
+	templates, err := s.recurringRepo.List(restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	upcoming := []UpcomingTransaction{}
+	for i := range templates {
+		recurring := &templates[i]
+		if !recurring.Active {
+			continue
+		}
+		for next := recurring.NextDate; next != nil && !next.After(until); next = recurring.NextOccurrence(next.AddDate(0, 0, 1)) {
+			upcoming = append(upcoming, UpcomingTransaction{
+				RecurringID:   recurring.ID,
+				Date:          next.Format("2006-01-02"),
+				Type:          recurring.Type,
+				Category:      recurring.Category,
+				Amount:        recurring.Amount,
+				Description:   recurring.Description,
+				PaymentMethod: recurring.PaymentMethod,
+			})
+		}
+	}
+
+	// As datas no formato ISO ordenam corretamente como texto
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		return upcoming[i].Date < upcoming[j].Date
+	})
+	return upcoming, nil
+}
+
+// RunScheduler lança as ocorrências vencidas ao iniciar e a cada rodada, até ctx ser cancelado.
+// Pode rodar em várias instâncias: cada modelo fica bloqueado enquanto é lançado
+// e cada ocorrência só pode ser lançada uma vez.
+func (s *RecurringTransactionService) RunScheduler(ctx context.Context) {
+	if err := s.runSchedulerOnce(time.Now()); err != nil {
+		log.Printf("Falha ao lançar transações recorrentes: %v", err)
+	}
+
+	ticker := time.NewTicker(recurringSchedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.runSchedulerOnce(now); err != nil {
+				log.Printf("Falha ao lançar transações recorrentes: %v", err)
+			}
+		}
+	}
+}
+
+// runSchedulerOnce lança todas as ocorrências até o dia de now, inclusive as atrasadas. Cada modelo é
+// lançado na sua própria transação: a falha de um é registrada e não impede os demais.
+func (s *RecurringTransactionService) runSchedulerOnce(now time.Time) error {
+	today := models.Day(now)
+
+	due, err := s.recurringRepo.FindDue(today)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for i := range due {
+		if err := s.post(due[i].RestaurantID, due[i].ID, today); err != nil {
+			log.Printf("Falha ao lançar a transação recorrente %s: %v", due[i].ID, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d recurring transactions failed", failed, len(due))
+	}
+	return nil
+}
+
+// post lança as ocorrências vencidas do modelo. O modelo fica bloqueado durante o lançamento,
+// e um modelo já lançado por outra instância não gera ocorrências novas.
+func (s *RecurringTransactionService) post(restaurantID, id uuid.UUID, today time.Time) error {
+	return s.uow.Do(func(tx repositories.Transaction) error {
+		recurringRepo := s.recurringRepo.WithTx(tx)
+
+		recurring, err := recurringRepo.FindByIDForUpdate(restaurantID, id)
+		if err != nil {
+			return err
+		}
+		if !recurring.Active || recurring.NextDate == nil || recurring.NextDate.After(today) {
+			return nil
+		}
+
+		for recurring.NextDate != nil && !recurring.NextDate.After(today) {
+			occurrence := *recurring.NextDate
+			transaction := &models.FinancialTransaction{
+				RestaurantID:   recurring.RestaurantID,
+				Type:           recurring.Type,
+				Category:       recurring.Category,
+				Amount:         recurring.Amount,
+				Description:    recurring.Description,
+				UserID:         recurring.UserID,
+				PaymentMethod:  recurring.PaymentMethod,
+				Date:           occurrence,
+				RecurringID:    &recurring.ID,
+				OccurrenceDate: &occurrence,
+			}
+			if _, err := recurringRepo.CreateOccurrence(transaction); err != nil {
+				return fmt.Errorf("failed to record recurring transaction %s: %w", recurring.ID, err)
+			}
+
+			recurring.LastOccurrence = &occurrence
+			recurring.NextDate = recurring.NextOccurrence(occurrence.AddDate(0, 0, 1))
+		}
+		return recurringRepo.Update(recurring)
+	})
+}
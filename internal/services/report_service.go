@@ -0,0 +1,249 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+// maxReportDays limita o tamanho da série diária do fluxo de caixa
+const maxReportDays = 366
+
+var ErrInvalidReportPeriod = errors.New("invalid report period")
+
+// ReportPeriod é um intervalo semiaberto [From, To)
+type ReportPeriod struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// previous retorna o período de mesma duração imediatamente anterior
+func (p ReportPeriod) previous() ReportPeriod {
+	days := p.days()
+	return ReportPeriod{From: p.From.AddDate(0, 0, -days), To: p.From}
+}
+
+// days conta os dias do período pelo calendário, sem depender de horário de verão
+func (p ReportPeriod) days() int {
+	from := time.Date(p.From.Year(), p.From.Month(), p.From.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(p.To.Year(), p.To.Month(), p.To.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// Comparison compara um valor com o do período anterior.
+// ChangePercent é nulo quando o valor anterior é zero.
+type Comparison struct {
+	Current       models.Money `json:"current"`
+	Previous      models.Money `json:"previous"`
+	Change        models.Money `json:"change"`
+	ChangePercent *float64     `json:"change_percent"`
+}
+
+func compare(current, previous models.Money) Comparison {
+	comparison := Comparison{Current: current, Previous: previous, Change: current.Sub(previous)}
+	if !previous.IsZero() {
+		percent := math.Round(float64(comparison.Change)/math.Abs(float64(previous))*10000) / 100
+		comparison.ChangePercent = &percent
+	}
+	return comparison
+}
+
+// CategoryLine é uma linha da DRE
+type CategoryLine struct {
+	Category models.TransactionCategory `json:"category"`
+	Comparison
+}
+
+// ProfitAndLoss é a demonstração de resultado por categoria comparada ao período anterior
+type ProfitAndLoss struct {
+	Period         ReportPeriod   `json:"period"`
+	PreviousPeriod ReportPeriod   `json:"previous_period"`
+	Income         []CategoryLine `json:"income"`
+	Expenses       []CategoryLine `json:"expenses"`
+	TotalIncome    Comparison     `json:"total_income"`
+	TotalExpense   Comparison     `json:"total_expense"`
+	NetProfit      Comparison     `json:"net_profit"`
+}
+
+// CashFlowDay é um dia do fluxo de caixa; Balance acumula o saldo desde o início do período
+type CashFlowDay struct {
+	Date    string       `json:"date"`
+	Income  models.Money `json:"income"`
+	Expense models.Money `json:"expense"`
+	Net     models.Money `json:"net"`
+	Balance models.Money `json:"balance"`
+}
+
+type CashFlow struct {
+	Period       ReportPeriod  `json:"period"`
+	Days         []CashFlowDay `json:"days"`
+	TotalIncome  models.Money  `json:"total_income"`
+	TotalExpense models.Money  `json:"total_expense"`
+	Net          models.Money  `json:"net"`
+}
+
+// PaymentMethodLine soma os lançamentos de uma forma de pagamento; Share é a fatia das receitas
+type PaymentMethodLine struct {
+	Method  string       `json:"method"`
+	Income  models.Money `json:"income"`
+	Expense models.Money `json:"expense"`
+	Net     models.Money `json:"net"`
+	Count   int64        `json:"count"`
+	Share   float64      `json:"share"`
+}
+
+type PaymentMethodBreakdown struct {
+	Period      ReportPeriod        `json:"period"`
+	Methods     []PaymentMethodLine `json:"methods"`
+	TotalIncome models.Money        `json:"total_income"`
+}
+
+type ReportService struct {
+	reportRepo repositories.ReportRepository
+}
+
+func NewReportService(reportRepo repositories.ReportRepository) *ReportService {
+	return &ReportService{
+		reportRepo: reportRepo,
+	}
+}
+
+func validatePeriod(period ReportPeriod) error {
+	if !period.To.After(period.From) {
+		return fmt.Errorf("%w: end must be after start", ErrInvalidReportPeriod)
+	}
+	if period.days() > maxReportDays {
+		return fmt.Errorf("%w: period cannot exceed %d days", ErrInvalidReportPeriod, maxReportDays)
+	}
+	return nil
+}
+
+// ProfitAndLoss monta a DRE do período e a compara com o período anterior de mesma duração
+func (s *ReportService) ProfitAndLoss(restaurantID uuid.UUID, period ReportPeriod) (*ProfitAndLoss, error) {
+	if err := validatePeriod(period); err != nil {
+		return nil, err
+	}
+	previousPeriod := period.previous()
+
+	current, err := s.reportRepo.CategoryTotals(restaurantID, period.From, period.To)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := s.reportRepo.CategoryTotals(restaurantID, previousPeriod.From, previousPeriod.To)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		Type     models.TransactionType
+		Category models.TransactionCategory
+	}
+	previousTotals := make(map[key]models.Money, len(previous))
+	for _, total := range previous {
+		previousTotals[key{total.Type, total.Category}] = total.Total
+	}
+
+	report := &ProfitAndLoss{
+		Period:         period,
+		PreviousPeriod: previousPeriod,
+		Income:         []CategoryLine{},
+		Expenses:       []CategoryLine{},
+	}
+	var income, expense, previousIncome, previousExpense models.Money
+
+	addLine := func(transactionType models.TransactionType, category models.TransactionCategory, amount, previousAmount models.Money) {
+		line := CategoryLine{Category: category, Comparison: compare(amount, previousAmount)}
+		switch transactionType {
+		case models.TransactionTypeIncome:
+			report.Income = append(report.Income, line)
+			income, previousIncome = income.Add(amount), previousIncome.Add(previousAmount)
+		case models.TransactionTypeExpense:
+			report.Expenses = append(report.Expenses, line)
+			expense, previousExpense = expense.Add(amount), previousExpense.Add(previousAmount)
+		}
+	}
+
+	// Categorias do período atual na ordem do banco, seguidas das que só existiam no anterior
+	for _, total := range current {
+		k := key{total.Type, total.Category}
+		addLine(total.Type, total.Category, total.Total, previousTotals[k])
+		delete(previousTotals, k)
+	}
+	for _, total := range previous {
+		k := key{total.Type, total.Category}
+		if amount, ok := previousTotals[k]; ok {
+			addLine(total.Type, total.Category, 0, amount)
+		}
+	}
+
+	report.TotalIncome = compare(income, previousIncome)
+	report.TotalExpense = compare(expense, previousExpense)
+	report.NetProfit = compare(income.Sub(expense), previousIncome.Sub(previousExpense))
+	return report, nil
+}
+
+// CashFlow retorna a série diária de entradas e saídas do período
+func (s *ReportService) CashFlow(restaurantID uuid.UUID, period ReportPeriod) (*CashFlow, error) {
+	if err := validatePeriod(period); err != nil {
+		return nil, err
+	}
+
+	totals, err := s.reportRepo.DailyTotals(restaurantID, period.From, period.To)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CashFlow{Period: period, Days: make([]CashFlowDay, 0, len(totals))}
+	for _, total := range totals {
+		report.TotalIncome = report.TotalIncome.Add(total.Income)
+		report.TotalExpense = report.TotalExpense.Add(total.Expense)
+
+		net := total.Income.Sub(total.Expense)
+		report.Net = report.Net.Add(net)
+		report.Days = append(report.Days, CashFlowDay{
+			Date:    total.Day.Format("2006-01-02"),
+			Income:  total.Income,
+			Expense: total.Expense,
+			Net:     net,
+			Balance: report.Net,
+		})
+	}
+	return report, nil
+}
+
+// PaymentMethods detalha os lançamentos do período por forma de pagamento
+func (s *ReportService) PaymentMethods(restaurantID uuid.UUID, period ReportPeriod) (*PaymentMethodBreakdown, error) {
+	if err := validatePeriod(period); err != nil {
+		return nil, err
+	}
+
+	totals, err := s.reportRepo.PaymentMethodTotals(restaurantID, period.From, period.To)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PaymentMethodBreakdown{Period: period, Methods: make([]PaymentMethodLine, 0, len(totals))}
+	for _, total := range totals {
+		report.TotalIncome = report.TotalIncome.Add(total.Income)
+	}
+	for _, total := range totals {
+		line := PaymentMethodLine{
+			Method:  total.Method,
+			Income:  total.Income,
+			Expense: total.Expense,
+			Net:     total.Income.Sub(total.Expense),
+			Count:   total.Count,
+		}
+		if !report.TotalIncome.IsZero() {
+			line.Share = math.Round(float64(total.Income)/float64(report.TotalIncome)*10000) / 100
+		}
+		report.Methods = append(report.Methods, line)
+	}
+	return report, nil
+}
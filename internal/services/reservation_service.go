@@ -0,0 +1,447 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/realtime"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidReservation  = errors.New("invalid reservation")
+	ErrReservationConflict = errors.New("reservation conflict")
+)
+
+const (
+	// defaultReservationDuration é usada quando a reserva não informa a duração esperada
+	defaultReservationDuration = 120
+	// reservationSchedulerInterval é o intervalo entre as rodadas do agendador
+	reservationSchedulerInterval = time.Minute
+)
+
+// ReservationService gerencia as reservas de mesas. Além das operações da API, um agendador
+// marca as mesas como reservadas pouco antes do horário (holdLead), libera as mesas de quem
+// não apareceu após a tolerância (noShowGrace) e encerra as reservas já atendidas.
+type ReservationService struct {
+	uow             repositories.UnitOfWork
+	reservationRepo repositories.ReservationRepository
+	tableRepo       repositories.TableRepository
+	events          realtime.Publisher
+	holdLead        time.Duration
+	noShowGrace     time.Duration
+}
+
+func NewReservationService(uow repositories.UnitOfWork, reservationRepo repositories.ReservationRepository, tableRepo repositories.TableRepository, events realtime.Publisher, holdLead, noShowGrace time.Duration) *ReservationService {
+	return &ReservationService{
+		uow:             uow,
+		reservationRepo: reservationRepo,
+		tableRepo:       tableRepo,
+		events:          events,
+		holdLead:        holdLead,
+		noShowGrace:     noShowGrace,
+	}
+}
+
+// ReservationInput são os dados informados ao criar ou alterar uma reserva
+type ReservationInput struct {
+	CustomerName    string
+	CustomerPhone   string
+	PartySize       int
+	StartsAt        time.Time
+	DurationMinutes int
+	TableIDs        []uuid.UUID
+	Notes           string
+}
+
+func (in *ReservationInput) validate() error {
+	in.CustomerName = strings.TrimSpace(in.CustomerName)
+	in.CustomerPhone = strings.TrimSpace(in.CustomerPhone)
+	if in.DurationMinutes == 0 {
+		in.DurationMinutes = defaultReservationDuration
+	}
+
+	switch {
+	case in.CustomerName == "":
+		return fmt.Errorf("%w: customer name is required", ErrInvalidReservation)
+	case in.CustomerPhone == "":
+		return fmt.Errorf("%w: customer phone is required", ErrInvalidReservation)
+	case in.PartySize <= 0:
+		return fmt.Errorf("%w: party size must be positive", ErrInvalidReservation)
+	case in.DurationMinutes < 0:
+		return fmt.Errorf("%w: duration must be positive", ErrInvalidReservation)
+	case in.StartsAt.IsZero():
+		return fmt.Errorf("%w: start time is required", ErrInvalidReservation)
+	case len(in.TableIDs) == 0:
+		return fmt.Errorf("%w: at least one table is required", ErrInvalidReservation)
+	}
+	return nil
+}
+
+// publishReservation publica a reserva e as mesas alteradas para as telas do salão após o commit
+func (s *ReservationService) publishReservation(tx repositories.Transaction, eventType string, reservation *models.Reservation, tables []models.Table) {
+	publishAfterCommit(tx, s.events, reservation.RestaurantID, realtime.TopicTables, eventType, reservation)
+	for i := range tables {
+		publishAfterCommit(tx, s.events, tables[i].RestaurantID, realtime.TopicTables, EventTableUpdated, &tables[i])
+	}
+}
+
+// lockTables bloqueia as mesas em ordem de ID, evitando deadlocks entre reservas concorrentes
+// que disputam as mesmas mesas, e confere se comportam o grupo
+func (s *ReservationService) lockTables(tx repositories.Transaction, restaurantID uuid.UUID, ids []uuid.UUID, partySize int) ([]models.Table, error) {
+	tableRepo := s.tableRepo.WithTx(tx)
+
+	unique := make(map[uuid.UUID]bool, len(ids))
+	sorted := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !unique[id] {
+			unique[id] = true
+			sorted = append(sorted, id)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].String() < sorted[j].String()
+	})
+
+	tables := make([]models.Table, 0, len(sorted))
+	capacity := 0
+	for _, id := range sorted {
+		table, err := tableRepo.FindByIDForUpdate(restaurantID, id)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, *table)
+		capacity += table.Capacity
+	}
+
+	if capacity < partySize {
+		return nil, fmt.Errorf("%w: tables seat %d but party has %d people", ErrInvalidReservation, capacity, partySize)
+	}
+	return tables, nil
+}
+
+// checkOverlap garante que nenhuma outra reserva ativa usa as mesas no mesmo horário
+func (s *ReservationService) checkOverlap(tx repositories.Transaction, reservation *models.Reservation) error {
+	overlapping, err := s.reservationRepo.WithTx(tx).FindOverlapping(reservation.RestaurantID, reservation.TableIDs(),
+		reservation.StartsAt, reservation.EndsAt, reservation.ID)
+	if err != nil {
+		return err
+	}
+	if len(overlapping) > 0 {
+		other := overlapping[0]
+		return fmt.Errorf("%w: tables already reserved from %s to %s", ErrReservationConflict,
+			other.StartsAt.Format(time.RFC3339), other.EndsAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// holdIfDue reserva as mesas imediatamente quando a reserva já está dentro da antecedência,
+// sem esperar a próxima rodada do agendador
+func (s *ReservationService) holdIfDue(tx repositories.Transaction, reservation *models.Reservation) ([]models.Table, error) {
+	if time.Now().Before(reservation.StartsAt.Add(-s.holdLead)) {
+		return nil, nil
+	}
+	return s.tableRepo.WithTx(tx).Reserve(reservation.RestaurantID, reservation.TableIDs())
+}
+
+func (s *ReservationService) apply(reservation *models.Reservation, in ReservationInput) {
+	reservation.CustomerName = in.CustomerName
+	reservation.CustomerPhone = in.CustomerPhone
+	reservation.PartySize = in.PartySize
+	reservation.StartsAt = in.StartsAt
+	reservation.DurationMinutes = in.DurationMinutes
+	reservation.EndsAt = in.StartsAt.Add(time.Duration(in.DurationMinutes) * time.Minute)
+	reservation.Notes = in.Notes
+}
+
+func (s *ReservationService) Create(restaurantID, userID uuid.UUID, in ReservationInput) (*models.Reservation, error) {
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+	if in.StartsAt.Before(time.Now()) {
+		return nil, fmt.Errorf("%w: start time is in the past", ErrInvalidReservation)
+	}
+
+	reservation := &models.Reservation{
+		RestaurantID: restaurantID,
+		Status:       models.ReservationStatusBooked,
+		UserID:       userID,
+	}
+	s.apply(reservation, in)
+
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		tables, err := s.lockTables(tx, restaurantID, in.TableIDs, in.PartySize)
+		if err != nil {
+			return err
+		}
+		reservation.Tables = tables
+
+		if err := s.checkOverlap(tx, reservation); err != nil {
+			return err
+		}
+
+		if err := s.reservationRepo.WithTx(tx).Create(reservation); err != nil {
+			return err
+		}
+
+		held, err := s.holdIfDue(tx, reservation)
+		if err != nil {
+			return err
+		}
+
+		s.publishReservation(tx, EventReservationCreated, reservation, held)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// Update altera os dados, o horário ou as mesas de uma reserva que ainda aguarda o cliente
+func (s *ReservationService) Update(restaurantID, id uuid.UUID, in ReservationInput) (*models.Reservation, error) {
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+
+	var reservation *models.Reservation
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		reservationRepo := s.reservationRepo.WithTx(tx)
+
+		var err error
+		reservation, err = reservationRepo.FindByIDForUpdate(restaurantID, id)
+		if err != nil {
+			return err
+		}
+		if reservation.Status != models.ReservationStatusBooked {
+			return fmt.Errorf("%w: only booked reservations can be changed", ErrInvalidReservation)
+		}
+
+		// As mesas antigas e as novas são bloqueadas juntas e em ordem de ID antes de qualquer alteração,
+		// como nas demais operações, para que atualizações concorrentes não se bloqueiem mutuamente
+		if _, err := s.lockTables(tx, restaurantID, append(reservation.TableIDs(), in.TableIDs...), 0); err != nil {
+			return err
+		}
+
+		// As mesas seguradas para o horário antigo voltam a ficar livres e são reservadas de novo se ainda for o caso
+		previous, err := s.tableRepo.WithTx(tx).Unreserve(restaurantID, reservation.TableIDs())
+		if err != nil {
+			return err
+		}
+
+		tables, err := s.lockTables(tx, restaurantID, in.TableIDs, in.PartySize)
+		if err != nil {
+			return err
+		}
+
+		s.apply(reservation, in)
+		reservation.Tables = tables
+		if err := s.checkOverlap(tx, reservation); err != nil {
+			return err
+		}
+
+		if err := reservationRepo.Update(reservation); err != nil {
+			return err
+		}
+		if err := reservationRepo.ReplaceTables(reservation, tables); err != nil {
+			return err
+		}
+
+		held, err := s.holdIfDue(tx, reservation)
+		if err != nil {
+			return err
+		}
+
+		s.publishReservation(tx, EventReservationUpdated, reservation, mergeTables(previous, held))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// mergeTables junta as listas mantendo apenas o último estado de cada mesa
+func mergeTables(lists ...[]models.Table) []models.Table {
+	index := make(map[uuid.UUID]int)
+	var merged []models.Table
+	for _, list := range lists {
+		for _, table := range list {
+			if i, ok := index[table.ID]; ok {
+				merged[i] = table
+				continue
+			}
+			index[table.ID] = len(merged)
+			merged = append(merged, table)
+		}
+	}
+	return merged
+}
+
+// finish encerra a reserva com o status informado e libera as mesas que estavam seguradas para ela
+func (s *ReservationService) finish(tx repositories.Transaction, reservation *models.Reservation, status models.ReservationStatus) error {
+	now := time.Now()
+	reservation.Status = status
+	if status == models.ReservationStatusCancelled {
+		reservation.CancelledAt = &now
+	}
+
+	if err := s.reservationRepo.WithTx(tx).Update(reservation); err != nil {
+		return err
+	}
+
+	released, err := s.tableRepo.WithTx(tx).Unreserve(reservation.RestaurantID, reservation.TableIDs())
+	if err != nil {
+		return err
+	}
+
+	s.publishReservation(tx, EventReservationUpdated, reservation, released)
+	return nil
+}
+
+// changeStatus aplica uma ação manual sobre uma reserva que ainda aguarda o cliente
+func (s *ReservationService) changeStatus(restaurantID, id uuid.UUID, apply func(tx repositories.Transaction, reservation *models.Reservation) error) (*models.Reservation, error) {
+	var reservation *models.Reservation
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		var err error
+		reservation, err = s.reservationRepo.WithTx(tx).FindByIDForUpdate(restaurantID, id)
+		if err != nil {
+			return err
+		}
+		if reservation.Status != models.ReservationStatusBooked {
+			return fmt.Errorf("%w: reservation is %s", ErrInvalidReservation, reservation.Status)
+		}
+		return apply(tx, reservation)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// Cancel cancela a reserva a pedido do cliente
+func (s *ReservationService) Cancel(restaurantID, id uuid.UUID) (*models.Reservation, error) {
+	return s.changeStatus(restaurantID, id, func(tx repositories.Transaction, reservation *models.Reservation) error {
+		return s.finish(tx, reservation, models.ReservationStatusCancelled)
+	})
+}
+
+// NoShow registra manualmente que o cliente não compareceu, sem esperar a tolerância
+func (s *ReservationService) NoShow(restaurantID, id uuid.UUID) (*models.Reservation, error) {
+	return s.changeStatus(restaurantID, id, func(tx repositories.Transaction, reservation *models.Reservation) error {
+		return s.finish(tx, reservation, models.ReservationStatusNoShow)
+	})
+}
+
+// Seat registra a chegada do cliente. As mesas continuam reservadas até a abertura do pedido.
+func (s *ReservationService) Seat(restaurantID, id uuid.UUID) (*models.Reservation, error) {
+	return s.changeStatus(restaurantID, id, func(tx repositories.Transaction, reservation *models.Reservation) error {
+		now := time.Now()
+		reservation.Status = models.ReservationStatusSeated
+		reservation.SeatedAt = &now
+
+		if err := s.reservationRepo.WithTx(tx).Update(reservation); err != nil {
+			return err
+		}
+
+		// O cliente pode chegar antes da antecedência configurada
+		held, err := s.tableRepo.WithTx(tx).Reserve(restaurantID, reservation.TableIDs())
+		if err != nil {
+			return err
+		}
+
+		s.publishReservation(tx, EventReservationUpdated, reservation, held)
+		return nil
+	})
+}
+
+func (s *ReservationService) GetByID(restaurantID, id uuid.UUID) (*models.Reservation, error) {
+	return s.reservationRepo.FindByID(restaurantID, id)
+}
+
+func (s *ReservationService) List(restaurantID uuid.UUID, filter repositories.ReservationFilter) ([]models.Reservation, error) {
+	return s.reservationRepo.List(restaurantID, filter)
+}
+
+// RunScheduler executa o agendador de reservas até ctx ser cancelado.
+// Pode rodar em várias instâncias: as reservas processadas ficam bloqueadas durante a rodada.
+func (s *ReservationService) RunScheduler(ctx context.Context) {
+	ticker := time.NewTicker(reservationSchedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.runSchedulerOnce(now); err != nil {
+				log.Printf("Falha ao processar reservas agendadas: %v", err)
+			}
+		}
+	}
+}
+
+// runSchedulerOnce encerra as reservas vencidas antes de segurar as próximas,
+// para que uma mesa liberada por um no-show volte a ser reservada na mesma rodada
+// se outra reserva já estiver dentro da antecedência
+func (s *ReservationService) runSchedulerOnce(now time.Time) error {
+	steps := []func(tx repositories.Transaction) error{
+		func(tx repositories.Transaction) error {
+			reservations, err := s.reservationRepo.WithTx(tx).FindSeatedEndedBefore(now)
+			if err != nil {
+				return err
+			}
+			for i := range reservations {
+				if err := s.finish(tx, &reservations[i], models.ReservationStatusCompleted); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		func(tx repositories.Transaction) error {
+			reservations, err := s.reservationRepo.WithTx(tx).FindBookedBefore(now.Add(-s.noShowGrace))
+			if err != nil {
+				return err
+			}
+			for i := range reservations {
+				if err := s.finish(tx, &reservations[i], models.ReservationStatusNoShow); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		func(tx repositories.Transaction) error {
+			reservations, err := s.reservationRepo.WithTx(tx).FindBookedBetween(now.Add(-s.noShowGrace), now.Add(s.holdLead))
+			if err != nil {
+				return err
+			}
+			tableRepo := s.tableRepo.WithTx(tx)
+			for i := range reservations {
+				// Reserve só altera mesas livres, então rodadas repetidas não geram eventos
+				held, err := tableRepo.Reserve(reservations[i].RestaurantID, reservations[i].TableIDs())
+				if err != nil {
+					return err
+				}
+				for j := range held {
+					publishAfterCommit(tx, s.events, held[j].RestaurantID, realtime.TopicTables, EventTableUpdated, &held[j])
+				}
+			}
+			return nil
+		},
+	}
+
+	for _, step := range steps {
+		if err := s.uow.Do(step); err != nil {
+			return err
+		}
+	}
+	return nil
+}
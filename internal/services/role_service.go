@@ -0,0 +1,287 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidRole = errors.New("invalid role")
+	// ErrRoleBuiltIn indica uma operação não permitida nos papéis padrão
+	ErrRoleBuiltIn = errors.New("operation not allowed for built-in roles")
+	// ErrPermissionDenied indica que o usuário tentou conceder ou atribuir mais do que pode
+	ErrPermissionDenied = errors.New("insufficient permissions")
+)
+
+// defaultRoleTypes são os tipos de usuário que têm um papel padrão em cada restaurante
+var defaultRoleTypes = []models.UserType{models.UserTypeAdmin, models.UserTypeManager, models.UserTypeStaff}
+
+var defaultRoleNames = map[models.UserType]string{
+	models.UserTypeAdmin:   "Admin",
+	models.UserTypeManager: "Manager",
+	models.UserTypeStaff:   "Staff",
+}
+
+// Actor é o usuário autenticado que executa a operação
+type Actor struct {
+	ID   uuid.UUID
+	Type models.UserType
+}
+
+// RoleInput descreve um papel; as permissões precisam estar no catálogo
+type RoleInput struct {
+	Name        string
+	Description string
+	Permissions []models.Permission
+}
+
+// RoleService mantém os papéis de cada restaurante e resolve as permissões dos usuários
+type RoleService struct {
+	uow            repositories.UnitOfWork
+	roleRepo       repositories.RoleRepository
+	membershipRepo repositories.MembershipRepository
+}
+
+func NewRoleService(uow repositories.UnitOfWork, roleRepo repositories.RoleRepository, membershipRepo repositories.MembershipRepository) *RoleService {
+	return &RoleService{
+		uow:            uow,
+		roleRepo:       roleRepo,
+		membershipRepo: membershipRepo,
+	}
+}
+
+// EnsureDefaults grava os papéis padrão que o restaurante ainda não tem
+func (s *RoleService) EnsureDefaults(restaurantID uuid.UUID) error {
+	return s.uow.Do(func(tx repositories.Transaction) error {
+		for _, userType := range defaultRoleTypes {
+			role := &models.Role{
+				RestaurantID: restaurantID,
+				Name:         defaultRoleNames[userType],
+				UserType:     &userType,
+				Permissions:  models.DefaultPermissions(userType),
+			}
+			if _, err := s.roleRepo.WithTx(tx).CreateDefault(role); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// membershipPermissions retorna as permissões do papel do vínculo ou, sem papel próprio, as do papel
+// padrão do seu tipo. Enquanto o restaurante não gravar o papel padrão valem as permissões de fábrica.
+func (s *RoleService) membershipPermissions(membership *models.Membership) ([]models.Permission, error) {
+	var (
+		role *models.Role
+		err  error
+	)
+	if membership.RoleID != nil {
+		role, err = s.roleRepo.FindByID(membership.RestaurantID, *membership.RoleID)
+	} else {
+		role, err = s.roleRepo.FindByUserType(membership.RestaurantID, membership.Type)
+	}
+	if err != nil {
+		if errors.Is(err, repositories.ErrRoleNotFound) {
+			return models.DefaultPermissions(membership.Type), nil
+		}
+		return nil, err
+	}
+	return role.Permissions, nil
+}
+
+// Permissions retorna as permissões do usuário no restaurante; o superadmin tem todas
+func (s *RoleService) Permissions(restaurantID uuid.UUID, actor Actor) ([]models.Permission, error) {
+	if actor.Type == models.UserTypeSuperAdmin {
+		return models.DefaultPermissions(models.UserTypeSuperAdmin), nil
+	}
+
+	membership, err := s.membershipRepo.Find(restaurantID, actor.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.membershipPermissions(membership)
+}
+
+// HasPermission informa se o usuário tem a permissão no restaurante
+func (s *RoleService) HasPermission(restaurantID, userID uuid.UUID, userType models.UserType, permission models.Permission) (bool, error) {
+	permissions, err := s.Permissions(restaurantID, Actor{ID: userID, Type: userType})
+	if err != nil {
+		if errors.Is(err, repositories.ErrMembershipNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return slices.Contains(permissions, permission), nil
+}
+
+// checkGrant garante que o usuário só conceda permissões que ele próprio tem
+func (s *RoleService) checkGrant(restaurantID uuid.UUID, actor Actor, permissions []models.Permission) error {
+	if actor.Type == models.UserTypeSuperAdmin {
+		return nil
+	}
+
+	held, err := s.Permissions(restaurantID, actor)
+	if err != nil {
+		return err
+	}
+	for _, permission := range permissions {
+		if !slices.Contains(held, permission) {
+			return fmt.Errorf("%w: you cannot grant %s", ErrPermissionDenied, permission)
+		}
+	}
+	return nil
+}
+
+// CheckAssignment garante que o usuário só cadastre ou altere usuários de tipos que ele gerencia
+// e só atribua papéis cujas permissões ele próprio tem
+func (s *RoleService) CheckAssignment(restaurantID uuid.UUID, actor Actor, userType models.UserType, roleID *uuid.UUID) error {
+	if !userType.Valid() {
+		return fmt.Errorf("%w: invalid user type", ErrInvalidRole)
+	}
+	if !actor.Type.CanManage(userType) {
+		return fmt.Errorf("%w: you cannot manage %s users", ErrPermissionDenied, userType)
+	}
+	if roleID == nil {
+		return nil
+	}
+
+	role, err := s.roleRepo.FindByID(restaurantID, *roleID)
+	if err != nil {
+		return err
+	}
+	return s.checkGrant(restaurantID, actor, role.Permissions)
+}
+
+// apply valida a entrada e a copia para o papel; o nome não pode repetir outro papel do restaurante
+func (s *RoleService) apply(role *models.Role, in RoleInput) error {
+	in.Name = strings.TrimSpace(in.Name)
+	switch {
+	case in.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidRole)
+	case len(in.Name) > 50:
+		return fmt.Errorf("%w: name must have at most 50 characters", ErrInvalidRole)
+	case len(in.Description) > 255:
+		return fmt.Errorf("%w: description must have at most 255 characters", ErrInvalidRole)
+	}
+
+	permissions := make([]models.Permission, 0, len(in.Permissions))
+	for _, permission := range in.Permissions {
+		if !permission.Valid() {
+			return fmt.Errorf("%w: unknown permission %q", ErrInvalidRole, permission)
+		}
+		if !slices.Contains(permissions, permission) {
+			permissions = append(permissions, permission)
+		}
+	}
+	// O papel padrão de admin sempre pode gerir papéis, para que o restaurante não perca o acesso
+	if role.UserType != nil && *role.UserType == models.UserTypeAdmin && !slices.Contains(permissions, models.PermissionRolesWrite) {
+		return fmt.Errorf("%w: the built-in admin role must keep %s", ErrInvalidRole, models.PermissionRolesWrite)
+	}
+
+	roles, err := s.roleRepo.List(role.RestaurantID)
+	if err != nil {
+		return err
+	}
+	for _, other := range roles {
+		if other.ID != role.ID && strings.EqualFold(other.Name, in.Name) {
+			return fmt.Errorf("%w: a role named %s already exists", ErrInvalidRole, other.Name)
+		}
+	}
+
+	role.Name = in.Name
+	role.Description = in.Description
+	role.Permissions = permissions
+	return nil
+}
+
+// List retorna os papéis do restaurante, gravando antes os papéis padrão que faltarem
+func (s *RoleService) List(restaurantID uuid.UUID) ([]models.Role, error) {
+	if err := s.EnsureDefaults(restaurantID); err != nil {
+		return nil, err
+	}
+	return s.roleRepo.List(restaurantID)
+}
+
+func (s *RoleService) Get(restaurantID, id uuid.UUID) (*models.Role, error) {
+	return s.roleRepo.FindByID(restaurantID, id)
+}
+
+func (s *RoleService) Create(restaurantID uuid.UUID, actor Actor, in RoleInput) (*models.Role, error) {
+	if err := s.EnsureDefaults(restaurantID); err != nil {
+		return nil, err
+	}
+
+	role := &models.Role{RestaurantID: restaurantID}
+	if err := s.apply(role, in); err != nil {
+		return nil, err
+	}
+	if err := s.checkGrant(restaurantID, actor, role.Permissions); err != nil {
+		return nil, err
+	}
+
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		return s.roleRepo.WithTx(tx).Create(role)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.roleRepo.FindByID(restaurantID, role.ID)
+}
+
+// Update altera o papel; nos papéis padrão o tipo de usuário associado é mantido
+func (s *RoleService) Update(restaurantID, id uuid.UUID, actor Actor, in RoleInput) (*models.Role, error) {
+	role, err := s.roleRepo.FindByID(restaurantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(role, in); err != nil {
+		return nil, err
+	}
+	if err := s.checkGrant(restaurantID, actor, role.Permissions); err != nil {
+		return nil, err
+	}
+
+	err = s.uow.Do(func(tx repositories.Transaction) error {
+		return s.roleRepo.WithTx(tx).Update(role)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.roleRepo.FindByID(restaurantID, id)
+}
+
+// Delete exclui um papel personalizado; os usuários que o tinham voltam ao papel padrão do seu tipo
+func (s *RoleService) Delete(restaurantID, id uuid.UUID) error {
+	role, err := s.roleRepo.FindByID(restaurantID, id)
+	if err != nil {
+		return err
+	}
+	if role.BuiltIn() {
+		return fmt.Errorf("%w: %s cannot be deleted", ErrRoleBuiltIn, role.Name)
+	}
+	return s.roleRepo.Delete(restaurantID, id)
+}
+
+// AssignRole define o papel do usuário no restaurante; sem roleID ele volta ao papel padrão do seu tipo
+func (s *RoleService) AssignRole(restaurantID, userID uuid.UUID, actor Actor, roleID *uuid.UUID) (*models.Membership, error) {
+	membership, err := s.membershipRepo.Find(restaurantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.CheckAssignment(restaurantID, actor, membership.Type, roleID); err != nil {
+		return nil, err
+	}
+
+	membership.RoleID = roleID
+	if err := s.membershipRepo.Update(membership); err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
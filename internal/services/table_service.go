@@ -2,24 +2,53 @@
 package services
 
 import (
+	"errors"
+	"fmt"
+	"sort"
+
 	"api-jet-manager/internal/domain/models"
 	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/realtime"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrInvalidTableMerge  = errors.New("invalid table merge")
+	ErrInvalidTableLayout = errors.New("invalid table layout")
+)
+
 type TableService struct {
+	uow       repositories.UnitOfWork
 	tableRepo repositories.TableRepository
+	events    realtime.Publisher
 }
 
-func NewTableService(tableRepo repositories.TableRepository) *TableService {
+func NewTableService(uow repositories.UnitOfWork, tableRepo repositories.TableRepository, events realtime.Publisher) *TableService {
 	return &TableService{
+		uow:       uow,
 		tableRepo: tableRepo,
+		events:    events,
+	}
+}
+
+// publishTable recarrega a mesa e as mesas juntadas a ela e publica seu estado atual para as telas do salão
+func (s *TableService) publishTable(restaurant_id uuid.UUID, id uuid.UUID) {
+	tables, err := s.tableRepo.FindGroup(restaurant_id, id)
+	if err != nil {
+		return
+	}
+	for i := range tables {
+		s.events.Publish(restaurant_id, realtime.TopicTables, EventTableUpdated, &tables[i])
 	}
 }
 
 func (s *TableService) Create(table *models.Table) error {
-	return s.tableRepo.Create(table)
+	if err := s.tableRepo.Create(table); err != nil {
+		return err
+	}
+	s.events.Publish(table.RestaurantID, realtime.TopicTables, EventTableCreated, table)
+	return nil
 }
 
 func (s *TableService) GetByID(restaurant_id uuid.UUID, id uuid.UUID) (*models.Table, error) {
@@ -31,11 +60,19 @@ func (s *TableService) GetByNumber(restaurant_id uuid.UUID, number int) (*models
 }
 
 func (s *TableService) Update(table *models.Table) error {
-	return s.tableRepo.Update(table)
+	if err := s.tableRepo.Update(table); err != nil {
+		return err
+	}
+	s.events.Publish(table.RestaurantID, realtime.TopicTables, EventTableUpdated, table)
+	return nil
 }
 
 func (s *TableService) Delete(restaurant_id uuid.UUID, id uuid.UUID) error {
-	return s.tableRepo.Delete(restaurant_id, id)
+	if err := s.tableRepo.Delete(restaurant_id, id); err != nil {
+		return err
+	}
+	s.events.Publish(restaurant_id, realtime.TopicTables, EventTableDeleted, map[string]uuid.UUID{"id": id})
+	return nil
 }
 
 func (s *TableService) List(restaurant_id uuid.UUID) ([]models.Table, error) {
@@ -43,9 +80,288 @@ func (s *TableService) List(restaurant_id uuid.UUID) ([]models.Table, error) {
 }
 
 func (s *TableService) UpdateStatus(restaurant_id uuid.UUID, id uuid.UUID, status models.TableStatus) error {
-	return s.tableRepo.UpdateStatus(restaurant_id, id, status)
+	if err := s.tableRepo.UpdateStatus(restaurant_id, id, status); err != nil {
+		return err
+	}
+	s.publishTable(restaurant_id, id)
+	return nil
 }
 
 func (s *TableService) SetCurrentOrder(restaurant_id uuid.UUID, id uuid.UUID, orderID *uuid.UUID) error {
-	return s.tableRepo.SetCurrentOrder(restaurant_id, id, orderID)
+	if err := s.tableRepo.SetCurrentOrder(restaurant_id, id, orderID); err != nil {
+		return err
+	}
+	s.publishTable(restaurant_id, id)
+	return nil
+}
+
+// Occupy marca a mesa como ocupada e a vincula ao pedido de forma atômica.
+// Retorna ErrTableNotAvailable se a mesa já estiver ocupada.
+func (s *TableService) Occupy(restaurant_id uuid.UUID, id uuid.UUID, orderID *uuid.UUID) error {
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		tableRepo := s.tableRepo.WithTx(tx)
+
+		table, err := tableRepo.FindByIDForUpdate(restaurant_id, id)
+		if err != nil {
+			return err
+		}
+
+		if table.Status == models.TableStatusOccupied {
+			return ErrTableNotAvailable
+		}
+
+		return tableRepo.Occupy(restaurant_id, id, orderID)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publishTable(restaurant_id, id)
+	return nil
+}
+
+// Release libera a mesa e remove o vínculo com o pedido atual de forma atômica
+func (s *TableService) Release(restaurant_id uuid.UUID, id uuid.UUID) error {
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		tableRepo := s.tableRepo.WithTx(tx)
+
+		if _, err := tableRepo.FindByIDForUpdate(restaurant_id, id); err != nil {
+			return err
+		}
+
+		return tableRepo.Release(restaurant_id, id)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publishTable(restaurant_id, id)
+	return nil
+}
+
+// TableLayout é a posição de uma mesa na planta do salão
+type TableLayout struct {
+	TableID  uuid.UUID
+	AreaID   *uuid.UUID
+	Shape    models.TableShape
+	PosX     float64
+	PosY     float64
+	Width    float64
+	Height   float64
+	Rotation float64
+}
+
+// UpdateLayout grava de uma vez as posições das mesas editadas na planta
+func (s *TableService) UpdateLayout(restaurant_id uuid.UUID, layouts []TableLayout) ([]models.Table, error) {
+	tables := make([]models.Table, 0, len(layouts))
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		tableRepo := s.tableRepo.WithTx(tx)
+
+		for _, layout := range layouts {
+			if !layout.Shape.Valid() {
+				return fmt.Errorf("%w: invalid shape %q", ErrInvalidTableLayout, layout.Shape)
+			}
+			if layout.Width <= 0 || layout.Height <= 0 {
+				return fmt.Errorf("%w: width and height must be positive", ErrInvalidTableLayout)
+			}
+			if layout.AreaID != nil {
+				if _, err := tableRepo.FindAreaByID(restaurant_id, *layout.AreaID); err != nil {
+					return err
+				}
+			}
+
+			table, err := tableRepo.FindByIDForUpdate(restaurant_id, layout.TableID)
+			if err != nil {
+				return err
+			}
+
+			table.AreaID = layout.AreaID
+			table.Shape = layout.Shape
+			table.PosX = layout.PosX
+			table.PosY = layout.PosY
+			table.Width = layout.Width
+			table.Height = layout.Height
+			table.Rotation = layout.Rotation
+			if err := tableRepo.UpdateLayout(table); err != nil {
+				return err
+			}
+			tables = append(tables, *table)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range tables {
+		s.events.Publish(restaurant_id, realtime.TopicTables, EventTableUpdated, &tables[i])
+	}
+	return tables, nil
+}
+
+// Merge junta as mesas à mesa principal para receber um grupo grande. As mesas passam a
+// compartilhar o status e o pedido atual da principal. No máximo uma das mesas pode ter
+// pedido aberto; pedidos separados precisam ser transferidos antes.
+func (s *TableService) Merge(restaurant_id uuid.UUID, primaryID uuid.UUID, memberIDs []uuid.UUID) ([]models.Table, error) {
+	members := make([]uuid.UUID, 0, len(memberIDs))
+	seen := map[uuid.UUID]bool{primaryID: true}
+	for _, id := range memberIDs {
+		if !seen[id] {
+			seen[id] = true
+			members = append(members, id)
+		}
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("%w: at least one other table is required", ErrInvalidTableMerge)
+	}
+
+	// Bloquear as mesas sempre na mesma ordem evita deadlocks entre junções concorrentes
+	locking := append([]uuid.UUID{primaryID}, members...)
+	sort.Slice(locking, func(i, j int) bool {
+		return locking[i].String() < locking[j].String()
+	})
+
+	var group []models.Table
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		tableRepo := s.tableRepo.WithTx(tx)
+
+		tables := make(map[uuid.UUID]*models.Table, len(locking))
+		for _, id := range locking {
+			table, err := tableRepo.FindByIDForUpdate(restaurant_id, id)
+			if err != nil {
+				return err
+			}
+			tables[id] = table
+		}
+
+		primary := tables[primaryID]
+		if primary.MergedIntoID != nil {
+			return fmt.Errorf("%w: table %d is already merged into another table", ErrInvalidTableMerge, primary.Number)
+		}
+
+		var sharedOrder *uuid.UUID
+		status := primary.Status
+		for _, table := range tables {
+			if table.ID != primaryID {
+				if table.MergedIntoID != nil && *table.MergedIntoID != primaryID {
+					return fmt.Errorf("%w: table %d is already merged into another table", ErrInvalidTableMerge, table.Number)
+				}
+				if table.MergedIntoID == nil {
+					ownGroup, err := tableRepo.FindGroup(restaurant_id, table.ID)
+					if err != nil {
+						return err
+					}
+					if len(ownGroup) > 1 {
+						return fmt.Errorf("%w: table %d has merged tables; split it first", ErrInvalidTableMerge, table.Number)
+					}
+				}
+			}
+
+			if table.CurrentOrderID != nil {
+				if sharedOrder != nil && *sharedOrder != *table.CurrentOrderID {
+					return fmt.Errorf("%w: tables have separate open orders; transfer them first", ErrInvalidTableMerge)
+				}
+				sharedOrder = table.CurrentOrderID
+			}
+
+			// Ocupada prevalece sobre reservada, que prevalece sobre livre
+			if table.Status == models.TableStatusOccupied ||
+				(table.Status == models.TableStatusReserved && status == models.TableStatusFree) {
+				status = table.Status
+			}
+		}
+
+		if sharedOrder != nil {
+			if err := tableRepo.Occupy(restaurant_id, primaryID, sharedOrder); err != nil {
+				return err
+			}
+		} else if status != primary.Status {
+			if err := tableRepo.UpdateStatus(restaurant_id, primaryID, status); err != nil {
+				return err
+			}
+		}
+
+		if err := tableRepo.Merge(restaurant_id, primaryID, members); err != nil {
+			return err
+		}
+
+		var err error
+		group, err = tableRepo.FindGroup(restaurant_id, primaryID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range group {
+		s.events.Publish(restaurant_id, realtime.TopicTables, EventTableUpdated, &group[i])
+	}
+	return group, nil
+}
+
+// Split separa mesas juntadas. Informando a mesa principal, o grupo inteiro é desfeito;
+// informando outra mesa do grupo, apenas ela é separada. As mesas separadas ficam livres
+// e o pedido compartilhado permanece na mesa principal.
+func (s *TableService) Split(restaurant_id uuid.UUID, id uuid.UUID) ([]models.Table, error) {
+	var affected []uuid.UUID
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		tableRepo := s.tableRepo.WithTx(tx)
+
+		group, err := tableRepo.FindGroupForUpdate(restaurant_id, id)
+		if err != nil {
+			return err
+		}
+		if len(group) < 2 {
+			return fmt.Errorf("%w: table is not merged", ErrInvalidTableMerge)
+		}
+
+		primaryID := group[0].ID
+		var members []uuid.UUID
+		for _, table := range group[1:] {
+			if id == primaryID || table.ID == id {
+				members = append(members, table.ID)
+			}
+		}
+
+		if err := tableRepo.Split(restaurant_id, primaryID, members); err != nil {
+			return err
+		}
+		affected = append([]uuid.UUID{primaryID}, members...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make([]models.Table, 0, len(affected))
+	for _, tableID := range affected {
+		if table, err := s.tableRepo.FindByID(restaurant_id, tableID); err == nil {
+			tables = append(tables, *table)
+			s.events.Publish(restaurant_id, realtime.TopicTables, EventTableUpdated, table)
+		}
+	}
+	return tables, nil
+}
+
+func (s *TableService) CreateArea(area *models.DiningArea) error {
+	return s.tableRepo.CreateArea(area)
+}
+
+func (s *TableService) GetArea(restaurant_id uuid.UUID, id uuid.UUID) (*models.DiningArea, error) {
+	return s.tableRepo.FindAreaByID(restaurant_id, id)
+}
+
+func (s *TableService) ListAreas(restaurant_id uuid.UUID) ([]models.DiningArea, error) {
+	return s.tableRepo.ListAreas(restaurant_id)
+}
+
+func (s *TableService) UpdateArea(area *models.DiningArea) error {
+	return s.tableRepo.UpdateArea(area)
+}
+
+func (s *TableService) DeleteArea(restaurant_id uuid.UUID, id uuid.UUID) error {
+	if _, err := s.tableRepo.FindAreaByID(restaurant_id, id); err != nil {
+		return err
+	}
+	return s.tableRepo.DeleteArea(restaurant_id, id)
 }
@@ -6,53 +6,52 @@ import (
 
 	"api-jet-manager/internal/domain/models"
 	"api-jet-manager/internal/domain/repositories"
-	"api-jet-manager/internal/infrastructure/auth"
 
 	"github.com/google/uuid"
 )
 
 type UserService struct {
-	userRepo   repositories.UserRepository
-	jwtService *auth.JWTService
+	userRepo    repositories.UserRepository
+	authService *AuthService
 }
 
-func NewUserService(userRepo repositories.UserRepository, jwtService *auth.JWTService) *UserService {
+func NewUserService(userRepo repositories.UserRepository, authService *AuthService) *UserService {
 	return &UserService{
-		userRepo:   userRepo,
-		jwtService: jwtService,
+		userRepo:    userRepo,
+		authService: authService,
 	}
 }
 
 func (s *UserService) Register(user *models.User) error {
-	// Verificar se já existe um usuário com o mesmo email
-	existingUser, err := s.userRepo.FindByEmail(*user.RestaurantID, user.Email)
+	// O email é único entre todos os restaurantes; contas existentes são vinculadas como membros
+	existingUser, err := s.userRepo.FindByEmailGlobal(user.Email)
 	if err == nil && existingUser != nil {
 		return errors.New("user with this email already exists")
 	}
 
-	// Criar o usuário
+	// Criar o usuário junto com os vínculos informados
 	return s.userRepo.Create(user)
 }
 
-func (s *UserService) Login(email, password string) (string, *models.User, error) {
+func (s *UserService) Login(email, password string, client ClientInfo) (*TokenPair, *models.User, error) {
 	// Buscar usuário pelo email
 	user, err := s.userRepo.FindByEmailGlobal(email)
 	if err != nil {
-		return "", nil, errors.New("invalid credentials")
+		return nil, nil, errors.New("invalid credentials")
 	}
 
 	// Verificar a senha
 	if err := user.CheckPassword(password); err != nil {
-		return "", nil, errors.New("invalid credentials")
+		return nil, nil, errors.New("invalid credentials")
 	}
 
-	// Gerar o token JWT
-	token, err := s.jwtService.GenerateToken(user)
+	// Abrir a sessão no restaurante padrão com o token de acesso e o refresh token
+	tokens, scoped, err := s.authService.Login(user, client)
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to generate token: %w", err)
+		return nil, nil, fmt.Errorf("failed to generate token: %w", err)
 	}
 
-	return token, user, nil
+	return tokens, scoped, nil
 }
 
 func (s *UserService) FindUserByID(restaurantID uuid.UUID, id uuid.UUID) (*models.User, error) {
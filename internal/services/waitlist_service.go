@@ -0,0 +1,337 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"api-jet-manager/internal/domain/models"
+	"api-jet-manager/internal/domain/repositories"
+	"api-jet-manager/internal/infrastructure/realtime"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidWaitlistEntry = errors.New("invalid waitlist entry")
+
+const (
+	// seatHistoryWindow é o período de pedidos usado para calcular o tempo médio de permanência
+	seatHistoryWindow = 30 * 24 * time.Hour
+	// minSeatHistory é o mínimo de pedidos para confiar na média; abaixo disso vale defaultSeatDuration
+	minSeatHistory      = 10
+	defaultSeatDuration = time.Hour
+	// minTableRelease é o menor tempo estimado para liberar uma mesa que já passou da média
+	minTableRelease = 5 * time.Minute
+	// quoteRounding arredonda a espera informada ao cliente
+	quoteRounding = 5 * time.Minute
+)
+
+// WaitQuote é a estimativa de espera para um grupo entrar agora na fila
+type WaitQuote struct {
+	PartySize          int `json:"party_size"`
+	WaitMinutes        int `json:"wait_minutes"`
+	PartiesAhead       int `json:"parties_ahead"` // Grupos na frente que disputam as mesas adequadas
+	FreeTables         int `json:"free_tables"`   // Mesas livres que comportam o grupo
+	AverageSeatMinutes int `json:"average_seat_minutes"`
+}
+
+// WaitlistService gerencia a fila de espera de clientes sem reserva
+type WaitlistService struct {
+	uow          repositories.UnitOfWork
+	waitlistRepo repositories.WaitlistRepository
+	tableRepo    repositories.TableRepository
+	orderRepo    repositories.OrderRepository
+	orderService *OrderService
+	events       realtime.Publisher
+}
+
+func NewWaitlistService(uow repositories.UnitOfWork, waitlistRepo repositories.WaitlistRepository, tableRepo repositories.TableRepository, orderRepo repositories.OrderRepository, orderService *OrderService, events realtime.Publisher) *WaitlistService {
+	return &WaitlistService{
+		uow:          uow,
+		waitlistRepo: waitlistRepo,
+		tableRepo:    tableRepo,
+		orderRepo:    orderRepo,
+		orderService: orderService,
+		events:       events,
+	}
+}
+
+// averageSeatDuration calcula a permanência média das mesas pelo histórico de pedidos pagos
+func (s *WaitlistService) averageSeatDuration(restaurantID uuid.UUID, now time.Time) (time.Duration, error) {
+	average, count, err := s.orderRepo.AverageSeatDuration(restaurantID, now.Add(-seatHistoryWindow))
+	if err != nil {
+		return 0, err
+	}
+	if count < minSeatHistory || average <= 0 {
+		return defaultSeatDuration, nil
+	}
+	return average, nil
+}
+
+// estimate calcula a espera de um grupo considerando as mesas que o comportam, quando cada
+// mesa ocupada deve vagar pela permanência média e os grupos que chegaram antes.
+// Mesas juntadas contam como uma só, com a capacidade somada, representada pela principal.
+// Mesas reservadas ficam de fora, pois estão seguradas para as reservas.
+func (s *WaitlistService) estimate(restaurantID uuid.UUID, partySize int, ahead []models.WaitlistEntry) (*WaitQuote, error) {
+	now := time.Now()
+
+	average, err := s.averageSeatDuration(restaurantID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	tables, err := s.tableRepo.List(restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	capacities := models.GroupCapacities(tables)
+
+	var releases []time.Duration
+	free, maxCapacity, suitable := 0, 0, 0
+	for _, table := range tables {
+		capacity := capacities[table.ID]
+		if table.MergedIntoID != nil || capacity < partySize {
+			continue
+		}
+		suitable++
+		if capacity > maxCapacity {
+			maxCapacity = capacity
+		}
+
+		switch table.Status {
+		case models.TableStatusFree:
+			free++
+		case models.TableStatusOccupied:
+			// Mesas ocupadas sem pedido acabaram de receber um grupo
+			seatedAt := table.UpdatedAt
+			if table.CurrentOrderID != nil {
+				if order, err := s.orderRepo.FindByID(restaurantID, *table.CurrentOrderID); err == nil {
+					seatedAt = order.CreatedAt
+				}
+			}
+
+			remaining := average - now.Sub(seatedAt)
+			if remaining < minTableRelease {
+				remaining = minTableRelease
+			}
+			releases = append(releases, remaining)
+		}
+	}
+	if suitable == 0 {
+		return nil, fmt.Errorf("%w: no table seats a party of %d", ErrInvalidWaitlistEntry, partySize)
+	}
+
+	// Grupos na frente que cabem em alguma das mesas adequadas disputam as mesmas mesas
+	competing := 0
+	for _, entry := range ahead {
+		if entry.PartySize <= maxCapacity {
+			competing++
+		}
+	}
+
+	var wait time.Duration
+	if competing >= free {
+		position := competing - free
+		if len(releases) == 0 {
+			// Todas as mesas adequadas estão reservadas
+			wait = time.Duration(position+1) * average
+		} else {
+			sort.Slice(releases, func(i, j int) bool { return releases[i] < releases[j] })
+			rounds := position / len(releases)
+			wait = releases[position%len(releases)] + time.Duration(rounds)*average
+		}
+	}
+
+	// Arredondar para cima para não prometer menos do que o provável
+	wait = ((wait + quoteRounding - 1) / quoteRounding) * quoteRounding
+
+	return &WaitQuote{
+		PartySize:          partySize,
+		WaitMinutes:        int(wait / time.Minute),
+		PartiesAhead:       competing,
+		FreeTables:         free,
+		AverageSeatMinutes: int(average / time.Minute),
+	}, nil
+}
+
+// Quote estima a espera de um grupo que entrasse agora na fila
+func (s *WaitlistService) Quote(restaurantID uuid.UUID, partySize int) (*WaitQuote, error) {
+	if partySize <= 0 {
+		return nil, fmt.Errorf("%w: party size must be positive", ErrInvalidWaitlistEntry)
+	}
+
+	ahead, err := s.waitlistRepo.ListActive(restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	return s.estimate(restaurantID, partySize, ahead)
+}
+
+// Add coloca o grupo na fila e registra a espera informada ao cliente
+func (s *WaitlistService) Add(entry *models.WaitlistEntry) (*WaitQuote, error) {
+	entry.CustomerName = strings.TrimSpace(entry.CustomerName)
+	entry.CustomerPhone = strings.TrimSpace(entry.CustomerPhone)
+	switch {
+	case entry.CustomerName == "":
+		return nil, fmt.Errorf("%w: customer name is required", ErrInvalidWaitlistEntry)
+	case entry.CustomerPhone == "":
+		return nil, fmt.Errorf("%w: customer phone is required", ErrInvalidWaitlistEntry)
+	}
+
+	quote, err := s.Quote(entry.RestaurantID, entry.PartySize)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Status = models.WaitlistStatusWaiting
+	entry.QuotedWaitMinutes = quote.WaitMinutes
+	if err := s.waitlistRepo.Create(entry); err != nil {
+		return nil, err
+	}
+
+	s.events.Publish(entry.RestaurantID, realtime.TopicTables, EventWaitlistCreated, entry)
+	return quote, nil
+}
+
+func (s *WaitlistService) GetByID(restaurantID, id uuid.UUID) (*models.WaitlistEntry, error) {
+	return s.waitlistRepo.FindByID(restaurantID, id)
+}
+
+// ListActive retorna a fila atual na ordem de chegada
+func (s *WaitlistService) ListActive(restaurantID uuid.UUID) ([]models.WaitlistEntry, error) {
+	return s.waitlistRepo.ListActive(restaurantID)
+}
+
+// ListSince retorna todos os grupos que entraram na fila a partir de since, inclusive os já atendidos
+func (s *WaitlistService) ListSince(restaurantID uuid.UUID, since time.Time) ([]models.WaitlistEntry, error) {
+	return s.waitlistRepo.ListSince(restaurantID, since)
+}
+
+// update aplica uma mudança a um grupo que ainda está na fila e publica o novo estado
+func (s *WaitlistService) update(restaurantID, id uuid.UUID, apply func(entry *models.WaitlistEntry)) (*models.WaitlistEntry, error) {
+	var entry *models.WaitlistEntry
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		waitlistRepo := s.waitlistRepo.WithTx(tx)
+
+		var err error
+		entry, err = waitlistRepo.FindByIDForUpdate(restaurantID, id)
+		if err != nil {
+			return err
+		}
+		if !entry.Active() {
+			return fmt.Errorf("%w: party is already %s", ErrInvalidWaitlistEntry, entry.Status)
+		}
+
+		apply(entry)
+		if err := waitlistRepo.Update(entry); err != nil {
+			return err
+		}
+
+		publishAfterCommit(tx, s.events, restaurantID, realtime.TopicTables, EventWaitlistUpdated, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Notify registra que o cliente foi avisado de que a mesa está pronta; pode ser repetido
+func (s *WaitlistService) Notify(restaurantID, id uuid.UUID) (*models.WaitlistEntry, error) {
+	return s.update(restaurantID, id, func(entry *models.WaitlistEntry) {
+		now := time.Now()
+		entry.Status = models.WaitlistStatusNotified
+		entry.NotifiedAt = &now
+	})
+}
+
+// Drop tira o grupo da fila
+func (s *WaitlistService) Drop(restaurantID, id uuid.UUID) (*models.WaitlistEntry, error) {
+	return s.update(restaurantID, id, func(entry *models.WaitlistEntry) {
+		now := time.Now()
+		entry.Status = models.WaitlistStatusDropped
+		entry.DroppedAt = &now
+	})
+}
+
+// Seat acomoda o grupo na mesa escolhida. A mesa é ocupada diretamente ou, se openOrder for
+// verdadeiro, pela abertura de um pedido vazio em nome do cliente. O grupo fica bloqueado durante
+// a operação, e a mesa, o pedido e a saída da fila são gravados na mesma transação.
+func (s *WaitlistService) Seat(restaurantID, id, tableID, actorID uuid.UUID, openOrder bool) (*models.WaitlistEntry, error) {
+	var order *models.Order
+	if openOrder {
+		order = &models.Order{
+			RestaurantID: restaurantID,
+			TableID:      &tableID,
+			UserID:       actorID,
+			Type:         models.OrderTypeInHouse,
+			Status:       models.OrderStatusPending,
+		}
+		if err := s.orderService.reserveCode(order); err != nil {
+			return nil, err
+		}
+	}
+
+	var entry *models.WaitlistEntry
+	err := s.uow.Do(func(tx repositories.Transaction) error {
+		waitlistRepo := s.waitlistRepo.WithTx(tx)
+		tableRepo := s.tableRepo.WithTx(tx)
+
+		var err error
+		entry, err = waitlistRepo.FindByIDForUpdate(restaurantID, id)
+		if err != nil {
+			return err
+		}
+		if !entry.Active() {
+			return fmt.Errorf("%w: party is already %s", ErrInvalidWaitlistEntry, entry.Status)
+		}
+
+		// Mesas juntadas acomodam o grupo com a capacidade somada; a principal vem primeiro
+		group, err := tableRepo.FindGroupForUpdate(restaurantID, tableID)
+		if err != nil {
+			return err
+		}
+		table := &group[0]
+		if capacity := models.GroupCapacities(group)[table.ID]; capacity < entry.PartySize {
+			return fmt.Errorf("%w: table %d seats %d but party has %d people", ErrInvalidWaitlistEntry,
+				table.Number, capacity, entry.PartySize)
+		}
+
+		if order != nil {
+			order.CustomerName = entry.CustomerName
+			order.CustomerPhone = entry.CustomerPhone
+			if err := s.orderService.createOrder(tx, order, nil); err != nil {
+				return err
+			}
+			entry.OrderID = &order.ID
+		} else {
+			if table.Status == models.TableStatusOccupied {
+				return ErrTableNotAvailable
+			}
+			if err := tableRepo.Occupy(restaurantID, tableID, nil); err != nil {
+				return err
+			}
+			if err := publishTableGroup(tx, tableRepo, s.events, restaurantID, tableID); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		entry.Status = models.WaitlistStatusSeated
+		entry.SeatedAt = &now
+		entry.TableID = &tableID
+		if err := waitlistRepo.Update(entry); err != nil {
+			return err
+		}
+
+		publishAfterCommit(tx, s.events, restaurantID, realtime.TopicTables, EventWaitlistUpdated, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS financial_transactions;
+DROP TABLE IF EXISTS order_items;
+DROP TABLE IF EXISTS orders;
+DROP TABLE IF EXISTS options;
+DROP TABLE IF EXISTS addons;
+DROP TABLE IF EXISTS products;
+DROP TABLE IF EXISTS product_categories;
+DROP TABLE IF EXISTS tables;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS restaurants;
@@ -0,0 +1,135 @@
+-- Esquema inicial, equivalente ao que o AutoMigrate criava.
+-- Usa IF NOT EXISTS para que bancos criados pelo AutoMigrate adotem o versionamento sem erro.
+
+CREATE TABLE IF NOT EXISTS restaurants (
+    id                UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name              VARCHAR(100) NOT NULL,
+    description       VARCHAR(255),
+    address           VARCHAR(255),
+    phone             VARCHAR(20),
+    email             VARCHAR(100),
+    logo              VARCHAR(255),
+    subscription_plan VARCHAR(50),
+    status            VARCHAR(20) NOT NULL DEFAULT 'trial',
+    trial_ends_at     TIMESTAMPTZ,
+    created_at        TIMESTAMPTZ,
+    updated_at        TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name          VARCHAR(100) NOT NULL,
+    email         VARCHAR(100) NOT NULL,
+    password      VARCHAR(100) NOT NULL,
+    type          VARCHAR(20) NOT NULL DEFAULT 'staff',
+    restaurant_id UUID REFERENCES restaurants (id),
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+
+CREATE TABLE IF NOT EXISTS tables (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id    UUID NOT NULL REFERENCES restaurants (id),
+    number           BIGINT NOT NULL,
+    capacity         BIGINT NOT NULL,
+    status           VARCHAR(20) NOT NULL DEFAULT 'free',
+    current_order_id UUID,
+    created_at       TIMESTAMPTZ,
+    updated_at       TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS product_categories (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants (id),
+    name          VARCHAR(100) NOT NULL,
+    description   VARCHAR(255),
+    active        BOOLEAN DEFAULT TRUE,
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS products (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants (id),
+    name          VARCHAR(100) NOT NULL,
+    description   VARCHAR(255),
+    price         DECIMAL NOT NULL,
+    category_id   UUID NOT NULL REFERENCES product_categories (id),
+    type          VARCHAR(20),
+    in_stock      BOOLEAN DEFAULT TRUE,
+    image_url     VARCHAR(255),
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS addons (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title          VARCHAR(100) NOT NULL,
+    product_id     UUID NOT NULL REFERENCES products (id),
+    selection_type VARCHAR(30) NOT NULL,
+    min_selections BIGINT DEFAULT 0,
+    max_selections BIGINT DEFAULT 1,
+    required       BOOLEAN DEFAULT FALSE,
+    price_method   VARCHAR(20) NOT NULL,
+    created_at     TIMESTAMPTZ,
+    updated_at     TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS options (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    addon_id     UUID NOT NULL REFERENCES addons (id),
+    name         VARCHAR(100) NOT NULL,
+    price        DECIMAL NOT NULL,
+    active       BOOLEAN DEFAULT TRUE,
+    max_quantity BIGINT DEFAULT 1,
+    created_at   TIMESTAMPTZ,
+    updated_at   TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS orders (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id    UUID NOT NULL REFERENCES restaurants (id),
+    table_id         UUID REFERENCES tables (id),
+    user_id          UUID NOT NULL REFERENCES users (id),
+    code             VARCHAR(20),
+    customer_name    VARCHAR(100),
+    customer_phone   VARCHAR(20),
+    customer_email   VARCHAR(100),
+    type             VARCHAR(20) NOT NULL DEFAULT 'in_house',
+    status           VARCHAR(20) NOT NULL DEFAULT 'pending',
+    total_amount     DECIMAL NOT NULL DEFAULT 0,
+    notes            VARCHAR(255),
+    delivery_address VARCHAR(255),
+    created_at       TIMESTAMPTZ,
+    updated_at       TIMESTAMPTZ,
+    paid_at          TIMESTAMPTZ,
+    delivered_at     TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS order_items (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id   UUID NOT NULL REFERENCES orders (id),
+    product_id UUID NOT NULL REFERENCES products (id),
+    quantity   BIGINT NOT NULL DEFAULT 1,
+    price      DECIMAL NOT NULL,
+    notes      VARCHAR(255),
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS financial_transactions (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id  UUID NOT NULL REFERENCES restaurants (id),
+    type           VARCHAR(20) NOT NULL,
+    category       VARCHAR(30) NOT NULL,
+    amount         DECIMAL NOT NULL,
+    description    VARCHAR(255),
+    order_id       UUID REFERENCES orders (id),
+    user_id        UUID NOT NULL REFERENCES users (id),
+    payment_method VARCHAR(30),
+    date           TIMESTAMPTZ NOT NULL,
+    created_at     TIMESTAMPTZ,
+    updated_at     TIMESTAMPTZ
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS order_status_transitions;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS cancelled_at;
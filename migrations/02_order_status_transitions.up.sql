@@ -0,0 +1,14 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS order_status_transitions (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id UUID NOT NULL,
+    order_id      UUID NOT NULL REFERENCES orders (id),
+    from_status   VARCHAR(20),
+    to_status     VARCHAR(20) NOT NULL,
+    user_id       UUID NOT NULL REFERENCES users (id),
+    created_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_transitions_restaurant_id ON order_status_transitions (restaurant_id);
+CREATE INDEX IF NOT EXISTS idx_order_status_transitions_order_id ON order_status_transitions (order_id);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS order_item_options (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_item_id UUID NOT NULL REFERENCES order_items (id),
+    addon_id      UUID NOT NULL,
+    option_id     UUID NOT NULL,
+    addon_title   VARCHAR(100),
+    name          VARCHAR(100) NOT NULL,
+    price         DECIMAL NOT NULL,
+    quantity      BIGINT NOT NULL DEFAULT 1,
+    created_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_item_options_order_item_id ON order_item_options (order_item_id);
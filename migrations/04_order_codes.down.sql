@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS order_code_sequences;
+DROP TABLE IF EXISTS order_code_settings;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS order_code_settings (
+    restaurant_id UUID PRIMARY KEY,
+    prefix        VARCHAR(10) NOT NULL,
+    reset         VARCHAR(20) NOT NULL DEFAULT 'daily',
+    padding       BIGINT NOT NULL DEFAULT 3,
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS order_code_sequences (
+    restaurant_id UUID NOT NULL,
+    period        VARCHAR(10) NOT NULL,
+    last_value    BIGINT NOT NULL DEFAULT 0,
+    updated_at    TIMESTAMPTZ,
+    PRIMARY KEY (restaurant_id, period)
+);
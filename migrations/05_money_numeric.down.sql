@@ -0,0 +1,6 @@
+ALTER TABLE financial_transactions ALTER COLUMN amount TYPE DECIMAL;
+ALTER TABLE order_item_options ALTER COLUMN price TYPE DECIMAL;
+ALTER TABLE order_items ALTER COLUMN price TYPE DECIMAL;
+ALTER TABLE orders ALTER COLUMN total_amount TYPE DECIMAL;
+ALTER TABLE options ALTER COLUMN price TYPE DECIMAL;
+ALTER TABLE products ALTER COLUMN price TYPE DECIMAL;
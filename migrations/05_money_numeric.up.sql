@@ -0,0 +1,8 @@
+-- Valores monetários passam a ter exatamente duas casas decimais.
+-- A conversão arredonda para o centavo mais próximo, com empates para longe do zero.
+ALTER TABLE products ALTER COLUMN price TYPE NUMERIC(12,2) USING price::NUMERIC(12,2);
+ALTER TABLE options ALTER COLUMN price TYPE NUMERIC(12,2) USING price::NUMERIC(12,2);
+ALTER TABLE orders ALTER COLUMN total_amount TYPE NUMERIC(12,2) USING total_amount::NUMERIC(12,2);
+ALTER TABLE order_items ALTER COLUMN price TYPE NUMERIC(12,2) USING price::NUMERIC(12,2);
+ALTER TABLE order_item_options ALTER COLUMN price TYPE NUMERIC(12,2) USING price::NUMERIC(12,2);
+ALTER TABLE financial_transactions ALTER COLUMN amount TYPE NUMERIC(12,2) USING amount::NUMERIC(12,2);
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_financial_transactions_order_id;
+DROP INDEX IF EXISTS idx_financial_transactions_restaurant_date;
+DROP INDEX IF EXISTS idx_order_items_order_id;
+DROP INDEX IF EXISTS idx_orders_table_id;
+DROP INDEX IF EXISTS idx_orders_restaurant_created_at;
+DROP INDEX IF EXISTS idx_orders_restaurant_status;
+DROP INDEX IF EXISTS idx_options_addon_id;
+DROP INDEX IF EXISTS idx_addons_product_id;
+DROP INDEX IF EXISTS idx_products_restaurant_category;
+DROP INDEX IF EXISTS idx_product_categories_restaurant_id;
+DROP INDEX IF EXISTS idx_users_restaurant_id;
+DROP INDEX IF EXISTS idx_tables_restaurant_number;
@@ -0,0 +1,14 @@
+-- O número da mesa é único dentro do restaurante
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tables_restaurant_number ON tables (restaurant_id, number);
+
+CREATE INDEX IF NOT EXISTS idx_users_restaurant_id ON users (restaurant_id);
+CREATE INDEX IF NOT EXISTS idx_product_categories_restaurant_id ON product_categories (restaurant_id);
+CREATE INDEX IF NOT EXISTS idx_products_restaurant_category ON products (restaurant_id, category_id);
+CREATE INDEX IF NOT EXISTS idx_addons_product_id ON addons (product_id);
+CREATE INDEX IF NOT EXISTS idx_options_addon_id ON options (addon_id);
+CREATE INDEX IF NOT EXISTS idx_orders_restaurant_status ON orders (restaurant_id, status);
+CREATE INDEX IF NOT EXISTS idx_orders_restaurant_created_at ON orders (restaurant_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_orders_table_id ON orders (table_id);
+CREATE INDEX IF NOT EXISTS idx_order_items_order_id ON order_items (order_id);
+CREATE INDEX IF NOT EXISTS idx_financial_transactions_restaurant_date ON financial_transactions (restaurant_id, date);
+CREATE INDEX IF NOT EXISTS idx_financial_transactions_order_id ON financial_transactions (order_id);
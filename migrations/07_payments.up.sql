@@ -0,0 +1,30 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS amount_paid NUMERIC(12,2) NOT NULL DEFAULT 0;
+
+-- Pedidos já pagos antes dos pagamentos parciais são considerados quitados
+UPDATE orders SET amount_paid = total_amount WHERE status = 'paid';
+
+CREATE TABLE IF NOT EXISTS payments (
+    id                       UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id            UUID NOT NULL REFERENCES restaurants (id),
+    order_id                 UUID NOT NULL REFERENCES orders (id),
+    method                   VARCHAR(30) NOT NULL,
+    amount                   NUMERIC(12,2) NOT NULL,
+    tendered                 NUMERIC(12,2) NOT NULL,
+    change_given             NUMERIC(12,2) NOT NULL,
+    payer_label              VARCHAR(100),
+    user_id                  UUID NOT NULL REFERENCES users (id),
+    financial_transaction_id UUID REFERENCES financial_transactions (id),
+    created_at               TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_payments_order_id ON payments (order_id);
+
+CREATE TABLE IF NOT EXISTS payment_items (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    payment_id    UUID NOT NULL REFERENCES payments (id),
+    order_item_id UUID NOT NULL,
+    quantity      BIGINT NOT NULL,
+    amount        NUMERIC(12,2) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_items_payment_id ON payment_items (payment_id);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS kitchen_tickets;
+
+ALTER TABLE product_categories DROP COLUMN IF EXISTS station_id;
+ALTER TABLE products DROP COLUMN IF EXISTS station_id;
+
+DROP TABLE IF EXISTS kitchen_stations;
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS kitchen_stations (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants (id),
+    name          VARCHAR(100) NOT NULL,
+    active        BOOLEAN DEFAULT TRUE,
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_kitchen_stations_restaurant_id ON kitchen_stations (restaurant_id);
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS station_id UUID REFERENCES kitchen_stations (id);
+ALTER TABLE product_categories ADD COLUMN IF NOT EXISTS station_id UUID REFERENCES kitchen_stations (id);
+
+CREATE TABLE IF NOT EXISTS kitchen_tickets (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants (id),
+    station_id    UUID REFERENCES kitchen_stations (id),
+    order_id      UUID NOT NULL REFERENCES orders (id),
+    order_item_id UUID NOT NULL,
+    order_code    VARCHAR(20),
+    order_type    VARCHAR(20),
+    product_name  VARCHAR(100) NOT NULL,
+    quantity      BIGINT NOT NULL,
+    options       VARCHAR(500),
+    notes         VARCHAR(255),
+    status        VARCHAR(20) NOT NULL DEFAULT 'queued',
+    started_at    TIMESTAMPTZ,
+    done_at       TIMESTAMPTZ,
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_kitchen_tickets_order_item_id ON kitchen_tickets (order_item_id);
+CREATE INDEX IF NOT EXISTS idx_kitchen_tickets_order_id ON kitchen_tickets (order_id);
+CREATE INDEX IF NOT EXISTS idx_kitchen_tickets_station_status ON kitchen_tickets (restaurant_id, station_id, status);
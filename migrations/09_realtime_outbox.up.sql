@@ -0,0 +1,8 @@
+-- Eventos em tempo real maiores que o limite do payload do NOTIFY
+CREATE TABLE IF NOT EXISTS realtime_outbox (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    payload    JSONB NOT NULL,
+    created_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_realtime_outbox_created_at ON realtime_outbox (created_at);
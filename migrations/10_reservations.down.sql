@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS reservation_tables;
+DROP TABLE IF EXISTS reservations;
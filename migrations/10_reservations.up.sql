@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS reservations (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id    UUID NOT NULL REFERENCES restaurants (id),
+    customer_name    VARCHAR(100) NOT NULL,
+    customer_phone   VARCHAR(20) NOT NULL,
+    party_size       BIGINT NOT NULL,
+    starts_at        TIMESTAMPTZ NOT NULL,
+    duration_minutes BIGINT NOT NULL,
+    ends_at          TIMESTAMPTZ NOT NULL,
+    status           VARCHAR(20) NOT NULL DEFAULT 'booked',
+    notes            VARCHAR(255),
+    user_id          UUID NOT NULL REFERENCES users (id),
+    seated_at        TIMESTAMPTZ,
+    cancelled_at     TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ,
+    updated_at       TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_reservations_restaurant_starts_at ON reservations (restaurant_id, starts_at);
+CREATE INDEX IF NOT EXISTS idx_reservations_status_starts_at ON reservations (status, starts_at);
+
+CREATE TABLE IF NOT EXISTS reservation_tables (
+    reservation_id UUID NOT NULL REFERENCES reservations (id) ON DELETE CASCADE,
+    table_id       UUID NOT NULL REFERENCES tables (id),
+    PRIMARY KEY (reservation_id, table_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reservation_tables_table_id ON reservation_tables (table_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_orders_restaurant_paid_at;
+DROP TABLE IF EXISTS waitlist_entries;
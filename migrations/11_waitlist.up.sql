@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id       UUID NOT NULL REFERENCES restaurants (id),
+    customer_name       VARCHAR(100) NOT NULL,
+    customer_phone      VARCHAR(20) NOT NULL,
+    party_size          BIGINT NOT NULL,
+    quoted_wait_minutes BIGINT NOT NULL DEFAULT 0,
+    status              VARCHAR(20) NOT NULL DEFAULT 'waiting',
+    notes               VARCHAR(255),
+    table_id            UUID REFERENCES tables (id),
+    order_id            UUID REFERENCES orders (id),
+    user_id             UUID NOT NULL REFERENCES users (id),
+    notified_at         TIMESTAMPTZ,
+    seated_at           TIMESTAMPTZ,
+    dropped_at          TIMESTAMPTZ,
+    created_at          TIMESTAMPTZ,
+    updated_at          TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_restaurant_status ON waitlist_entries (restaurant_id, status, created_at);
+
+-- Usado no cálculo do tempo médio de permanência nas mesas
+CREATE INDEX IF NOT EXISTS idx_orders_restaurant_paid_at ON orders (restaurant_id, paid_at) WHERE table_id IS NOT NULL;
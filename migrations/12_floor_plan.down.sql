@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_tables_merged_into_id;
+
+ALTER TABLE tables DROP COLUMN IF EXISTS merged_into_id;
+ALTER TABLE tables DROP COLUMN IF EXISTS rotation;
+ALTER TABLE tables DROP COLUMN IF EXISTS height;
+ALTER TABLE tables DROP COLUMN IF EXISTS width;
+ALTER TABLE tables DROP COLUMN IF EXISTS pos_y;
+ALTER TABLE tables DROP COLUMN IF EXISTS pos_x;
+ALTER TABLE tables DROP COLUMN IF EXISTS shape;
+ALTER TABLE tables DROP COLUMN IF EXISTS area_id;
+
+DROP TABLE IF EXISTS dining_areas;
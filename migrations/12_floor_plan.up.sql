@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS dining_areas (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants (id),
+    name          VARCHAR(100) NOT NULL,
+    sort_order    BIGINT NOT NULL DEFAULT 0,
+    active        BOOLEAN DEFAULT TRUE,
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_dining_areas_restaurant_id ON dining_areas (restaurant_id);
+
+ALTER TABLE tables ADD COLUMN IF NOT EXISTS area_id UUID REFERENCES dining_areas (id) ON DELETE SET NULL;
+ALTER TABLE tables ADD COLUMN IF NOT EXISTS shape VARCHAR(20) NOT NULL DEFAULT 'square';
+ALTER TABLE tables ADD COLUMN IF NOT EXISTS pos_x DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE tables ADD COLUMN IF NOT EXISTS pos_y DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE tables ADD COLUMN IF NOT EXISTS width DOUBLE PRECISION NOT NULL DEFAULT 1;
+ALTER TABLE tables ADD COLUMN IF NOT EXISTS height DOUBLE PRECISION NOT NULL DEFAULT 1;
+ALTER TABLE tables ADD COLUMN IF NOT EXISTS rotation DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE tables ADD COLUMN IF NOT EXISTS merged_into_id UUID REFERENCES tables (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tables_merged_into_id ON tables (merged_into_id) WHERE merged_into_id IS NOT NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS stock_deducted_at;
+
+DROP TABLE IF EXISTS stock_movements;
+DROP TABLE IF EXISTS recipe_items;
+DROP TABLE IF EXISTS ingredients;
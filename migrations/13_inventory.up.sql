@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS ingredients (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants (id),
+    name          VARCHAR(100) NOT NULL,
+    unit          VARCHAR(10) NOT NULL,
+    on_hand       NUMERIC(14,3) NOT NULL DEFAULT 0,
+    minimum_stock NUMERIC(14,3) NOT NULL DEFAULT 0,
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_ingredients_restaurant_id ON ingredients (restaurant_id);
+
+-- Cada linha pertence a um produto ou a uma opção de complemento e some junto com ele
+CREATE TABLE IF NOT EXISTS recipe_items (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants (id),
+    product_id    UUID REFERENCES products (id) ON DELETE CASCADE,
+    option_id     UUID REFERENCES options (id) ON DELETE CASCADE,
+    ingredient_id UUID NOT NULL REFERENCES ingredients (id),
+    quantity      NUMERIC(14,3) NOT NULL,
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ,
+    CHECK ((product_id IS NULL) <> (option_id IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_recipe_items_product_ingredient ON recipe_items (product_id, ingredient_id) WHERE product_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_recipe_items_option_ingredient ON recipe_items (option_id, ingredient_id) WHERE option_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_recipe_items_ingredient_id ON recipe_items (ingredient_id);
+
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants (id),
+    ingredient_id UUID NOT NULL REFERENCES ingredients (id),
+    type          VARCHAR(20) NOT NULL,
+    quantity      NUMERIC(14,3) NOT NULL,
+    order_id      UUID REFERENCES orders (id),
+    user_id       UUID REFERENCES users (id),
+    notes         VARCHAR(255),
+    created_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_ingredient ON stock_movements (restaurant_id, ingredient_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_order_id ON stock_movements (order_id) WHERE order_id IS NOT NULL;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS stock_deducted_at TIMESTAMPTZ;
@@ -0,0 +1,8 @@
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS purchase_order_id;
+
+DROP INDEX IF EXISTS idx_financial_transactions_purchase_order_id;
+ALTER TABLE financial_transactions DROP COLUMN IF EXISTS purchase_order_id;
+
+DROP TABLE IF EXISTS purchase_order_items;
+DROP TABLE IF EXISTS purchase_orders;
+DROP TABLE IF EXISTS suppliers;
@@ -0,0 +1,53 @@
+CREATE TABLE IF NOT EXISTS suppliers (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants (id),
+    name          VARCHAR(100) NOT NULL,
+    document      VARCHAR(20),
+    contact_name  VARCHAR(100),
+    phone         VARCHAR(20),
+    email         VARCHAR(100),
+    notes         VARCHAR(255),
+    active        BOOLEAN DEFAULT TRUE,
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_suppliers_restaurant_id ON suppliers (restaurant_id);
+
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id  UUID NOT NULL REFERENCES restaurants (id),
+    supplier_id    UUID NOT NULL REFERENCES suppliers (id),
+    status         VARCHAR(20) NOT NULL DEFAULT 'draft',
+    notes          VARCHAR(255),
+    total          NUMERIC(12,2) NOT NULL DEFAULT 0,
+    received_total NUMERIC(12,2) NOT NULL DEFAULT 0,
+    expected_at    TIMESTAMPTZ,
+    user_id        UUID NOT NULL REFERENCES users (id),
+    ordered_at     TIMESTAMPTZ,
+    received_at    TIMESTAMPTZ,
+    cancelled_at   TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ,
+    updated_at     TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_restaurant_status ON purchase_orders (restaurant_id, status, created_at);
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_supplier_id ON purchase_orders (supplier_id);
+
+CREATE TABLE IF NOT EXISTS purchase_order_items (
+    id                UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    purchase_order_id UUID NOT NULL REFERENCES purchase_orders (id) ON DELETE CASCADE,
+    ingredient_id     UUID NOT NULL REFERENCES ingredients (id),
+    quantity          NUMERIC(14,3) NOT NULL,
+    quantity_received NUMERIC(14,3) NOT NULL DEFAULT 0,
+    unit_cost         NUMERIC(12,2) NOT NULL,
+    created_at        TIMESTAMPTZ,
+    updated_at        TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_purchase_order_items_order_id ON purchase_order_items (purchase_order_id);
+
+ALTER TABLE financial_transactions ADD COLUMN IF NOT EXISTS purchase_order_id UUID REFERENCES purchase_orders (id);
+CREATE INDEX IF NOT EXISTS idx_financial_transactions_purchase_order_id ON financial_transactions (purchase_order_id) WHERE purchase_order_id IS NOT NULL;
+
+ALTER TABLE stock_movements ADD COLUMN IF NOT EXISTS purchase_order_id UUID REFERENCES purchase_orders (id);
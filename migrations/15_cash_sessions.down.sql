@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_payments_cash_session_id;
+ALTER TABLE payments DROP COLUMN IF EXISTS cash_session_id;
+
+DROP TABLE IF EXISTS cash_session_counts;
+DROP TABLE IF EXISTS cash_movements;
+DROP TABLE IF EXISTS cash_sessions;
@@ -0,0 +1,48 @@
+CREATE TABLE IF NOT EXISTS cash_sessions (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id  UUID NOT NULL REFERENCES restaurants (id),
+    register       VARCHAR(50) NOT NULL,
+    status         VARCHAR(20) NOT NULL DEFAULT 'open',
+    opening_float  NUMERIC(12,2) NOT NULL DEFAULT 0,
+    expected_total NUMERIC(12,2) NOT NULL DEFAULT 0,
+    counted_total  NUMERIC(12,2) NOT NULL DEFAULT 0,
+    difference     NUMERIC(12,2) NOT NULL DEFAULT 0,
+    notes          VARCHAR(255),
+    opened_by      UUID NOT NULL REFERENCES users (id),
+    opened_at      TIMESTAMPTZ NOT NULL,
+    closed_by      UUID REFERENCES users (id),
+    closed_at      TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ,
+    updated_at     TIMESTAMPTZ
+);
+
+-- Cada caixa tem no máximo um turno aberto
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cash_sessions_open_register ON cash_sessions (restaurant_id, register) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_cash_sessions_restaurant_opened_at ON cash_sessions (restaurant_id, opened_at);
+
+CREATE TABLE IF NOT EXISTS cash_movements (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants (id),
+    session_id    UUID NOT NULL REFERENCES cash_sessions (id),
+    type          VARCHAR(20) NOT NULL,
+    amount        NUMERIC(12,2) NOT NULL,
+    reason        VARCHAR(255),
+    user_id       UUID NOT NULL REFERENCES users (id),
+    created_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_cash_movements_session_id ON cash_movements (session_id);
+
+CREATE TABLE IF NOT EXISTS cash_session_counts (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_id UUID NOT NULL REFERENCES cash_sessions (id),
+    method     VARCHAR(30) NOT NULL,
+    expected   NUMERIC(12,2) NOT NULL,
+    counted    NUMERIC(12,2) NOT NULL,
+    difference NUMERIC(12,2) NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cash_session_counts_session_method ON cash_session_counts (session_id, method);
+
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS cash_session_id UUID REFERENCES cash_sessions (id);
+CREATE INDEX IF NOT EXISTS idx_payments_cash_session_id ON payments (cash_session_id) WHERE cash_session_id IS NOT NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_financial_transactions_recurring_occurrence;
+ALTER TABLE financial_transactions DROP COLUMN IF EXISTS occurrence_date;
+ALTER TABLE financial_transactions DROP COLUMN IF EXISTS recurring_id;
+
+DROP TABLE IF EXISTS recurring_transactions;
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS recurring_transactions (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id   UUID NOT NULL REFERENCES restaurants (id),
+    type            VARCHAR(20) NOT NULL,
+    category        VARCHAR(30) NOT NULL,
+    amount          NUMERIC(12,2) NOT NULL,
+    description     VARCHAR(255),
+    payment_method  VARCHAR(30),
+    frequency       VARCHAR(20) NOT NULL,
+    interval        INTEGER NOT NULL DEFAULT 1,
+    day_of_month    INTEGER NOT NULL DEFAULT 0,
+    weekday         INTEGER NOT NULL DEFAULT 0,
+    start_date      DATE NOT NULL,
+    end_date        DATE,
+    next_date       DATE,
+    last_occurrence DATE,
+    active          BOOLEAN NOT NULL DEFAULT TRUE,
+    user_id         UUID NOT NULL REFERENCES users (id),
+    created_at      TIMESTAMPTZ,
+    updated_at      TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_recurring_transactions_restaurant_id ON recurring_transactions (restaurant_id);
+-- Consultado pelo agendador a cada rodada
+CREATE INDEX IF NOT EXISTS idx_recurring_transactions_due ON recurring_transactions (next_date) WHERE active;
+
+ALTER TABLE financial_transactions ADD COLUMN IF NOT EXISTS recurring_id UUID REFERENCES recurring_transactions (id) ON DELETE SET NULL;
+ALTER TABLE financial_transactions ADD COLUMN IF NOT EXISTS occurrence_date DATE;
+
+-- Cada ocorrência de um lançamento recorrente gera no máximo uma transação
+CREATE UNIQUE INDEX IF NOT EXISTS idx_financial_transactions_recurring_occurrence ON financial_transactions (recurring_id, occurrence_date) WHERE recurring_id IS NOT NULL;
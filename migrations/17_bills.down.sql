@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bill_attachments;
+DROP TABLE IF EXISTS bill_settlements;
+DROP TABLE IF EXISTS bills;
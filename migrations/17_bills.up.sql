@@ -0,0 +1,51 @@
+CREATE TABLE IF NOT EXISTS bills (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants (id),
+    kind          VARCHAR(20) NOT NULL,
+    status        VARCHAR(20) NOT NULL DEFAULT 'open',
+    category      VARCHAR(30) NOT NULL,
+    description   VARCHAR(255) NOT NULL,
+    counterparty  VARCHAR(100),
+    supplier_id   UUID REFERENCES suppliers (id),
+    document      VARCHAR(100),
+    amount        NUMERIC(12,2) NOT NULL,
+    paid_amount   NUMERIC(12,2) NOT NULL DEFAULT 0,
+    due_date      DATE NOT NULL,
+    notes         TEXT,
+    user_id       UUID NOT NULL REFERENCES users (id),
+    paid_at       TIMESTAMPTZ,
+    cancelled_at  TIMESTAMPTZ,
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_bills_restaurant_due_date ON bills (restaurant_id, due_date);
+CREATE INDEX IF NOT EXISTS idx_bills_supplier_id ON bills (supplier_id) WHERE supplier_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS bill_settlements (
+    id                       UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id            UUID NOT NULL REFERENCES restaurants (id),
+    bill_id                  UUID NOT NULL REFERENCES bills (id),
+    amount                   NUMERIC(12,2) NOT NULL,
+    payment_method           VARCHAR(30),
+    paid_at                  TIMESTAMPTZ NOT NULL,
+    notes                    VARCHAR(255),
+    financial_transaction_id UUID REFERENCES financial_transactions (id) ON DELETE SET NULL,
+    user_id                  UUID NOT NULL REFERENCES users (id),
+    created_at               TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_bill_settlements_bill_id ON bill_settlements (bill_id);
+
+CREATE TABLE IF NOT EXISTS bill_attachments (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    bill_id      UUID NOT NULL REFERENCES bills (id) ON DELETE CASCADE,
+    file_name    VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100),
+    size         BIGINT NOT NULL DEFAULT 0,
+    url          VARCHAR(1024) NOT NULL,
+    user_id      UUID NOT NULL REFERENCES users (id),
+    created_at   TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_bill_attachments_bill_id ON bill_attachments (bill_id);
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    family_id  UUID NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    access_jti UUID NOT NULL,
+    user_agent VARCHAR(255),
+    ip         VARCHAR(45),
+    expires_at TIMESTAMPTZ NOT NULL,
+    rotated_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refresh_tokens_token_hash ON refresh_tokens (token_hash);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_expires_at ON refresh_tokens (expires_at);
+
+-- Tokens de acesso revogados antes de expirar, consultados pelo AuthMiddleware a cada requisição
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+    jti        UUID PRIMARY KEY,
+    user_id    UUID NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires_at ON revoked_tokens (expires_at);
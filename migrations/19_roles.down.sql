@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN IF EXISTS role_id;
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS roles;
@@ -0,0 +1,42 @@
+-- Papéis de cada restaurante; os papéis padrão têm user_type e valem para os usuários desse tipo sem papel próprio
+CREATE TABLE IF NOT EXISTS roles (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants (id) ON DELETE CASCADE,
+    name          VARCHAR(50) NOT NULL,
+    description   VARCHAR(255),
+    user_type     VARCHAR(20),
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_roles_restaurant_id ON roles (restaurant_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_roles_restaurant_user_type ON roles (restaurant_id, user_type) WHERE user_type IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id    UUID NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+    permission VARCHAR(50) NOT NULL,
+    PRIMARY KEY (role_id, permission)
+);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role_id UUID REFERENCES roles (id) ON DELETE SET NULL;
+
+-- Papéis padrão dos restaurantes existentes, com as mesmas permissões que cada tipo tinha até aqui
+INSERT INTO roles (restaurant_id, name, user_type, created_at, updated_at)
+SELECT r.id, d.name, d.user_type, NOW(), NOW()
+FROM restaurants r
+CROSS JOIN (VALUES ('Admin', 'admin'), ('Manager', 'manager'), ('Staff', 'staff')) AS d (name, user_type)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT ro.id, p.permission
+FROM roles ro
+CROSS JOIN (VALUES
+    ('users.write'), ('roles.write'), ('orders.cancel'), ('payments.void'), ('tables.write'), ('tables.delete'),
+    ('floor_plan.write'), ('products.write'), ('products.delete'), ('kitchen.write'), ('inventory.write'),
+    ('purchasing.read'), ('purchasing.write'), ('finance.read'), ('finance.write'), ('finance.delete'),
+    ('settings.write')
+) AS p (permission)
+WHERE ro.user_type = 'admin'
+   OR (ro.user_type = 'manager' AND p.permission NOT IN ('roles.write', 'tables.delete', 'products.delete', 'finance.delete'))
+   OR (ro.user_type = 'staff' AND p.permission = 'orders.cancel')
+ON CONFLICT DO NOTHING;
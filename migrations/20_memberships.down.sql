@@ -0,0 +1,10 @@
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS restaurant_id;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role_id UUID REFERENCES roles (id) ON DELETE SET NULL;
+
+UPDATE users u
+SET role_id = m.role_id
+FROM memberships m
+WHERE m.user_id = u.id AND m.restaurant_id = u.restaurant_id;
+
+DROP TABLE IF EXISTS memberships;
@@ -0,0 +1,30 @@
+-- Vínculos dos usuários com os restaurantes, cada um com o tipo e o papel do usuário ali
+CREATE TABLE IF NOT EXISTS memberships (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id       UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    restaurant_id UUID NOT NULL REFERENCES restaurants (id) ON DELETE CASCADE,
+    type          VARCHAR(20) NOT NULL DEFAULT 'staff',
+    role_id       UUID REFERENCES roles (id) ON DELETE SET NULL,
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_memberships_user_restaurant ON memberships (user_id, restaurant_id);
+CREATE INDEX IF NOT EXISTS idx_memberships_restaurant_id ON memberships (restaurant_id);
+
+-- Cada usuário passa a pertencer ao restaurante que tinha, com o mesmo tipo e papel
+INSERT INTO memberships (user_id, restaurant_id, type, role_id, created_at, updated_at)
+SELECT id, restaurant_id, type, role_id, NOW(), NOW()
+FROM users
+WHERE restaurant_id IS NOT NULL AND type <> 'superadmin'
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE users DROP COLUMN IF EXISTS role_id;
+
+-- Restaurante ativo de cada sessão, mantido nas renovações
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS restaurant_id UUID;
+
+UPDATE refresh_tokens rt
+SET restaurant_id = u.restaurant_id
+FROM users u
+WHERE rt.user_id = u.id AND rt.restaurant_id IS NULL;
@@ -0,0 +1,7 @@
+DELETE FROM role_permissions WHERE permission = 'devices.write';
+
+DROP TABLE IF EXISTS devices;
+
+ALTER TABLE users DROP COLUMN IF EXISTS pin_locked_until;
+ALTER TABLE users DROP COLUMN IF EXISTS pin_failed_attempts;
+ALTER TABLE users DROP COLUMN IF EXISTS pin;
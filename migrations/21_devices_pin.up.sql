@@ -0,0 +1,30 @@
+-- PIN dos usuários para entrar nos terminais, com o controle das tentativas erradas
+ALTER TABLE users ADD COLUMN IF NOT EXISTS pin VARCHAR(100);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS pin_failed_attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS pin_locked_until TIMESTAMPTZ;
+
+-- Terminais compartilhados de cada restaurante; o terminal se identifica pelo hash do seu token
+CREATE TABLE IF NOT EXISTS devices (
+    id                UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id     UUID NOT NULL REFERENCES restaurants (id) ON DELETE CASCADE,
+    name              VARCHAR(100) NOT NULL,
+    token_hash        VARCHAR(64) NOT NULL,
+    auto_lock_minutes INTEGER NOT NULL DEFAULT 5,
+    active_user_id    UUID REFERENCES users (id) ON DELETE SET NULL,
+    session_jti       UUID,
+    last_activity_at  TIMESTAMPTZ,
+    failed_attempts   INTEGER NOT NULL DEFAULT 0,
+    locked_until      TIMESTAMPTZ,
+    created_at        TIMESTAMPTZ,
+    updated_at        TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_devices_token_hash ON devices (token_hash);
+CREATE INDEX IF NOT EXISTS idx_devices_restaurant_id ON devices (restaurant_id);
+
+-- Os papéis padrão de admin e gerente passam a cadastrar terminais
+INSERT INTO role_permissions (role_id, permission)
+SELECT id, 'devices.write'
+FROM roles
+WHERE user_type IN ('admin', 'manager')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS idx_devices_pairing_code_hash;
+
+ALTER TABLE devices DROP COLUMN IF EXISTS last_seen_ip;
+ALTER TABLE devices DROP COLUMN IF EXISTS last_seen_at;
+ALTER TABLE devices DROP COLUMN IF EXISTS registered_by_id;
+ALTER TABLE devices DROP COLUMN IF EXISTS revoked_at;
+ALTER TABLE devices DROP COLUMN IF EXISTS paired_at;
+ALTER TABLE devices DROP COLUMN IF EXISTS pairing_expires_at;
+ALTER TABLE devices DROP COLUMN IF EXISTS pairing_code_hash;
+
+-- Terminais sem token (aguardando pareamento ou revogados) não existiam antes desta migração
+DELETE FROM devices WHERE token_hash IS NULL;
+ALTER TABLE devices ALTER COLUMN token_hash SET NOT NULL;
+
+ALTER TABLE devices DROP COLUMN IF EXISTS kind;
@@ -0,0 +1,19 @@
+-- Tipo do terminal, que define o que a sua credencial pode fazer
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS kind VARCHAR(20) NOT NULL DEFAULT 'pos';
+
+-- Pareamento com código de uso único; o token só existe depois do pareamento e some na revogação
+ALTER TABLE devices ALTER COLUMN token_hash DROP NOT NULL;
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS pairing_code_hash VARCHAR(64);
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS pairing_expires_at TIMESTAMPTZ;
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS paired_at TIMESTAMPTZ;
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS revoked_at TIMESTAMPTZ;
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS registered_by_id UUID REFERENCES users (id) ON DELETE SET NULL;
+
+-- Último acesso do terminal
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS last_seen_at TIMESTAMPTZ;
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS last_seen_ip VARCHAR(45);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_devices_pairing_code_hash ON devices (pairing_code_hash);
+
+-- Os terminais já cadastrados receberam o token no cadastro e contam como pareados
+UPDATE devices SET paired_at = created_at WHERE paired_at IS NULL;
@@ -0,0 +1 @@
+DELETE FROM role_permissions WHERE permission IN ('cash.manage', 'reservations.write', 'kitchen.bump');
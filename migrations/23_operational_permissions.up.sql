@@ -0,0 +1,9 @@
+-- Permissões das operações do salão, da cozinha e do caixa. Os papéis padrão de admin e gerente
+-- recebem todas; o de funcionário recebe as de reservas e da cozinha, que já usava.
+INSERT INTO role_permissions (role_id, permission)
+SELECT ro.id, p.permission
+FROM roles ro
+CROSS JOIN (VALUES ('cash.manage'), ('reservations.write'), ('kitchen.bump')) AS p (permission)
+WHERE ro.user_type IN ('admin', 'manager')
+   OR (ro.user_type = 'staff' AND p.permission IN ('reservations.write', 'kitchen.bump'))
+ON CONFLICT DO NOTHING;
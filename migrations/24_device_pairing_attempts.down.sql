@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_devices_pairing_selector;
+
+UPDATE devices SET pairing_code_hash = NULL, pairing_expires_at = NULL WHERE pairing_code_hash IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_devices_pairing_code_hash ON devices (pairing_code_hash);
+
+ALTER TABLE devices DROP COLUMN IF EXISTS pairing_attempts;
+ALTER TABLE devices DROP COLUMN IF EXISTS pairing_selector;
@@ -0,0 +1,9 @@
+-- O código de pareamento passa a ser localizado pelo seletor, a sua primeira metade, para que as
+-- tentativas erradas sejam contadas por terminal. Os códigos pendentes deixam de valer.
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS pairing_selector VARCHAR(4);
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS pairing_attempts INTEGER NOT NULL DEFAULT 0;
+
+UPDATE devices SET pairing_code_hash = NULL, pairing_expires_at = NULL WHERE pairing_code_hash IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_devices_pairing_code_hash;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_devices_pairing_selector ON devices (pairing_selector);
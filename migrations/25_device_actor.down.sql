@@ -0,0 +1,6 @@
+ALTER TABLE kitchen_tickets DROP COLUMN IF EXISTS done_device_id;
+ALTER TABLE kitchen_tickets DROP COLUMN IF EXISTS done_by_id;
+
+ALTER TABLE order_status_transitions DROP COLUMN IF EXISTS device_id;
+DELETE FROM order_status_transitions WHERE user_id IS NULL;
+ALTER TABLE order_status_transitions ALTER COLUMN user_id SET NOT NULL;
@@ -0,0 +1,7 @@
+-- As ações feitas pela credencial de um terminal, sem usuário conectado, passam a ser registradas
+-- em nome do próprio terminal em vez de quem o cadastrou.
+ALTER TABLE order_status_transitions ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE order_status_transitions ADD COLUMN IF NOT EXISTS device_id UUID REFERENCES devices (id) ON DELETE SET NULL;
+
+ALTER TABLE kitchen_tickets ADD COLUMN IF NOT EXISTS done_by_id UUID REFERENCES users (id) ON DELETE SET NULL;
+ALTER TABLE kitchen_tickets ADD COLUMN IF NOT EXISTS done_device_id UUID REFERENCES devices (id) ON DELETE SET NULL;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_orders_restaurant_code_period;
+ALTER TABLE orders DROP COLUMN IF EXISTS code_period;
+
+ALTER TABLE order_code_settings DROP COLUMN IF EXISTS timezone;
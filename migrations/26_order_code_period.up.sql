@@ -0,0 +1,6 @@
+-- O dia e o mês dos códigos passam a seguir o fuso configurado pelo restaurante, e cada pedido guarda
+-- o período da sequência que gerou o seu código. O índice garante que um código não se repita no período.
+ALTER TABLE order_code_settings ADD COLUMN IF NOT EXISTS timezone VARCHAR(50) NOT NULL DEFAULT 'UTC';
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS code_period VARCHAR(10);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_restaurant_code_period ON orders (restaurant_id, code_period, code) WHERE code_period IS NOT NULL;
@@ -0,0 +1,8 @@
+// Package migrations contém os arquivos SQL versionados do banco de dados.
+// Os arquivos seguem o padrão NN_nome.up.sql / NN_nome.down.sql e são embutidos no binário.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
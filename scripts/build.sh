@@ -1,3 +1,3 @@
 #!/bin/bash
 # Script simples para construir o projeto
-go build -o main ./cmd/server/main.go
\ No newline at end of file
+go build -o main ./cmd/server
\ No newline at end of file